@@ -0,0 +1,43 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"hash/fnv"
+)
+
+// AdvisoryLockID derives the key of a Postgres advisory lock from its name
+func AdvisoryLockID(name string) int64 {
+	hash := fnv.New64a()
+	hash.Write([]byte(name))
+	return int64(hash.Sum64())
+}
+
+// TryAdvisoryLock runs fn while holding the session advisory lock name. It
+// does not wait for the lock: when another session holds it, fn is skipped
+// and false is returned.
+func TryAdvisoryLock(ctx context.Context, db *sql.DB, name string, fn func(ctx context.Context) error) (acquired bool, err error) {
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to get connection: %w", err)
+	}
+	defer conn.Close()
+
+	lockID := AdvisoryLockID(name)
+	if err := conn.QueryRowContext(ctx, "SELECT pg_try_advisory_lock($1)", lockID).Scan(&acquired); err != nil {
+		return false, fmt.Errorf("failed to acquire lock %s: %w", name, err)
+	}
+	if !acquired {
+		return false, nil
+	}
+	defer func() {
+		// The lock is released with the session if unlocking fails
+		if _, unlockErr := conn.ExecContext(context.Background(), "SELECT pg_advisory_unlock($1)", lockID); unlockErr != nil {
+			err = errors.Join(err, fmt.Errorf("failed to release lock %s: %w", name, unlockErr))
+		}
+	}()
+
+	return true, fn(ctx)
+}
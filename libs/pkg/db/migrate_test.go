@@ -0,0 +1,276 @@
+package db
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+	"testing/fstest"
+	"time"
+)
+
+func TestLoadMigrations(t *testing.T) {
+	fsys := fstest.MapFS{
+		"000002_add_index.up.sql":      {Data: []byte("CREATE INDEX")},
+		"000001_create_table.up.sql":   {Data: []byte("CREATE TABLE")},
+		"000001_create_table.down.sql": {Data: []byte("DROP TABLE")},
+		"README.md":                    {Data: []byte("not a migration")},
+	}
+
+	migrations, err := LoadMigrations(fsys)
+	if err != nil {
+		t.Fatalf("LoadMigrations() error = %v", err)
+	}
+
+	want := []Migration{
+		{Version: 1, Name: "create_table", Up: "CREATE TABLE", Down: "DROP TABLE"},
+		{Version: 2, Name: "add_index", Up: "CREATE INDEX"},
+	}
+	if !reflect.DeepEqual(migrations, want) {
+		t.Errorf("LoadMigrations() = %+v, want %+v", migrations, want)
+	}
+}
+
+func TestLoadMigrationsRejectsInvalidSets(t *testing.T) {
+	tests := []struct {
+		name string
+		fsys fstest.MapFS
+	}{
+		{name: "no up file", fsys: fstest.MapFS{"000001_create_table.down.sql": {}}},
+		{name: "two names", fsys: fstest.MapFS{
+			"000001_create_table.up.sql": {Data: []byte("CREATE TABLE")},
+			"000001_other.down.sql":      {Data: []byte("DROP TABLE")},
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := LoadMigrations(tt.fsys); err == nil {
+				t.Error("LoadMigrations() error = nil, want an error")
+			}
+		})
+	}
+}
+
+// versionsDB is a database holding nothing but the migrations table. It
+// records the migration statements it runs and fails the ones containing
+// failOn.
+type versionsDB struct {
+	versions map[int64]time.Time
+	ran      []string
+	failOn   string
+}
+
+func (d *versionsDB) Connect(context.Context) (driver.Conn, error) { return &versionsConn{db: d}, nil }
+func (d *versionsDB) Driver() driver.Driver                        { return nil }
+
+// versionsConn buffers the changes of a transaction until it commits
+type versionsConn struct {
+	db      *versionsDB
+	ran     []string
+	added   []int64
+	removed []int64
+}
+
+func (c *versionsConn) Prepare(string) (driver.Stmt, error) { return nil, errors.New("not supported") }
+func (c *versionsConn) Close() error                        { return nil }
+func (c *versionsConn) Begin() (driver.Tx, error)           { return c, nil }
+
+func (c *versionsConn) Commit() error {
+	for _, version := range c.added {
+		c.db.versions[version] = time.Now()
+	}
+	for _, version := range c.removed {
+		delete(c.db.versions, version)
+	}
+	c.db.ran = append(c.db.ran, c.ran...)
+	return c.Rollback()
+}
+
+func (c *versionsConn) Rollback() error {
+	c.ran, c.added, c.removed = nil, nil, nil
+	return nil
+}
+
+func (c *versionsConn) ExecContext(_ context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	switch {
+	case strings.Contains(query, "advisory"), strings.HasPrefix(query, "CREATE TABLE IF NOT EXISTS"):
+	case strings.HasPrefix(query, "INSERT INTO schema_versions"):
+		c.added = append(c.added, args[0].Value.(int64))
+	case strings.HasPrefix(query, "DELETE FROM schema_versions"):
+		c.removed = append(c.removed, args[0].Value.(int64))
+	case c.db.failOn != "" && strings.Contains(query, c.db.failOn):
+		return nil, errors.New("syntax error")
+	default:
+		c.ran = append(c.ran, query)
+	}
+	return driver.RowsAffected(1), nil
+}
+
+func (c *versionsConn) QueryContext(context.Context, string, []driver.NamedValue) (driver.Rows, error) {
+	rows := &versionRows{}
+	for version, appliedAt := range c.db.versions {
+		rows.values = append(rows.values, []driver.Value{version, appliedAt})
+	}
+	return rows, nil
+}
+
+type versionRows struct {
+	values [][]driver.Value
+}
+
+func (r *versionRows) Columns() []string { return []string{"version", "applied_at"} }
+func (r *versionRows) Close() error      { return nil }
+
+func (r *versionRows) Next(dest []driver.Value) error {
+	if len(r.values) == 0 {
+		return io.EOF
+	}
+	copy(dest, r.values[0])
+	r.values = r.values[1:]
+	return nil
+}
+
+func newTestMigrator(t *testing.T, versions ...int64) (*Migrator, *versionsDB) {
+	t.Helper()
+
+	fsys := fstest.MapFS{}
+	for _, name := range []string{"000001_one", "000002_two", "000003_three"} {
+		fsys[name+".up.sql"] = &fstest.MapFile{Data: []byte("up " + name)}
+		fsys[name+".down.sql"] = &fstest.MapFile{Data: []byte("down " + name)}
+	}
+
+	state := &versionsDB{versions: make(map[int64]time.Time)}
+	for _, version := range versions {
+		state.versions[version] = time.Now()
+	}
+
+	db := sql.OpenDB(state)
+	t.Cleanup(func() { db.Close() })
+
+	m, err := NewMigrator(db, fsys)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return m, state
+}
+
+func appliedVersions(state *versionsDB) []int64 {
+	versions := make([]int64, 0, len(state.versions))
+	for version := range state.versions {
+		versions = append(versions, version)
+	}
+	sort.Slice(versions, func(i, j int) bool { return versions[i] < versions[j] })
+	return versions
+}
+
+func TestMigrator(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name    string
+		applied []int64
+		run     func(m *Migrator) error
+		wantRan []string
+		want    []int64
+	}{
+		{
+			name:    "up applies pending migrations in order",
+			applied: []int64{1},
+			run:     func(m *Migrator) error { return m.Up(ctx) },
+			wantRan: []string{"up 000002_two", "up 000003_three"},
+			want:    []int64{1, 2, 3},
+		},
+		{
+			name:    "down reverts the last migrations",
+			applied: []int64{1, 2, 3},
+			run:     func(m *Migrator) error { return m.Down(ctx, 2) },
+			wantRan: []string{"down 000003_three", "down 000002_two"},
+			want:    []int64{1},
+		},
+		{
+			name:    "to reverts above the version and applies up to it",
+			applied: []int64{1, 3},
+			run:     func(m *Migrator) error { return m.To(ctx, 2) },
+			wantRan: []string{"down 000003_three", "up 000002_two"},
+			want:    []int64{1, 2},
+		},
+		{
+			name:    "to 0 reverts everything",
+			applied: []int64{1, 2},
+			run:     func(m *Migrator) error { return m.To(ctx, 0) },
+			wantRan: []string{"down 000002_two", "down 000001_one"},
+			want:    []int64{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, state := newTestMigrator(t, tt.applied...)
+
+			if err := tt.run(m); err != nil {
+				t.Fatalf("migration error = %v", err)
+			}
+			if !reflect.DeepEqual(state.ran, tt.wantRan) {
+				t.Errorf("ran %q, want %q", state.ran, tt.wantRan)
+			}
+			if got := appliedVersions(state); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("applied versions = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMigratorStopsAtAFailedMigration(t *testing.T) {
+	m, state := newTestMigrator(t)
+	state.failOn = "000002_two"
+
+	if err := m.Up(context.Background()); err == nil || !strings.Contains(err.Error(), "2_two") {
+		t.Fatalf("Up() error = %v, want the failed migration", err)
+	}
+	if got := appliedVersions(state); !reflect.DeepEqual(got, []int64{1}) {
+		t.Errorf("applied versions = %v, want [1]", got)
+	}
+}
+
+func TestMigratorRejectsUnknownVersions(t *testing.T) {
+	m, _ := newTestMigrator(t)
+	if err := m.To(context.Background(), 4); err == nil {
+		t.Error("To() an unknown version succeeded")
+	}
+}
+
+func TestRunMigrateCommand(t *testing.T) {
+	m, state := newTestMigrator(t, 1)
+
+	var out bytes.Buffer
+	if err := RunMigrateCommand(context.Background(), m, []string{"status"}, &out); err != nil {
+		t.Fatalf("status error = %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 4 || !strings.Contains(lines[2], "two") || !strings.HasSuffix(lines[2], "pending") {
+		t.Errorf("status output = %q", out.String())
+	}
+	if strings.HasSuffix(lines[1], "pending") {
+		t.Errorf("status lists an applied migration as pending: %q", lines[1])
+	}
+
+	for _, args := range [][]string{{}, {"down", "zero"}, {"to"}, {"sideways"}} {
+		if err := RunMigrateCommand(context.Background(), m, args, io.Discard); err == nil {
+			t.Errorf("migrate %q succeeded", args)
+		}
+	}
+
+	if err := RunMigrateCommand(context.Background(), m, []string{"to", "2"}, io.Discard); err != nil {
+		t.Fatalf("migrate to 2 error = %v", err)
+	}
+	if got := appliedVersions(state); !reflect.DeepEqual(got, []int64{1, 2}) {
+		t.Errorf("applied versions = %v, want [1 2]", got)
+	}
+}
@@ -9,9 +9,22 @@ type ErrorCode int
 const (
 	ErrValidation ErrorCode = 400
 	ErrAuth       ErrorCode = 401
+	ErrForbidden  ErrorCode = 403
 	ErrNotFound   ErrorCode = 404
 	ErrConflict   ErrorCode = 409
-	ErrInternal   ErrorCode = 500
+	// ErrPreconditionFailed rejects writes made against a stale version of a
+	// resource
+	ErrPreconditionFailed ErrorCode = 412
+	// ErrPayloadTooLarge rejects request bodies over the size an endpoint
+	// reads
+	ErrPayloadTooLarge ErrorCode = 413
+	// ErrUnsupportedMediaType rejects request bodies of a content type the
+	// endpoint does not accept
+	ErrUnsupportedMediaType ErrorCode = 415
+	// ErrPreconditionRequired rejects writes that do not name the version of
+	// the resource they were made against
+	ErrPreconditionRequired ErrorCode = 428
+	ErrInternal             ErrorCode = 500
 )
 
 type ErrorBuilder interface {
@@ -19,11 +32,15 @@ type ErrorBuilder interface {
 	Unwrap() error
 }
 
+// CustomError is an application error. It serializes as RFC 7807 problem
+// details, see Problem.
 type CustomError struct {
-	Code    ErrorCode      `json:"code"`
-	Context map[string]any `json:"context,omitempty"`
-	Message string         `json:"message"`
-	Orig    error          `json:"-"`
+	Code     ErrorCode
+	Context  map[string]any
+	Message  string
+	Detail   string
+	Instance string
+	Orig     error
 }
 
 func (e *CustomError) Error() string {
@@ -58,6 +75,21 @@ func WithContext(ctx map[string]any) ErrorOption {
 	}
 }
 
+// WithDetail sets an explanation specific to this occurrence of the error
+func WithDetail(detail string) ErrorOption {
+	return func(e *CustomError) {
+		e.Detail = detail
+	}
+}
+
+// WithInstance sets the URI reference of the occurrence, usually the request
+// path
+func WithInstance(instance string) ErrorOption {
+	return func(e *CustomError) {
+		e.Instance = instance
+	}
+}
+
 func WithOriginal(err error) ErrorOption {
 	return func(e *CustomError) {
 		e.Orig = err
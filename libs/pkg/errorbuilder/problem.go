@@ -0,0 +1,188 @@
+package errorbuilder
+
+import (
+	"encoding/json"
+	"mime"
+	"net/http"
+	"strings"
+)
+
+// ProblemContentType is the media type of RFC 7807 problem details
+const ProblemContentType = "application/problem+json"
+
+// problemTypePrefix prefixes the type URI derived from an error message
+const problemTypePrefix = "urn:localloop:problem:"
+
+// BlankProblemType is the type of problems that carry no semantics beyond
+// their HTTP status
+const BlankProblemType = "about:blank"
+
+// Problem is an RFC 7807 problem details object. Extensions are serialized as
+// additional top-level members.
+type Problem struct {
+	Type       string
+	Title      string
+	Status     int
+	Detail     string
+	Instance   string
+	Extensions map[string]any
+}
+
+var problemMembers = map[string]bool{
+	"type":     true,
+	"title":    true,
+	"status":   true,
+	"detail":   true,
+	"instance": true,
+}
+
+func (p Problem) MarshalJSON() ([]byte, error) {
+	members := make(map[string]any, len(p.Extensions)+5)
+	for key, value := range p.Extensions {
+		// Extensions must not shadow the standard members
+		if !problemMembers[key] {
+			members[key] = value
+		}
+	}
+
+	members["type"] = p.Type
+	if p.Type == "" {
+		members["type"] = BlankProblemType
+	}
+	members["title"] = p.Title
+	members["status"] = p.Status
+	if p.Detail != "" {
+		members["detail"] = p.Detail
+	}
+	if p.Instance != "" {
+		members["instance"] = p.Instance
+	}
+
+	return json.Marshal(members)
+}
+
+func (p *Problem) UnmarshalJSON(data []byte) error {
+	var members map[string]json.RawMessage
+	if err := json.Unmarshal(data, &members); err != nil {
+		return err
+	}
+
+	*p = Problem{}
+	for key, raw := range members {
+		var err error
+		switch key {
+		case "type":
+			err = json.Unmarshal(raw, &p.Type)
+		case "title":
+			err = json.Unmarshal(raw, &p.Title)
+		case "status":
+			err = json.Unmarshal(raw, &p.Status)
+		case "detail":
+			err = json.Unmarshal(raw, &p.Detail)
+		case "instance":
+			err = json.Unmarshal(raw, &p.Instance)
+		default:
+			var value any
+			if err = json.Unmarshal(raw, &value); err == nil {
+				if p.Extensions == nil {
+					p.Extensions = make(map[string]any)
+				}
+				p.Extensions[key] = value
+			}
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Problem describes the error as problem details. The type is derived from
+// the message, which is shared by every occurrence of the error.
+func (e *CustomError) Problem() Problem {
+	return Problem{
+		Type:       ProblemType(e.Message),
+		Title:      e.Message,
+		Status:     int(e.Code),
+		Detail:     e.Detail,
+		Instance:   e.Instance,
+		Extensions: e.Context,
+	}
+}
+
+func (e *CustomError) MarshalJSON() ([]byte, error) {
+	return json.Marshal(e.Problem())
+}
+
+func (e *CustomError) UnmarshalJSON(data []byte) error {
+	var problem Problem
+	if err := json.Unmarshal(data, &problem); err != nil {
+		return err
+	}
+	*e = *FromProblem(problem)
+	return nil
+}
+
+// FromProblem converts problem details back into an error
+func FromProblem(p Problem) *CustomError {
+	context := p.Extensions
+	if context == nil {
+		context = make(map[string]any)
+	}
+
+	message := p.Title
+	if message == "" {
+		message = strings.ToLower(http.StatusText(p.Status))
+	}
+
+	return &CustomError{
+		Code:     ErrorCode(p.Status),
+		Context:  context,
+		Message:  message,
+		Detail:   p.Detail,
+		Instance: p.Instance,
+	}
+}
+
+// ProblemType returns the type URI of errors with the given message, e.g.
+// "urn:localloop:problem:category-not-found" for "category not found"
+func ProblemType(message string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(message) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			if dash && b.Len() > 0 {
+				b.WriteByte('-')
+			}
+			b.WriteRune(r)
+			dash = false
+		} else {
+			dash = true
+		}
+	}
+	if b.Len() == 0 {
+		return BlankProblemType
+	}
+	return problemTypePrefix + b.String()
+}
+
+// FromResponse reads the problem details of a failed HTTP response. Bodies of
+// another media type yield an error carrying only the response status.
+func FromResponse(resp *http.Response) *CustomError {
+	problem := Problem{Status: resp.StatusCode}
+
+	mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	if mediaType == ProblemContentType {
+		var decoded Problem
+		if err := json.NewDecoder(resp.Body).Decode(&decoded); err == nil {
+			problem = decoded
+		}
+	}
+
+	if problem.Status == 0 {
+		problem.Status = resp.StatusCode
+	}
+
+	return FromProblem(problem)
+}
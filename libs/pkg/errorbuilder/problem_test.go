@@ -0,0 +1,124 @@
+package errorbuilder
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestProblemType(t *testing.T) {
+	tests := map[string]string{
+		"category not found":           "urn:localloop:problem:category-not-found",
+		"If-Match header is required!": "urn:localloop:problem:if-match-header-is-required",
+		"  ":                           BlankProblemType,
+	}
+
+	for message, want := range tests {
+		if got := ProblemType(message); got != want {
+			t.Errorf("ProblemType(%q) = %s, want %s", message, got, want)
+		}
+	}
+}
+
+func TestProblemJSON(t *testing.T) {
+	problem := Problem{
+		Type:   "urn:localloop:problem:category-not-found",
+		Title:  "category not found",
+		Status: 404,
+		Extensions: map[string]any{
+			"category": "bikes",
+			"status":   "shadowed",
+		},
+	}
+
+	data, err := json.Marshal(problem)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var members map[string]any
+	if err := json.Unmarshal(data, &members); err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]any{
+		"type":     "urn:localloop:problem:category-not-found",
+		"title":    "category not found",
+		"status":   float64(404),
+		"category": "bikes",
+	}
+	if !reflect.DeepEqual(members, want) {
+		t.Errorf("Marshal() = %s, want %v", data, want)
+	}
+
+	var decoded Problem
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	problem.Extensions = map[string]any{"category": "bikes"}
+	if !reflect.DeepEqual(decoded, problem) {
+		t.Errorf("Unmarshal() = %+v, want %+v", decoded, problem)
+	}
+}
+
+func TestCustomErrorRoundTrip(t *testing.T) {
+	err := NewError("category not found", ErrNotFound)(
+		WithDetail("no category with ID 42"),
+		WithInstance("/categories/42"),
+		WithContext(map[string]any{"category": "42"}),
+	)
+
+	data, marshalErr := json.Marshal(err)
+	if marshalErr != nil {
+		t.Fatal(marshalErr)
+	}
+	var decoded CustomError
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(&decoded, err) {
+		t.Errorf("round trip = %+v, want %+v", decoded, err)
+	}
+}
+
+func TestFromResponse(t *testing.T) {
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+		want        *CustomError
+	}{
+		{
+			name:        "problem details",
+			contentType: ProblemContentType + "; charset=utf-8",
+			body:        `{"type":"urn:localloop:problem:category-not-found","title":"category not found","status":404,"category":"42"}`,
+			want:        &CustomError{Code: ErrNotFound, Message: "category not found", Context: map[string]any{"category": "42"}},
+		},
+		{
+			name:        "problem without a status",
+			contentType: ProblemContentType,
+			body:        `{"title":"category not found"}`,
+			want:        &CustomError{Code: ErrNotFound, Message: "category not found", Context: map[string]any{}},
+		},
+		{
+			name:        "other media type",
+			contentType: "text/html",
+			body:        "<h1>Not Found</h1>",
+			want:        &CustomError{Code: ErrNotFound, Message: "not found", Context: map[string]any{}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := &http.Response{
+				StatusCode: http.StatusNotFound,
+				Header:     http.Header{"Content-Type": {tt.contentType}},
+				Body:       io.NopCloser(strings.NewReader(tt.body)),
+			}
+			if got := FromResponse(resp); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("FromResponse() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,737 @@
+// Package jsonschema validates decoded JSON documents against JSON Schemas,
+// as the catalog does for field type properties and the listing service for
+// custom field values
+package jsonschema
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Violation describes a single place where a document does not conform
+// to a JSON Schema. Path is a JSON pointer (RFC 6901) into the document.
+type Violation struct {
+	Path    string `json:"path"`
+	Keyword string `json:"keyword"`
+	Message string `json:"message"`
+}
+
+// Validate validates a decoded JSON document against a JSON Schema.
+//
+// It supports the subset of draft 2020-12 that makes sense for field type
+// properties and field values: type, enum, const, the numeric, string, array
+// and object assertions, allOf/anyOf/oneOf/not, if/then/else and local $ref
+// pointers into the same schema (e.g. "#/$defs/option"). Annotation-only
+// keywords such as format, title or description are ignored, anchors are
+// not supported.
+//
+// All violations are collected rather than stopping at the first one. An
+// error is returned only if the schema itself is malformed.
+func Validate(schema map[string]interface{}, document interface{}) ([]Violation, error) {
+	v := newSchemaValidator(schema)
+	violations := v.validate(schema, document, "", 0)
+	if v.err != nil {
+		return nil, v.err
+	}
+	return violations, nil
+}
+
+// Check reports whether a JSON Schema is well formed, i.e. keywords
+// carry values of the expected type, patterns compile and references resolve.
+// References that lead back to themselves without descending into the
+// document are rejected, validating against them would never end.
+func Check(schema map[string]interface{}) error {
+	v := newSchemaValidator(schema)
+	v.check(schema, "", 0)
+	return v.err
+}
+
+// maxSchemaDepth guards against self-referencing schemas
+const maxSchemaDepth = 64
+
+var schemaTypes = map[string]bool{
+	"null": true, "boolean": true, "object": true, "array": true,
+	"number": true, "integer": true, "string": true,
+}
+
+// refState tracks the references Check follows
+type refState int
+
+const (
+	refUnchecked refState = iota
+	refChecking
+	refChecked
+)
+
+type schemaValidator struct {
+	root    map[string]interface{}
+	regexps map[string]*regexp.Regexp
+	// refs holds the state of each reference for check, and the references
+	// being applied at a document path for validate
+	refs map[string]refState
+	err  error
+}
+
+func newSchemaValidator(root map[string]interface{}) *schemaValidator {
+	return &schemaValidator{
+		root:    root,
+		regexps: make(map[string]*regexp.Regexp),
+		refs:    make(map[string]refState),
+	}
+}
+
+func (v *schemaValidator) fail(schemaPath, format string, args ...any) {
+	if v.err == nil {
+		v.err = fmt.Errorf("invalid schema at %q: %s", schemaPath, fmt.Sprintf(format, args...))
+	}
+}
+
+func (v *schemaValidator) validate(schema interface{}, doc interface{}, path string, depth int) []Violation {
+	if v.err != nil {
+		return nil
+	}
+	if depth > maxSchemaDepth {
+		v.fail(path, "maximum nesting depth exceeded")
+		return nil
+	}
+
+	switch s := schema.(type) {
+	case bool:
+		if !s {
+			return []Violation{violation(path, "false", "no value is allowed here")}
+		}
+		return nil
+	case map[string]interface{}:
+		return v.validateObject(s, doc, path, depth)
+	default:
+		v.fail(path, "schema must be an object or a boolean")
+		return nil
+	}
+}
+
+func (v *schemaValidator) validateObject(s map[string]interface{}, doc interface{}, path string, depth int) []Violation {
+	var out []Violation
+
+	if ref, ok := s["$ref"]; ok {
+		target := v.resolve(ref, path)
+		if target != nil {
+			// A reference applied again at the same path would recurse forever
+			key := fmt.Sprintf("%v@%s", ref, path)
+			if v.refs[key] == refChecking {
+				v.fail(path, "$ref %q refers back to itself", ref)
+				return nil
+			}
+			v.refs[key] = refChecking
+			out = append(out, v.validate(target, doc, path, depth+1)...)
+			delete(v.refs, key)
+		}
+	}
+
+	typeMatches := true
+	if t, ok := s["type"]; ok {
+		types := v.typeList(t, path)
+		if len(types) > 0 && !matchesAnyType(doc, types) {
+			typeMatches = false
+			out = append(out, violation(path, "type", fmt.Sprintf("expected %s, got %s", strings.Join(types, " or "), jsonType(doc))))
+		}
+	}
+
+	if enum, ok := s["enum"]; ok {
+		values, isArray := enum.([]interface{})
+		if !isArray {
+			v.fail(path, "enum must be an array")
+			return nil
+		}
+		found := false
+		for _, value := range values {
+			if jsonEqual(value, doc) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			out = append(out, violation(path, "enum", "value is not one of the allowed values"))
+		}
+	}
+
+	if c, ok := s["const"]; ok && !jsonEqual(c, doc) {
+		out = append(out, violation(path, "const", fmt.Sprintf("value must be %v", c)))
+	}
+
+	// Type-specific assertions would only add noise to a type mismatch, the
+	// combinators still apply
+	if typeMatches {
+		switch d := doc.(type) {
+		case float64:
+			out = append(out, v.validateNumber(s, d, path)...)
+		case string:
+			out = append(out, v.validateString(s, d, path)...)
+		case []interface{}:
+			out = append(out, v.validateArray(s, d, path, depth)...)
+		case map[string]interface{}:
+			out = append(out, v.validateProperties(s, d, path, depth)...)
+		}
+	}
+
+	out = append(out, v.validateCombinators(s, doc, path, depth)...)
+	return out
+}
+
+func (v *schemaValidator) validateNumber(s map[string]interface{}, d float64, path string) []Violation {
+	var out []Violation
+
+	if n, ok := v.number(s, "minimum", path); ok && d < n {
+		out = append(out, violation(path, "minimum", fmt.Sprintf("must be >= %v", n)))
+	}
+	if n, ok := v.number(s, "maximum", path); ok && d > n {
+		out = append(out, violation(path, "maximum", fmt.Sprintf("must be <= %v", n)))
+	}
+	if n, ok := v.number(s, "exclusiveMinimum", path); ok && d <= n {
+		out = append(out, violation(path, "exclusiveMinimum", fmt.Sprintf("must be > %v", n)))
+	}
+	if n, ok := v.number(s, "exclusiveMaximum", path); ok && d >= n {
+		out = append(out, violation(path, "exclusiveMaximum", fmt.Sprintf("must be < %v", n)))
+	}
+	if n, ok := v.number(s, "multipleOf", path); ok {
+		if n <= 0 {
+			v.fail(path, "multipleOf must be greater than 0")
+		} else if q := d / n; math.Abs(q-math.Round(q)) > 1e-9 {
+			out = append(out, violation(path, "multipleOf", fmt.Sprintf("must be a multiple of %v", n)))
+		}
+	}
+
+	return out
+}
+
+func (v *schemaValidator) validateString(s map[string]interface{}, d string, path string) []Violation {
+	var out []Violation
+	length := utf8.RuneCountInString(d)
+
+	if n, ok := v.count(s, "minLength", path); ok && length < n {
+		out = append(out, violation(path, "minLength", fmt.Sprintf("must be at least %d characters", n)))
+	}
+	if n, ok := v.count(s, "maxLength", path); ok && length > n {
+		out = append(out, violation(path, "maxLength", fmt.Sprintf("must be at most %d characters", n)))
+	}
+	if p, ok := s["pattern"]; ok {
+		if re := v.regexp(p, path); re != nil && !re.MatchString(d) {
+			out = append(out, violation(path, "pattern", fmt.Sprintf("must match pattern %q", re.String())))
+		}
+	}
+
+	return out
+}
+
+func (v *schemaValidator) validateArray(s map[string]interface{}, d []interface{}, path string, depth int) []Violation {
+	var out []Violation
+
+	if n, ok := v.count(s, "minItems", path); ok && len(d) < n {
+		out = append(out, violation(path, "minItems", fmt.Sprintf("must contain at least %d items", n)))
+	}
+	if n, ok := v.count(s, "maxItems", path); ok && len(d) > n {
+		out = append(out, violation(path, "maxItems", fmt.Sprintf("must contain at most %d items", n)))
+	}
+	if unique, ok := s["uniqueItems"].(bool); ok && unique {
+		for i := 0; i < len(d); i++ {
+			for j := i + 1; j < len(d); j++ {
+				if jsonEqual(d[i], d[j]) {
+					out = append(out, violation(pointer(path, strconv.Itoa(j)), "uniqueItems", fmt.Sprintf("duplicates item %d", i)))
+				}
+			}
+		}
+	}
+
+	prefixLen := 0
+	if p, ok := s["prefixItems"]; ok {
+		prefix, isArray := p.([]interface{})
+		if !isArray {
+			v.fail(path, "prefixItems must be an array")
+			return nil
+		}
+		prefixLen = len(prefix)
+		for i := 0; i < len(prefix) && i < len(d); i++ {
+			out = append(out, v.validate(prefix[i], d[i], pointer(path, strconv.Itoa(i)), depth+1)...)
+		}
+	}
+
+	if items, ok := s["items"]; ok {
+		for i := prefixLen; i < len(d); i++ {
+			out = append(out, v.validate(items, d[i], pointer(path, strconv.Itoa(i)), depth+1)...)
+		}
+	}
+
+	if contains, ok := s["contains"]; ok {
+		matches := 0
+		for i := range d {
+			if len(v.validate(contains, d[i], pointer(path, strconv.Itoa(i)), depth+1)) == 0 {
+				matches++
+			}
+		}
+		min := 1
+		if n, ok := v.count(s, "minContains", path); ok {
+			min = n
+		}
+		if matches < min {
+			out = append(out, violation(path, "contains", fmt.Sprintf("must contain at least %d matching items", min)))
+		}
+		if n, ok := v.count(s, "maxContains", path); ok && matches > n {
+			out = append(out, violation(path, "maxContains", fmt.Sprintf("must contain at most %d matching items", n)))
+		}
+	}
+
+	return out
+}
+
+func (v *schemaValidator) validateProperties(s map[string]interface{}, d map[string]interface{}, path string, depth int) []Violation {
+	var out []Violation
+
+	if n, ok := v.count(s, "minProperties", path); ok && len(d) < n {
+		out = append(out, violation(path, "minProperties", fmt.Sprintf("must have at least %d properties", n)))
+	}
+	if n, ok := v.count(s, "maxProperties", path); ok && len(d) > n {
+		out = append(out, violation(path, "maxProperties", fmt.Sprintf("must have at most %d properties", n)))
+	}
+
+	if r, ok := s["required"]; ok {
+		required, isArray := r.([]interface{})
+		if !isArray {
+			v.fail(path, "required must be an array")
+			return nil
+		}
+		for _, name := range required {
+			key, isString := name.(string)
+			if !isString {
+				v.fail(path, "required must contain strings")
+				return nil
+			}
+			if _, present := d[key]; !present {
+				out = append(out, violation(pointer(path, key), "required", "property is required"))
+			}
+		}
+	}
+
+	properties, _ := s["properties"].(map[string]interface{})
+	patternProperties, _ := s["patternProperties"].(map[string]interface{})
+
+	// Iterate in a stable order so violations are deterministic
+	keys := make([]string, 0, len(d))
+	for key := range d {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		value := d[key]
+		childPath := pointer(path, key)
+		evaluated := false
+
+		if sub, ok := properties[key]; ok {
+			evaluated = true
+			out = append(out, v.validate(sub, value, childPath, depth+1)...)
+		}
+
+		for pattern, sub := range patternProperties {
+			if re := v.regexp(pattern, path); re != nil && re.MatchString(key) {
+				evaluated = true
+				out = append(out, v.validate(sub, value, childPath, depth+1)...)
+			}
+		}
+
+		if additional, ok := s["additionalProperties"]; ok && !evaluated {
+			if allowed, isBool := additional.(bool); isBool && !allowed {
+				out = append(out, violation(childPath, "additionalProperties", "property is not allowed"))
+			} else if !isBool {
+				out = append(out, v.validate(additional, value, childPath, depth+1)...)
+			}
+		}
+
+		if names, ok := s["propertyNames"]; ok {
+			if len(v.validate(names, key, childPath, depth+1)) > 0 {
+				out = append(out, violation(childPath, "propertyNames", "property name is not allowed"))
+			}
+		}
+	}
+
+	return out
+}
+
+func (v *schemaValidator) validateCombinators(s map[string]interface{}, doc interface{}, path string, depth int) []Violation {
+	var out []Violation
+
+	if allOf, ok := s["allOf"]; ok {
+		for _, sub := range v.schemaList(allOf, "allOf", path) {
+			out = append(out, v.validate(sub, doc, path, depth+1)...)
+		}
+	}
+
+	if anyOf, ok := s["anyOf"]; ok {
+		matched := false
+		for _, sub := range v.schemaList(anyOf, "anyOf", path) {
+			if len(v.validate(sub, doc, path, depth+1)) == 0 {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			out = append(out, violation(path, "anyOf", "value does not match any of the allowed schemas"))
+		}
+	}
+
+	if oneOf, ok := s["oneOf"]; ok {
+		matches := 0
+		for _, sub := range v.schemaList(oneOf, "oneOf", path) {
+			if len(v.validate(sub, doc, path, depth+1)) == 0 {
+				matches++
+			}
+		}
+		if matches != 1 {
+			out = append(out, violation(path, "oneOf", fmt.Sprintf("value must match exactly one schema, matched %d", matches)))
+		}
+	}
+
+	if not, ok := s["not"]; ok && len(v.validate(not, doc, path, depth+1)) == 0 {
+		out = append(out, violation(path, "not", "value must not match the schema"))
+	}
+
+	if cond, ok := s["if"]; ok {
+		if len(v.validate(cond, doc, path, depth+1)) == 0 {
+			if then, ok := s["then"]; ok {
+				out = append(out, v.validate(then, doc, path, depth+1)...)
+			}
+		} else if els, ok := s["else"]; ok {
+			out = append(out, v.validate(els, doc, path, depth+1)...)
+		}
+	}
+
+	return out
+}
+
+// check walks every subschema and reports the first malformed keyword.
+func (v *schemaValidator) check(schema interface{}, path string, depth int) {
+	if v.err != nil {
+		return
+	}
+	if depth > maxSchemaDepth {
+		v.fail(path, "maximum nesting depth exceeded")
+		return
+	}
+
+	s, ok := schema.(map[string]interface{})
+	if !ok {
+		if _, isBool := schema.(bool); !isBool {
+			v.fail(path, "schema must be an object or a boolean")
+		}
+		return
+	}
+
+	if ref, ok := s["$ref"]; ok {
+		v.checkRef(ref, path)
+	}
+	if t, ok := s["type"]; ok {
+		v.typeList(t, path)
+	}
+	if enum, ok := s["enum"]; ok {
+		if _, isArray := enum.([]interface{}); !isArray {
+			v.fail(path, "enum must be an array")
+		}
+	}
+	for _, keyword := range []string{"minimum", "maximum", "exclusiveMinimum", "exclusiveMaximum", "multipleOf"} {
+		v.number(s, keyword, path)
+	}
+	for _, keyword := range []string{"minLength", "maxLength", "minItems", "maxItems", "minContains", "maxContains", "minProperties", "maxProperties"} {
+		v.count(s, keyword, path)
+	}
+	if p, ok := s["pattern"]; ok {
+		v.regexp(p, path)
+	}
+	if r, ok := s["required"]; ok {
+		required, isArray := r.([]interface{})
+		if !isArray {
+			v.fail(path, "required must be an array")
+		}
+		for _, name := range required {
+			if _, isString := name.(string); !isString {
+				v.fail(path, "required must contain strings")
+			}
+		}
+	}
+
+	for _, keyword := range []string{"items", "contains", "additionalProperties", "propertyNames", "not", "if", "then", "else"} {
+		if sub, ok := s[keyword]; ok {
+			v.check(sub, pointer(path, keyword), depth+1)
+		}
+	}
+	for _, keyword := range []string{"prefixItems", "allOf", "anyOf", "oneOf"} {
+		if list, ok := s[keyword]; ok {
+			for i, sub := range v.schemaList(list, keyword, path) {
+				v.check(sub, pointer(pointer(path, keyword), strconv.Itoa(i)), depth+1)
+			}
+		}
+	}
+	for _, keyword := range []string{"properties", "patternProperties", "$defs"} {
+		if m, ok := s[keyword]; ok {
+			subs, isObject := m.(map[string]interface{})
+			if !isObject {
+				v.fail(path, "%s must be an object", keyword)
+				continue
+			}
+			for name, sub := range subs {
+				if keyword == "patternProperties" {
+					v.regexp(name, path)
+				}
+				v.check(sub, pointer(pointer(path, keyword), name), depth+1)
+			}
+		}
+	}
+}
+
+// checkRef resolves a reference and follows it through the keywords that
+// apply to the same document value, failing if they lead back to it. Each
+// reference is followed once.
+func (v *schemaValidator) checkRef(ref interface{}, path string) {
+	target := v.resolve(ref, path)
+	if target == nil {
+		return
+	}
+
+	key := ref.(string)
+	switch v.refs[key] {
+	case refChecking:
+		v.fail(path, "$ref %q refers back to itself without descending into the document", key)
+		return
+	case refChecked:
+		return
+	}
+
+	v.refs[key] = refChecking
+	v.checkInPlace(target, path, 0)
+	v.refs[key] = refChecked
+}
+
+// checkInPlace follows the references of the subschemas that apply to the
+// same document value as schema
+func (v *schemaValidator) checkInPlace(schema interface{}, path string, depth int) {
+	s, ok := schema.(map[string]interface{})
+	if !ok || v.err != nil {
+		return
+	}
+	if depth > maxSchemaDepth {
+		v.fail(path, "maximum nesting depth exceeded")
+		return
+	}
+
+	if ref, ok := s["$ref"]; ok {
+		v.checkRef(ref, path)
+	}
+	for _, keyword := range []string{"not", "if", "then", "else"} {
+		if sub, ok := s[keyword]; ok {
+			v.checkInPlace(sub, pointer(path, keyword), depth+1)
+		}
+	}
+	for _, keyword := range []string{"allOf", "anyOf", "oneOf"} {
+		if list, ok := s[keyword].([]interface{}); ok {
+			for i, sub := range list {
+				v.checkInPlace(sub, pointer(pointer(path, keyword), strconv.Itoa(i)), depth+1)
+			}
+		}
+	}
+}
+
+// resolve looks up a local "#/..." reference in the root schema.
+func (v *schemaValidator) resolve(ref interface{}, path string) interface{} {
+	r, ok := ref.(string)
+	if !ok || !strings.HasPrefix(r, "#") {
+		v.fail(path, "only local $ref values are supported")
+		return nil
+	}
+	if r != "#" && !strings.HasPrefix(r, "#/") {
+		v.fail(path, "$ref %q must be a JSON pointer, anchors are not supported", r)
+		return nil
+	}
+
+	var current interface{} = v.root
+	for _, token := range strings.Split(strings.TrimPrefix(r, "#"), "/")[1:] {
+		token = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+		switch c := current.(type) {
+		case map[string]interface{}:
+			current, ok = c[token]
+		case []interface{}:
+			i, err := strconv.Atoi(token)
+			ok = err == nil && i >= 0 && i < len(c)
+			if ok {
+				current = c[i]
+			}
+		default:
+			ok = false
+		}
+		if !ok {
+			v.fail(path, "unresolvable $ref %q", r)
+			return nil
+		}
+	}
+	return current
+}
+
+func (v *schemaValidator) typeList(t interface{}, path string) []string {
+	var types []string
+	switch tt := t.(type) {
+	case string:
+		types = []string{tt}
+	case []interface{}:
+		for _, item := range tt {
+			name, ok := item.(string)
+			if !ok {
+				v.fail(path, "type must contain strings")
+				return nil
+			}
+			types = append(types, name)
+		}
+	default:
+		v.fail(path, "type must be a string or an array")
+		return nil
+	}
+
+	for _, name := range types {
+		if !schemaTypes[name] {
+			v.fail(path, "unknown type %q", name)
+			return nil
+		}
+	}
+	return types
+}
+
+func (v *schemaValidator) schemaList(list interface{}, keyword, path string) []interface{} {
+	subs, ok := list.([]interface{})
+	if !ok || len(subs) == 0 {
+		v.fail(path, "%s must be a non-empty array", keyword)
+		return nil
+	}
+	return subs
+}
+
+func (v *schemaValidator) number(s map[string]interface{}, keyword, path string) (float64, bool) {
+	raw, ok := s[keyword]
+	if !ok {
+		return 0, false
+	}
+	n, ok := raw.(float64)
+	if !ok {
+		v.fail(path, "%s must be a number", keyword)
+		return 0, false
+	}
+	return n, true
+}
+
+func (v *schemaValidator) count(s map[string]interface{}, keyword, path string) (int, bool) {
+	n, ok := v.number(s, keyword, path)
+	if !ok {
+		return 0, false
+	}
+	if n < 0 || n != math.Trunc(n) {
+		v.fail(path, "%s must be a non-negative integer", keyword)
+		return 0, false
+	}
+	return int(n), true
+}
+
+func (v *schemaValidator) regexp(pattern interface{}, path string) *regexp.Regexp {
+	p, ok := pattern.(string)
+	if !ok {
+		v.fail(path, "pattern must be a string")
+		return nil
+	}
+	if re, ok := v.regexps[p]; ok {
+		return re
+	}
+	re, err := regexp.Compile(p)
+	if err != nil {
+		v.fail(path, "invalid pattern %q: %v", p, err)
+		return nil
+	}
+	v.regexps[p] = re
+	return re
+}
+
+func violation(path, keyword, message string) Violation {
+	if path == "" {
+		path = "/"
+	}
+	return Violation{Path: path, Keyword: keyword, Message: message}
+}
+
+// pointer appends an escaped reference token to a JSON pointer
+func pointer(path, token string) string {
+	token = strings.ReplaceAll(token, "~", "~0")
+	token = strings.ReplaceAll(token, "/", "~1")
+	return path + "/" + token
+}
+
+func jsonType(doc interface{}) string {
+	switch d := doc.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case float64:
+		if d == math.Trunc(d) {
+			return "integer"
+		}
+		return "number"
+	case string:
+		return "string"
+	case []interface{}:
+		return "array"
+	case map[string]interface{}:
+		return "object"
+	default:
+		return fmt.Sprintf("%T", doc)
+	}
+}
+
+func matchesAnyType(doc interface{}, types []string) bool {
+	actual := jsonType(doc)
+	for _, t := range types {
+		if t == actual || (t == "number" && actual == "integer") {
+			return true
+		}
+	}
+	return false
+}
+
+// jsonEqual compares two decoded JSON values structurally
+func jsonEqual(a, b interface{}) bool {
+	switch av := a.(type) {
+	case []interface{}:
+		bv, ok := b.([]interface{})
+		if !ok || len(av) != len(bv) {
+			return false
+		}
+		for i := range av {
+			if !jsonEqual(av[i], bv[i]) {
+				return false
+			}
+		}
+		return true
+	case map[string]interface{}:
+		bv, ok := b.(map[string]interface{})
+		if !ok || len(av) != len(bv) {
+			return false
+		}
+		for key, value := range av {
+			other, ok := bv[key]
+			if !ok || !jsonEqual(value, other) {
+				return false
+			}
+		}
+		return true
+	case nil, bool, float64, string:
+		return a == b
+	default:
+		return false
+	}
+}
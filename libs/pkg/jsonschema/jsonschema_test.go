@@ -0,0 +1,183 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func decodeJSON(t *testing.T, raw string) interface{} {
+	t.Helper()
+
+	var v interface{}
+	if err := json.Unmarshal([]byte(raw), &v); err != nil {
+		t.Fatalf("invalid JSON %s: %v", raw, err)
+	}
+	return v
+}
+
+func TestValidateSchema(t *testing.T) {
+	tests := []struct {
+		name     string
+		schema   string
+		document string
+		// want lists the path and keyword of each violation
+		want []string
+	}{
+		{
+			name:     "valid object",
+			schema:   `{"type": "object", "properties": {"size": {"type": "integer", "minimum": 1}}, "required": ["size"]}`,
+			document: `{"size": 3}`,
+		},
+		{
+			name:     "missing and invalid properties",
+			schema:   `{"type": "object", "properties": {"size": {"type": "integer", "minimum": 1}, "color": {"enum": ["red", "blue"]}}, "required": ["size"]}`,
+			document: `{"color": "green"}`,
+			want:     []string{"/size required", "/color enum"},
+		},
+		{
+			name:     "additional properties",
+			schema:   `{"type": "object", "additionalProperties": false}`,
+			document: `{"extra": 1}`,
+			want:     []string{"/extra additionalProperties"},
+		},
+		{
+			name:     "string assertions",
+			schema:   `{"type": "string", "minLength": 2, "pattern": "^[a-z]+$"}`,
+			document: `"A"`,
+			want:     []string{"/ minLength", "/ pattern"},
+		},
+		{
+			name:     "array items",
+			schema:   `{"type": "array", "items": {"type": "string"}, "uniqueItems": true}`,
+			document: `["a", 1, "a"]`,
+			want:     []string{"/2 uniqueItems", "/1 type"},
+		},
+		{
+			name:     "ref into defs",
+			schema:   `{"$defs": {"size": {"type": "integer"}}, "properties": {"size": {"$ref": "#/$defs/size"}}}`,
+			document: `{"size": "large"}`,
+			want:     []string{"/size type"},
+		},
+		{
+			name:     "recursive ref through properties",
+			schema:   `{"type": "object", "properties": {"name": {"type": "string"}, "child": {"$ref": "#"}}}`,
+			document: `{"name": "a", "child": {"name": "b", "child": {"name": 3}}}`,
+			want:     []string{"/child/child/name type"},
+		},
+		{
+			name:     "anyOf matches",
+			schema:   `{"anyOf": [{"type": "string"}, {"type": "integer"}]}`,
+			document: `3`,
+		},
+		{
+			name:     "anyOf fails",
+			schema:   `{"anyOf": [{"type": "string"}, {"type": "integer"}]}`,
+			document: `true`,
+			want:     []string{"/ anyOf"},
+		},
+		{
+			name:     "oneOf matching twice",
+			schema:   `{"oneOf": [{"type": "number"}, {"type": "integer"}]}`,
+			document: `3`,
+			want:     []string{"/ oneOf"},
+		},
+		{
+			name:     "not",
+			schema:   `{"not": {"const": "used"}}`,
+			document: `"used"`,
+			want:     []string{"/ not"},
+		},
+		{
+			name:     "if then else",
+			schema:   `{"if": {"properties": {"kind": {"const": "shoe"}}}, "then": {"required": ["size"]}, "else": {"required": ["length"]}}`,
+			document: `{"kind": "shoe"}`,
+			want:     []string{"/size required"},
+		},
+		{
+			name:     "combinators apply on a type mismatch",
+			schema:   `{"type": "string", "not": {"type": "integer"}}`,
+			document: `3`,
+			want:     []string{"/ type", "/ not"},
+		},
+		{
+			name:     "type-specific assertions are skipped on a type mismatch",
+			schema:   `{"type": "integer", "minimum": 10}`,
+			document: `1.5`,
+			want:     []string{"/ type"},
+		},
+		{
+			name:     "false schema",
+			schema:   `{"properties": {"locked": false}}`,
+			document: `{"locked": 1}`,
+			want:     []string{"/locked false"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schema := decodeJSON(t, tt.schema).(map[string]interface{})
+			violations, err := Validate(schema, decodeJSON(t, tt.document))
+			if err != nil {
+				t.Fatalf("Validate() error = %v", err)
+			}
+
+			got := make([]string, len(violations))
+			for i, v := range violations {
+				got[i] = v.Path + " " + v.Keyword
+			}
+			if strings.Join(got, ", ") != strings.Join(tt.want, ", ") {
+				t.Errorf("violations = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateSchemaStopsOnSelfReference(t *testing.T) {
+	// Every branch applies the same schema to the same value again
+	schema := decodeJSON(t, `{"$defs": {"a": {"anyOf": [{"$ref": "#/$defs/a"}, {"$ref": "#/$defs/a"}]}}, "$ref": "#/$defs/a"}`)
+
+	if _, err := Validate(schema.(map[string]interface{}), "x"); err == nil {
+		t.Fatal("Validate() error = nil, want an error")
+	}
+}
+
+func TestCheckSchema(t *testing.T) {
+	tests := []struct {
+		name    string
+		schema  string
+		wantErr string
+	}{
+		{name: "valid", schema: `{"type": "object", "properties": {"size": {"type": "integer"}}}`},
+		{name: "ref into defs", schema: `{"$defs": {"size": {"type": "integer"}}, "properties": {"size": {"$ref": "#/$defs/size"}}}`},
+		{name: "ref to the root", schema: `{"properties": {"child": {"$ref": "#"}}}`},
+		{name: "recursion through items", schema: `{"$defs": {"list": {"anyOf": [{"type": "string"}, {"type": "array", "items": {"$ref": "#/$defs/list"}}]}}, "$ref": "#/$defs/list"}`},
+		{name: "shared ref", schema: `{"$defs": {"s": {"type": "string"}}, "allOf": [{"$ref": "#/$defs/s"}, {"$ref": "#/$defs/s"}]}`},
+		{name: "unknown type", schema: `{"type": "text"}`, wantErr: "unknown type"},
+		{name: "bad pattern", schema: `{"pattern": "("}`, wantErr: "invalid pattern"},
+		{name: "negative count", schema: `{"minLength": -1}`, wantErr: "non-negative integer"},
+		{name: "empty combinator", schema: `{"anyOf": []}`, wantErr: "non-empty array"},
+		{name: "unresolvable ref", schema: `{"$ref": "#/$defs/missing"}`, wantErr: "unresolvable"},
+		{name: "remote ref", schema: `{"$ref": "https://example.com/schema"}`, wantErr: "only local"},
+		{name: "anchor ref", schema: `{"$defs": {"a": {"$anchor": "a"}}, "$ref": "#a"}`, wantErr: "anchors are not supported"},
+		{name: "self reference", schema: `{"$defs": {"a": {"$ref": "#/$defs/a"}}, "$ref": "#/$defs/a"}`, wantErr: "refers back to itself"},
+		{name: "self reference through anyOf", schema: `{"$defs": {"a": {"anyOf": [{"$ref": "#/$defs/a"}, {"$ref": "#/$defs/a"}]}}, "$ref": "#/$defs/a"}`, wantErr: "refers back to itself"},
+		{name: "mutual reference through not", schema: `{"$defs": {"a": {"not": {"$ref": "#/$defs/b"}}, "b": {"allOf": [{"$ref": "#/$defs/a"}]}}}`, wantErr: "refers back to itself"},
+		{name: "root reference in place", schema: `{"oneOf": [{"$ref": "#"}]}`, wantErr: "refers back to itself"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Check(decodeJSON(t, tt.schema).(map[string]interface{}))
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("Check() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("Check() error = %v, want one containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
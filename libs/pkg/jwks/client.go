@@ -0,0 +1,174 @@
+package jwks
+
+import (
+	"context"
+	"crypto"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Client fetches a remote key set and caches it. An unknown kid triggers a
+// refetch so that keys rotated in by the issuer are picked up immediately,
+// at most once per minRefresh to avoid hammering the issuer with bogus kids.
+// Keys are fetched outside the lock, concurrent lookups share one fetch.
+type Client struct {
+	url        string
+	client     *http.Client
+	ttl        time.Duration
+	minRefresh time.Duration
+
+	mu          sync.Mutex
+	keys        map[string]cachedKey
+	fetchedAt   time.Time
+	attemptedAt time.Time
+	fetching    *fetch
+}
+
+// fetch is a fetch of the key set in flight, done is closed once err is set
+type fetch struct {
+	done chan struct{}
+	err  error
+}
+
+type cachedKey struct {
+	alg string
+	key crypto.PublicKey
+}
+
+// ClientOption configures a Client
+type ClientOption func(*Client)
+
+// WithTTL sets how long a fetched key set is used before it is refetched
+func WithTTL(ttl time.Duration) ClientOption {
+	return func(c *Client) {
+		c.ttl = ttl
+	}
+}
+
+// WithHTTPClient replaces the default HTTP client
+func WithHTTPClient(client *http.Client) ClientOption {
+	return func(c *Client) {
+		c.client = client
+	}
+}
+
+func NewClient(url string, opts ...ClientOption) *Client {
+	c := &Client{
+		url:        url,
+		client:     &http.Client{Timeout: 5 * time.Second},
+		ttl:        15 * time.Minute,
+		minRefresh: 30 * time.Second,
+		keys:       make(map[string]cachedKey),
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// Key returns the public key and algorithm published under kid
+func (c *Client) Key(ctx context.Context, kid string) (crypto.PublicKey, string, error) {
+	c.mu.Lock()
+	key, ok := c.keys[kid]
+	if ok && time.Since(c.fetchedAt) <= c.ttl {
+		c.mu.Unlock()
+		return key.key, key.alg, nil
+	}
+
+	f, leader := c.fetching, false
+	if f == nil && time.Since(c.attemptedAt) > c.minRefresh {
+		c.attemptedAt = time.Now()
+		f, leader = &fetch{done: make(chan struct{})}, true
+		c.fetching = f
+	}
+	c.mu.Unlock()
+
+	if f != nil {
+		if leader {
+			c.refresh(ctx, f)
+		} else {
+			select {
+			case <-f.done:
+			case <-ctx.Done():
+				return nil, "", ctx.Err()
+			}
+		}
+
+		if f.err != nil {
+			// Keep serving cached keys while the issuer is unavailable
+			if ok {
+				return key.key, key.alg, nil
+			}
+			return nil, "", f.err
+		}
+	}
+
+	c.mu.Lock()
+	key, ok = c.keys[kid]
+	c.mu.Unlock()
+	if !ok {
+		return nil, "", fmt.Errorf("%w: %q", ErrKeyNotFound, kid)
+	}
+	return key.key, key.alg, nil
+}
+
+// refresh runs f and stores the fetched keys
+func (c *Client) refresh(ctx context.Context, f *fetch) {
+	keys, err := c.fetch(ctx)
+
+	c.mu.Lock()
+	if err == nil {
+		c.keys = keys
+		c.fetchedAt = time.Now()
+	}
+	c.fetching = nil
+	c.mu.Unlock()
+
+	f.err = err
+	close(f.done)
+}
+
+func (c *Client) fetch(ctx context.Context) (map[string]cachedKey, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch key set: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch key set: unexpected status %d", resp.StatusCode)
+	}
+
+	var set Set
+	if err := json.NewDecoder(resp.Body).Decode(&set); err != nil {
+		return nil, fmt.Errorf("failed to decode key set: %w", err)
+	}
+
+	keys := make(map[string]cachedKey, len(set.Keys))
+	var errs []error
+	for _, k := range set.Keys {
+		if k.Use != "" && k.Use != "sig" {
+			continue
+		}
+		pub, err := k.PublicKey()
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		keys[k.Kid] = cachedKey{alg: k.Alg, key: pub}
+	}
+
+	if len(keys) == 0 && len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return keys, nil
+}
@@ -0,0 +1,129 @@
+package jwks
+
+import (
+	"context"
+	"crypto/ed25519"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// keyServer serves a key set. While block is set, requests wait for it to
+// be closed.
+type keyServer struct {
+	*httptest.Server
+	set      Set
+	requests atomic.Int32
+	block    chan struct{}
+	started  chan struct{}
+}
+
+func newKeyServer(t *testing.T, kids ...string) *keyServer {
+	t.Helper()
+
+	s := &keyServer{started: make(chan struct{}, 16)}
+	for _, kid := range kids {
+		pub, _, err := ed25519.GenerateKey(nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		key, err := NewKey(kid, pub)
+		if err != nil {
+			t.Fatal(err)
+		}
+		s.set.Keys = append(s.set.Keys, key)
+	}
+
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.requests.Add(1)
+		s.started <- struct{}{}
+		if s.block != nil {
+			<-s.block
+		}
+		json.NewEncoder(w).Encode(s.set)
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func TestClientKey(t *testing.T) {
+	server := newKeyServer(t, "a")
+	client := NewClient(server.URL)
+	ctx := context.Background()
+
+	if _, alg, err := client.Key(ctx, "a"); err != nil || alg != AlgEdDSA {
+		t.Fatalf("Key() = %q, %v, want %q", alg, err, AlgEdDSA)
+	}
+	if _, _, err := client.Key(ctx, "a"); err != nil {
+		t.Fatalf("Key() of a cached key error = %v", err)
+	}
+	if n := server.requests.Load(); n != 1 {
+		t.Errorf("fetched the key set %d times, want 1", n)
+	}
+
+	// Unknown kids are not refetched within minRefresh
+	if _, _, err := client.Key(ctx, "b"); !errors.Is(err, ErrKeyNotFound) {
+		t.Errorf("Key() of an unknown kid error = %v, want ErrKeyNotFound", err)
+	}
+	if n := server.requests.Load(); n != 1 {
+		t.Errorf("fetched the key set %d times, want 1", n)
+	}
+}
+
+func TestClientFetchesOutsideTheLock(t *testing.T) {
+	server := newKeyServer(t, "a")
+	client := NewClient(server.URL)
+	ctx := context.Background()
+
+	if _, _, err := client.Key(ctx, "a"); err != nil {
+		t.Fatal(err)
+	}
+	<-server.started
+	client.attemptedAt = time.Time{}
+
+	// Lookups of an unknown kid wait for one shared fetch
+	server.block = make(chan struct{})
+	var wg sync.WaitGroup
+	errs := make(chan error, 4)
+	for i := 0; i < cap(errs); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, _, err := client.Key(ctx, "b")
+			errs <- err
+		}()
+	}
+	<-server.started
+
+	// Cached keys are served while the fetch is in flight
+	done := make(chan error)
+	go func() {
+		_, _, err := client.Key(ctx, "a")
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("Key() of a cached key error = %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Key() of a cached key waited for the fetch")
+	}
+
+	close(server.block)
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if !errors.Is(err, ErrKeyNotFound) {
+			t.Errorf("Key() of an unknown kid error = %v, want ErrKeyNotFound", err)
+		}
+	}
+	if n := server.requests.Load(); n != 2 {
+		t.Errorf("fetched the key set %d times, want 2", n)
+	}
+}
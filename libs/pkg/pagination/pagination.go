@@ -0,0 +1,195 @@
+package pagination
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/url"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+
+	"localloop/libs/pkg/errorbuilder"
+)
+
+const (
+	DefaultLimit = 20
+	MaxLimit     = 100
+)
+
+var ErrInvalidQuery = errorbuilder.NewError("invalid list query", errorbuilder.ErrValidation)
+
+// Spec declares how a resource can be listed
+type Spec struct {
+	// Sorts are the fields the list can be ordered by, the first one is the
+	// default. Ties are always broken by ID.
+	Sorts []string
+	// Descending is the default sort direction
+	Descending bool
+	// Filters are the query parameters that narrow the list down
+	Filters []string
+}
+
+// Query selects one page of a list
+type Query struct {
+	Limit      int
+	Sort       string
+	Descending bool
+	After      *Cursor
+	Filters    map[string]string
+}
+
+// Filter returns the value of a filter, if it is set
+func (q Query) Filter(name string) (string, bool) {
+	value, ok := q.Filters[name]
+	return value, ok
+}
+
+// UUIDFilter returns the value of a filter holding an ID
+func (q Query) UUIDFilter(name string) (uuid.NullUUID, error) {
+	value, ok := q.Filters[name]
+	if !ok {
+		return uuid.NullUUID{}, nil
+	}
+
+	id, err := uuid.Parse(value)
+	if err != nil {
+		return uuid.NullUUID{}, invalidParam(name, "must be a valid UUID")
+	}
+	return uuid.NullUUID{UUID: id, Valid: true}, nil
+}
+
+// FetchLimit is the number of rows to fetch for the page. The extra row tells
+// whether there is a next page.
+func (q Query) FetchLimit() int {
+	return q.Limit + 1
+}
+
+// Cursor marks the position of the last item of a page in the ordering of
+// the query that produced it
+type Cursor struct {
+	Sort       string    `json:"s"`
+	Descending bool      `json:"d,omitempty"`
+	Key        string    `json:"k"`
+	ID         uuid.UUID `json:"id"`
+}
+
+// Time parses a key created with TimeKey
+func (c *Cursor) Time() (time.Time, error) {
+	t, err := time.Parse(time.RFC3339Nano, c.Key)
+	if err != nil {
+		return time.Time{}, invalidParam("cursor", "is malformed")
+	}
+	return t, nil
+}
+
+// TimeKey formats a timestamp sort key without losing precision
+func TimeKey(t time.Time) string {
+	return t.UTC().Format(time.RFC3339Nano)
+}
+
+func (c Cursor) Encode() string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func DecodeCursor(cursor string) (*Cursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	var c Cursor
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+// Page is one page of a list and the cursor of the next one, which is empty
+// on the last page
+type Page[T any] struct {
+	Items      []T
+	NextCursor string
+}
+
+// NewPage builds a page from items fetched with Query.FetchLimit. key returns
+// the sort key and ID of an item.
+func NewPage[T any](items []T, q Query, key func(T) (string, uuid.UUID)) Page[T] {
+	if len(items) <= q.Limit {
+		return Page[T]{Items: items}
+	}
+
+	items = items[:q.Limit]
+	sortKey, id := key(items[len(items)-1])
+	cursor := Cursor{
+		Sort:       q.Sort,
+		Descending: q.Descending,
+		Key:        sortKey,
+		ID:         id,
+	}
+
+	return Page[T]{Items: items, NextCursor: cursor.Encode()}
+}
+
+// Parse reads a query from the limit, cursor and sort parameters and the
+// filters of the spec. Sort takes a field name, prefixed with "-" to sort in
+// descending order.
+func (s Spec) Parse(values url.Values) (Query, error) {
+	q := Query{
+		Limit:      DefaultLimit,
+		Descending: s.Descending,
+		Filters:    make(map[string]string),
+	}
+	if len(s.Sorts) > 0 {
+		q.Sort = s.Sorts[0]
+	}
+
+	if value := values.Get("limit"); value != "" {
+		limit, err := strconv.Atoi(value)
+		if err != nil || limit < 1 || limit > MaxLimit {
+			return q, invalidParam("limit", "must be an integer between 1 and "+strconv.Itoa(MaxLimit))
+		}
+		q.Limit = limit
+	}
+
+	if value := values.Get("sort"); value != "" {
+		field, descending := value, false
+		if value[0] == '-' {
+			field, descending = value[1:], true
+		}
+		if !slices.Contains(s.Sorts, field) {
+			return q, invalidParam("sort", "unsupported sort field "+field)
+		}
+		q.Sort, q.Descending = field, descending
+	}
+
+	if value := values.Get("cursor"); value != "" {
+		cursor, err := DecodeCursor(value)
+		if err != nil {
+			return q, invalidParam("cursor", "is malformed")
+		}
+		if cursor.Sort != q.Sort || cursor.Descending != q.Descending {
+			return q, invalidParam("cursor", "was issued for a different sort order")
+		}
+		q.After = cursor
+	}
+
+	for _, name := range s.Filters {
+		if value := values.Get(name); value != "" {
+			q.Filters[name] = value
+		}
+	}
+
+	return q, nil
+}
+
+func invalidParam(name, reason string) error {
+	return ErrInvalidQuery(
+		errorbuilder.WithContext(map[string]any{
+			"param":  name,
+			"reason": reason,
+		}),
+	)
+}
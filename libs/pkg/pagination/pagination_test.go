@@ -0,0 +1,146 @@
+package pagination
+
+import (
+	"errors"
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"localloop/libs/pkg/errorbuilder"
+)
+
+var spec = Spec{
+	Sorts:      []string{"createdAt", "name"},
+	Descending: true,
+	Filters:    []string{"parentId"},
+}
+
+func invalidParamName(err error) string {
+	var appErr *errorbuilder.CustomError
+	if !errors.As(err, &appErr) {
+		return ""
+	}
+	name, _ := appErr.Context["param"].(string)
+	return name
+}
+
+func TestCursorRoundTrip(t *testing.T) {
+	cursor := Cursor{Sort: "name", Descending: true, Key: "shoes", ID: uuid.New()}
+
+	decoded, err := DecodeCursor(cursor.Encode())
+	if err != nil {
+		t.Fatalf("DecodeCursor() error = %v", err)
+	}
+	if *decoded != cursor {
+		t.Errorf("DecodeCursor() = %+v, want %+v", *decoded, cursor)
+	}
+
+	if _, err := DecodeCursor("not a cursor"); err == nil {
+		t.Error("DecodeCursor() of garbage succeeded")
+	}
+}
+
+func TestTimeKeyKeepsPrecision(t *testing.T) {
+	created := time.Date(2024, 5, 1, 12, 30, 0, 123456789, time.FixedZone("CEST", 2*60*60))
+	cursor := Cursor{Key: TimeKey(created)}
+
+	got, err := cursor.Time()
+	if err != nil {
+		t.Fatalf("Time() error = %v", err)
+	}
+	if !got.Equal(created) {
+		t.Errorf("Time() = %v, want %v", got, created)
+	}
+}
+
+func TestParse(t *testing.T) {
+	after := Cursor{Sort: "name", Key: "shoes", ID: uuid.New()}
+	parentID := uuid.NewString()
+
+	tests := []struct {
+		name      string
+		values    url.Values
+		want      Query
+		wantParam string
+	}{
+		{
+			name:   "defaults",
+			values: url.Values{},
+			want:   Query{Limit: DefaultLimit, Sort: "createdAt", Descending: true, Filters: map[string]string{}},
+		},
+		{
+			name: "every parameter",
+			values: url.Values{
+				"limit":    {"5"},
+				"sort":     {"name"},
+				"cursor":   {after.Encode()},
+				"parentId": {parentID},
+				"other":    {"ignored"},
+			},
+			want: Query{
+				Limit:   5,
+				Sort:    "name",
+				After:   &after,
+				Filters: map[string]string{"parentId": parentID},
+			},
+		},
+		{
+			name:   "descending sort",
+			values: url.Values{"sort": {"-name"}},
+			want:   Query{Limit: DefaultLimit, Sort: "name", Descending: true, Filters: map[string]string{}},
+		},
+		{name: "zero limit", values: url.Values{"limit": {"0"}}, wantParam: "limit"},
+		{name: "limit over the maximum", values: url.Values{"limit": {"101"}}, wantParam: "limit"},
+		{name: "unknown sort", values: url.Values{"sort": {"price"}}, wantParam: "sort"},
+		{name: "malformed cursor", values: url.Values{"cursor": {"!"}}, wantParam: "cursor"},
+		{
+			name:      "cursor of another sort order",
+			values:    url.Values{"sort": {"-name"}, "cursor": {after.Encode()}},
+			wantParam: "cursor",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := spec.Parse(tt.values)
+			if tt.wantParam != "" {
+				if param := invalidParamName(err); param != tt.wantParam {
+					t.Fatalf("Parse() error = %v, want an invalid %s", err, tt.wantParam)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Parse() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewPage(t *testing.T) {
+	ids := []uuid.UUID{uuid.New(), uuid.New(), uuid.New()}
+	key := func(id uuid.UUID) (string, uuid.UUID) { return id.String(), id }
+	q := Query{Limit: 2, Sort: "name"}
+
+	page := NewPage(ids, q, key)
+	if !reflect.DeepEqual(page.Items, ids[:2]) {
+		t.Errorf("items = %v, want %v", page.Items, ids[:2])
+	}
+	cursor, err := DecodeCursor(page.NextCursor)
+	if err != nil {
+		t.Fatalf("next cursor is malformed: %v", err)
+	}
+	if cursor.ID != ids[1] || cursor.Key != ids[1].String() || cursor.Sort != "name" {
+		t.Errorf("next cursor = %+v, want one after %v", cursor, ids[1])
+	}
+
+	last := NewPage(ids[:2], q, key)
+	if len(last.Items) != 2 || last.NextCursor != "" {
+		t.Errorf("last page = %+v, want both items and no cursor", last)
+	}
+}
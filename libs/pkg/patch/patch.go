@@ -0,0 +1,103 @@
+package patch
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// ContentType is the media type of JSON merge patches (RFC 7396)
+const ContentType = "application/merge-patch+json"
+
+// Field is a member of a merge patch. It tells a member that is absent, which
+// leaves the target unchanged, apart from one that is null, which removes or
+// clears it.
+type Field[T any] struct {
+	Set   bool
+	Null  bool
+	Value T
+}
+
+// Value returns a field that sets the target to v
+func Value[T any](v T) Field[T] {
+	return Field[T]{Set: true, Value: v}
+}
+
+// Null returns a field that clears the target
+func Null[T any]() Field[T] {
+	return Field[T]{Set: true, Null: true}
+}
+
+func (f *Field[T]) UnmarshalJSON(data []byte) error {
+	var zero T
+	f.Set, f.Null, f.Value = true, false, zero
+
+	if bytes.Equal(bytes.TrimSpace(data), []byte("null")) {
+		f.Null = true
+		return nil
+	}
+	return json.Unmarshal(data, &f.Value)
+}
+
+func (f Field[T]) MarshalJSON() ([]byte, error) {
+	if !f.Set || f.Null {
+		return []byte("null"), nil
+	}
+	return json.Marshal(f.Value)
+}
+
+// Apply returns the patched value of current
+func (f Field[T]) Apply(current T) T {
+	if !f.Set {
+		return current
+	}
+	if f.Null {
+		var zero T
+		return zero
+	}
+	return f.Value
+}
+
+// ApplyPtr is Apply for optional values, which null sets to nil
+func (f Field[T]) ApplyPtr(current *T) *T {
+	if !f.Set {
+		return current
+	}
+	if f.Null {
+		return nil
+	}
+	v := f.Value
+	return &v
+}
+
+// Validated returns the value validate tags of the member apply to, which
+// is nil unless the member sets a value
+func (f Field[T]) Validated() any {
+	if !f.Set || f.Null {
+		return nil
+	}
+	return f.Value
+}
+
+// Merge applies a merge patch to a decoded JSON object. Members of patch that
+// are objects are merged recursively, null members are removed and all others
+// replace the member of target. target is not modified.
+func Merge(target, patch map[string]any) map[string]any {
+	merged := make(map[string]any, len(target)+len(patch))
+	for key, value := range target {
+		merged[key] = value
+	}
+
+	for key, value := range patch {
+		switch value := value.(type) {
+		case nil:
+			delete(merged, key)
+		case map[string]any:
+			current, _ := merged[key].(map[string]any)
+			merged[key] = Merge(current, value)
+		default:
+			merged[key] = value
+		}
+	}
+
+	return merged
+}
@@ -0,0 +1,104 @@
+package patch
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+type request struct {
+	Name        Field[string]         `json:"name"`
+	Description Field[string]         `json:"description"`
+	Properties  Field[map[string]any] `json:"properties"`
+}
+
+func TestFieldUnmarshal(t *testing.T) {
+	var req request
+	if err := json.Unmarshal([]byte(`{"name": "shoes", "description": null}`), &req); err != nil {
+		t.Fatal(err)
+	}
+
+	if want := Value("shoes"); req.Name != want {
+		t.Errorf("name = %+v, want %+v", req.Name, want)
+	}
+	if want := Null[string](); req.Description != want {
+		t.Errorf("description = %+v, want %+v", req.Description, want)
+	}
+	if req.Properties.Set {
+		t.Errorf("properties = %+v, want it absent", req.Properties)
+	}
+
+	if err := json.Unmarshal([]byte(`{"name": 1}`), &req); err == nil {
+		t.Error("unmarshalling a number into a string field succeeded")
+	}
+}
+
+func TestFieldApply(t *testing.T) {
+	current := "boots"
+
+	tests := []struct {
+		name     string
+		field    Field[string]
+		want     string
+		wantPtr  *string
+		validate any
+	}{
+		{name: "absent", field: Field[string]{}, want: "boots", wantPtr: &current},
+		{name: "null", field: Null[string](), want: "", wantPtr: nil},
+		{name: "value", field: Value("shoes"), want: "shoes", wantPtr: ptr("shoes"), validate: "shoes"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.field.Apply(current); got != tt.want {
+				t.Errorf("Apply() = %q, want %q", got, tt.want)
+			}
+			if got := tt.field.ApplyPtr(&current); !reflect.DeepEqual(got, tt.wantPtr) {
+				t.Errorf("ApplyPtr() = %v, want %v", got, tt.wantPtr)
+			}
+			if got := tt.field.Validated(); got != tt.validate {
+				t.Errorf("Validated() = %v, want %v", got, tt.validate)
+			}
+		})
+	}
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+func TestMerge(t *testing.T) {
+	target := map[string]any{
+		"title":   "Goodbye!",
+		"author":  map[string]any{"givenName": "John", "familyName": "Doe"},
+		"tags":    []any{"example", "sample"},
+		"content": "This will be unchanged",
+	}
+	patch := map[string]any{
+		"title":       "Hello!",
+		"phoneNumber": "+01-123-456-7890",
+		"author":      map[string]any{"familyName": nil},
+		"tags":        []any{"example"},
+	}
+
+	// The example of RFC 7396, section 3
+	want := map[string]any{
+		"title":       "Hello!",
+		"author":      map[string]any{"givenName": "John"},
+		"tags":        []any{"example"},
+		"content":     "This will be unchanged",
+		"phoneNumber": "+01-123-456-7890",
+	}
+
+	got := Merge(target, patch)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Merge() = %v, want %v", got, want)
+	}
+	if target["title"] != "Goodbye!" {
+		t.Error("Merge() modified the target")
+	}
+
+	if got := Merge(nil, map[string]any{"a": map[string]any{"b": nil, "c": 1}}); !reflect.DeepEqual(got, map[string]any{"a": map[string]any{"c": 1}}) {
+		t.Errorf("Merge() into nil = %v", got)
+	}
+}
@@ -0,0 +1,77 @@
+package precondition
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"localloop/libs/pkg/errorbuilder"
+)
+
+var (
+	ErrPreconditionFailed   = errorbuilder.NewError("resource was modified", errorbuilder.ErrPreconditionFailed)
+	ErrPreconditionRequired = errorbuilder.NewError("if-match header required", errorbuilder.ErrPreconditionRequired)
+)
+
+// ETag returns the entity tag of a resource version. Versions are the
+// UpdatedAt timestamps of resources, at the microsecond precision of the
+// database.
+func ETag(version time.Time) string {
+	return strconv.Quote(strconv.FormatInt(version.UnixMicro(), 36))
+}
+
+// ParseETag returns the version an entity tag created with ETag stands for.
+// Weak tags are rejected: If-Match compares tags strongly, so they never match.
+func ParseETag(tag string) (time.Time, bool) {
+	unquoted, err := strconv.Unquote(strings.TrimSpace(tag))
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	micros, err := strconv.ParseInt(unquoted, 36, 64)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return time.UnixMicro(micros), true
+}
+
+// MatchNone tells whether tag matches an If-None-Match header, which lists
+// entity tags or is "*"
+func MatchNone(header, tag string) bool {
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == tag {
+			return true
+		}
+	}
+	return false
+}
+
+type ifMatchKey struct{}
+
+// WithIfMatch returns a context under which writes only succeed against the
+// given version of a resource
+func WithIfMatch(ctx context.Context, version time.Time) context.Context {
+	return context.WithValue(ctx, ifMatchKey{}, version)
+}
+
+// IfMatch returns the version writes under ctx are conditional on, if any
+func IfMatch(ctx context.Context) (time.Time, bool) {
+	version, ok := ctx.Value(ifMatchKey{}).(time.Time)
+	return version, ok
+}
+
+// Check fails with ErrPreconditionFailed when ctx is conditional on another
+// version than current
+func Check(ctx context.Context, current time.Time) error {
+	version, ok := IfMatch(ctx)
+	if !ok || version.UnixMicro() == current.UnixMicro() {
+		return nil
+	}
+	return ErrPreconditionFailed(
+		errorbuilder.WithContext(map[string]any{
+			"currentETag": ETag(current),
+		}),
+	)
+}
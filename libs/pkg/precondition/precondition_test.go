@@ -0,0 +1,87 @@
+package precondition
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"localloop/libs/pkg/errorbuilder"
+)
+
+func TestETagRoundTrip(t *testing.T) {
+	version := time.Date(2024, 5, 1, 12, 30, 0, 123456789, time.UTC)
+
+	for _, tag := range []string{ETag(version), " " + ETag(version) + " "} {
+		got, ok := ParseETag(tag)
+		if !ok {
+			t.Fatalf("ParseETag(%s) failed", tag)
+		}
+		// Versions are kept at microsecond precision
+		if want := version.Truncate(time.Microsecond); !got.Equal(want) {
+			t.Errorf("ParseETag(%s) = %v, want %v", tag, got, want)
+		}
+	}
+
+	for _, tag := range []string{"", "abc", `"not base 36!"`, "W/" + ETag(version)} {
+		if _, ok := ParseETag(tag); ok {
+			t.Errorf("ParseETag(%s) succeeded", tag)
+		}
+	}
+}
+
+func TestMatchNone(t *testing.T) {
+	tag := ETag(time.Now())
+
+	tests := []struct {
+		header string
+		want   bool
+	}{
+		{header: tag, want: true},
+		{header: "W/" + tag, want: true},
+		{header: `"other", ` + tag, want: true},
+		{header: "*", want: true},
+		{header: `"other"`, want: false},
+	}
+
+	for _, tt := range tests {
+		if got := MatchNone(tt.header, tag); got != tt.want {
+			t.Errorf("MatchNone(%s) = %v, want %v", tt.header, got, tt.want)
+		}
+	}
+}
+
+func TestCheck(t *testing.T) {
+	current := time.Date(2024, 5, 1, 12, 30, 0, 123456789, time.UTC)
+
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		wantErr bool
+	}{
+		{name: "unconditional", ctx: context.Background()},
+		{name: "current version", ctx: WithIfMatch(context.Background(), current)},
+		{name: "current version from its ETag", ctx: WithIfMatch(context.Background(), current.Truncate(time.Microsecond))},
+		{name: "other version", ctx: WithIfMatch(context.Background(), current.Add(-time.Second)), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Check(tt.ctx, current)
+			if !tt.wantErr {
+				if err != nil {
+					t.Fatalf("Check() error = %v", err)
+				}
+				return
+			}
+
+			var appErr *errorbuilder.CustomError
+			if !errors.As(err, &appErr) || appErr.Code != errorbuilder.ErrPreconditionFailed {
+				t.Fatalf("Check() error = %v, want a failed precondition", err)
+			}
+			if appErr.Context["currentETag"] != ETag(current) {
+				t.Errorf("currentETag = %v, want %s", appErr.Context["currentETag"], ETag(current))
+			}
+		})
+	}
+}
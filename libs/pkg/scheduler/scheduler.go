@@ -0,0 +1,101 @@
+// Package scheduler runs periodic background jobs next to a service's HTTP
+// server
+package scheduler
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+)
+
+// Job is a unit of background work run every Interval
+type Job struct {
+	Name     string
+	Interval time.Duration
+	Run      func(ctx context.Context) error
+}
+
+// LockFunc runs fn unless another replica holds the lock name, and reports
+// whether it did. See db.TryAdvisoryLock.
+type LockFunc func(ctx context.Context, name string, fn func(ctx context.Context) error) (bool, error)
+
+// Scheduler runs jobs on their intervals until it is stopped
+type Scheduler struct {
+	jobs   []Job
+	lock   LockFunc
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+type Option func(*Scheduler)
+
+// WithLock makes every run of a job exclusive across replicas, runs that do
+// not get the lock are skipped
+func WithLock(lock LockFunc) Option {
+	return func(s *Scheduler) {
+		s.lock = lock
+	}
+}
+
+func New(jobs []Job, opts ...Option) *Scheduler {
+	s := &Scheduler{jobs: jobs}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// Start runs every job once and then on its interval, in the background
+func (s *Scheduler) Start(ctx context.Context) {
+	ctx, s.cancel = context.WithCancel(ctx)
+
+	for _, job := range s.jobs {
+		s.wg.Add(1)
+		go func(job Job) {
+			defer s.wg.Done()
+			s.loop(ctx, job)
+		}(job)
+	}
+
+	log.Printf("Scheduler started with %d jobs\n", len(s.jobs))
+}
+
+// Stop cancels the running jobs and waits for them to return
+func (s *Scheduler) Stop() {
+	if s.cancel == nil {
+		return
+	}
+	s.cancel()
+	s.wg.Wait()
+	log.Println("Scheduler stopped")
+}
+
+func (s *Scheduler) loop(ctx context.Context, job Job) {
+	ticker := time.NewTicker(job.Interval)
+	defer ticker.Stop()
+
+	for {
+		s.run(ctx, job)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *Scheduler) run(ctx context.Context, job Job) {
+	run := job.Run
+	if s.lock != nil {
+		run = func(ctx context.Context) error {
+			_, err := s.lock(ctx, "job:"+job.Name, job.Run)
+			return err
+		}
+	}
+
+	if err := run(ctx); err != nil && ctx.Err() == nil {
+		log.Printf("Job %s failed: %v\n", job.Name, err)
+	}
+}
@@ -0,0 +1,108 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+// counter counts the runs of a job and signals each of them
+type counter struct {
+	mu   sync.Mutex
+	runs int
+	ran  chan struct{}
+}
+
+func newCounter() *counter {
+	return &counter{ran: make(chan struct{}, 100)}
+}
+
+func (c *counter) run(context.Context) error {
+	c.mu.Lock()
+	c.runs++
+	c.mu.Unlock()
+	c.ran <- struct{}{}
+	return errors.New("failures are logged and the job keeps running")
+}
+
+func (c *counter) count() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.runs
+}
+
+func (c *counter) wait(t *testing.T, runs int) {
+	t.Helper()
+	for i := 0; i < runs; i++ {
+		select {
+		case <-c.ran:
+		case <-time.After(time.Second):
+			t.Fatalf("job ran %d times, want %d", c.count(), runs)
+		}
+	}
+}
+
+func TestSchedulerRunsJobsOnTheirInterval(t *testing.T) {
+	job := newCounter()
+	s := New([]Job{{Name: "count", Interval: 10 * time.Millisecond, Run: job.run}})
+
+	s.Start(context.Background())
+	job.wait(t, 3)
+	s.Stop()
+
+	stopped := job.count()
+	time.Sleep(30 * time.Millisecond)
+	if runs := job.count(); runs != stopped {
+		t.Errorf("job ran %d times after Stop()", runs-stopped)
+	}
+}
+
+func TestSchedulerRunsJobsImmediately(t *testing.T) {
+	job := newCounter()
+	s := New([]Job{{Name: "count", Interval: time.Hour, Run: job.run}})
+
+	s.Start(context.Background())
+	defer s.Stop()
+	job.wait(t, 1)
+}
+
+func TestSchedulerSkipsRunsWithoutTheLock(t *testing.T) {
+	var mu sync.Mutex
+	var locks []string
+	held := true
+	lock := func(ctx context.Context, name string, fn func(context.Context) error) (bool, error) {
+		mu.Lock()
+		locks = append(locks, name)
+		acquired := !held
+		held = !held
+		mu.Unlock()
+
+		if !acquired {
+			return false, nil
+		}
+		return true, fn(ctx)
+	}
+
+	job := newCounter()
+	s := New([]Job{{Name: "count", Interval: 5 * time.Millisecond, Run: job.run}}, WithLock(lock))
+	s.Start(context.Background())
+	job.wait(t, 2)
+	s.Stop()
+
+	mu.Lock()
+	defer mu.Unlock()
+	if runs := job.count(); len(locks) < 2*runs-1 {
+		t.Errorf("job ran %d times with %d lock attempts, want runs without the lock skipped", runs, len(locks))
+	}
+	for _, name := range locks {
+		if name != "job:count" {
+			t.Errorf("locked %q, want job:count", name)
+		}
+	}
+}
+
+func TestStopWithoutStart(t *testing.T) {
+	New(nil).Stop()
+}
@@ -3,6 +3,8 @@ package crud
 import (
 	"context"
 	"fmt"
+	"localloop/libs/pkg/pagination"
+	"localloop/libs/pkg/precondition"
 	"localloop/libs/pkg/web/handler"
 	"net/http"
 
@@ -11,6 +13,17 @@ import (
 
 type EmptyRequest struct{}
 
+// IDRequest addresses a single resource by the id route variable
+type IDRequest struct {
+	ID uuid.UUID `param:"id" validate:"required"`
+}
+
+// ListMeta is returned next to a page of items
+type ListMeta struct {
+	Limit      int    `json:"limit"`
+	NextCursor string `json:"nextCursor,omitempty"`
+}
+
 // Generic type parameters:
 // D: Domain type (or more like Resource type, e.g. Category, User, etc.)
 // C: Create request type
@@ -21,8 +34,10 @@ type CRUDHandler[D any, C any, U any, R any] struct {
 	get        func(context.Context, uuid.UUID) (*D, error)
 	update     func(context.Context, uuid.UUID, U) (*D, error)
 	delete     func(context.Context, uuid.UUID) error
-	list       func(context.Context) ([]*D, error)
+	list       func(context.Context, pagination.Query) (pagination.Page[*D], error)
 	toResponse func(*D) R
+	listSpec   pagination.Spec
+	options    options[D]
 }
 
 func NewCRUDHandler[D any, C any, U any, R any](
@@ -30,8 +45,10 @@ func NewCRUDHandler[D any, C any, U any, R any](
 	get func(context.Context, uuid.UUID) (*D, error),
 	update func(context.Context, uuid.UUID, U) (*D, error),
 	delete func(context.Context, uuid.UUID) error,
-	list func(context.Context) ([]*D, error),
+	list func(context.Context, pagination.Query) (pagination.Page[*D], error),
 	toResponse func(*D) R,
+	listSpec pagination.Spec,
+	opts ...Option[D],
 ) *CRUDHandler[D, C, U, R] {
 	return &CRUDHandler[D, C, U, R]{
 		create:     create,
@@ -40,6 +57,8 @@ func NewCRUDHandler[D any, C any, U any, R any](
 		delete:     delete,
 		list:       list,
 		toResponse: toResponse,
+		listSpec:   listSpec,
+		options:    newOptions(opts),
 	}
 }
 
@@ -48,20 +67,25 @@ func (h *CRUDHandler[D, C, U, R]) Create(req C, r *http.Request) (any, error) {
 	if err != nil {
 		return nil, err
 	}
-	return h.toResponse(result), nil
+	return h.options.respond(result, h.toResponse(result)), nil
 }
 
-func (h *CRUDHandler[D, C, U, R]) Get(_ EmptyRequest, r *http.Request) (any, error) {
-	id, err := handler.ParseIDParam(r, "id")
+func (h *CRUDHandler[D, C, U, R]) Get(req IDRequest, r *http.Request) (any, error) {
+	result, err := h.get(r.Context(), req.ID)
 	if err != nil {
-		return nil, fmt.Errorf("invalid ID: %w", err)
+		return nil, err
 	}
 
-	result, err := h.get(r.Context(), id)
-	if err != nil {
-		return nil, err
+	if h.options.version != nil {
+		etag := precondition.ETag(h.options.version(result))
+		if header := r.Header.Get("If-None-Match"); header != "" && precondition.MatchNone(header, etag) {
+			return handler.Response{
+				Header: http.Header{"Etag": {etag}},
+				Status: http.StatusNotModified,
+			}, nil
+		}
 	}
-	return h.toResponse(result), nil
+	return h.options.respond(result, h.toResponse(result)), nil
 }
 
 func (h *CRUDHandler[D, C, U, R]) Update(req U, r *http.Request) (any, error) {
@@ -70,34 +94,63 @@ func (h *CRUDHandler[D, C, U, R]) Update(req U, r *http.Request) (any, error) {
 		return nil, fmt.Errorf("invalid ID: %w", err)
 	}
 
-	result, err := h.update(r.Context(), id, req)
+	ctx, err := h.options.ifMatch(r)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := h.update(ctx, id, req)
 	if err != nil {
 		return nil, err
 	}
-	return h.toResponse(result), nil
+	return h.options.respond(result, h.toResponse(result)), nil
 }
 
-func (h *CRUDHandler[D, C, U, R]) Delete(_ EmptyRequest, r *http.Request) (any, error) {
-	id, err := handler.ParseIDParam(r, "id")
+func (h *CRUDHandler[D, C, U, R]) Delete(req IDRequest, r *http.Request) (any, error) {
+	ctx, err := h.options.ifMatch(r)
 	if err != nil {
-		return nil, fmt.Errorf("invalid ID: %w", err)
+		return nil, err
 	}
 
-	if err := h.delete(r.Context(), id); err != nil {
+	if err := h.delete(ctx, req.ID); err != nil {
 		return nil, err
 	}
-	return map[string]string{"id": id.String()}, nil
+	return map[string]string{"id": req.ID.String()}, nil
+}
+
+// IfMatch returns the context of a write to the resource r addresses, which
+// carries the version named by If-Match for versioned resources. Handlers
+// that stand in for one of the CRUD operations use it to keep its
+// preconditions.
+func (h *CRUDHandler[D, C, U, R]) IfMatch(r *http.Request) (context.Context, error) {
+	return h.options.ifMatch(r)
 }
 
+// Respond returns the response to result the CRUD operations return, with
+// the ETag of versioned resources
+func (h *CRUDHandler[D, C, U, R]) Respond(result *D) any {
+	return h.options.respond(result, h.toResponse(result))
+}
+
+// List returns one page of items, selected by the limit, cursor, sort and
+// filter query parameters of the list spec
 func (h *CRUDHandler[D, C, U, R]) List(_ EmptyRequest, r *http.Request) (any, error) {
-	results, err := h.list(r.Context())
+	query, err := h.listSpec.Parse(r.URL.Query())
+	if err != nil {
+		return nil, err
+	}
+
+	page, err := h.list(r.Context(), query)
 	if err != nil {
 		return nil, err
 	}
 
-	responses := make([]R, len(results))
-	for i, result := range results {
+	responses := make([]R, len(page.Items))
+	for i, result := range page.Items {
 		responses[i] = h.toResponse(result)
 	}
-	return responses, nil
+	return handler.Response{
+		Data: responses,
+		Meta: ListMeta{Limit: query.Limit, NextCursor: page.NextCursor},
+	}, nil
 }
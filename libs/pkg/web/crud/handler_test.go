@@ -0,0 +1,112 @@
+package crud
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+
+	"localloop/libs/pkg/errorbuilder"
+	"localloop/libs/pkg/pagination"
+	"localloop/libs/pkg/precondition"
+	"localloop/libs/pkg/web/handler"
+)
+
+type item struct {
+	ID        uuid.UUID
+	UpdatedAt time.Time
+}
+
+// newItemHandler serves one versioned item. Writes record the version they
+// are conditional on.
+func newItemHandler(current *item, written *time.Time) *CRUDHandler[item, struct{}, struct{}, uuid.UUID] {
+	get := func(context.Context, uuid.UUID) (*item, error) { return current, nil }
+	update := func(ctx context.Context, _ uuid.UUID, _ struct{}) (*item, error) {
+		*written, _ = precondition.IfMatch(ctx)
+		return current, nil
+	}
+	return NewCRUDHandler[item, struct{}, struct{}, uuid.UUID](
+		nil, get, update, nil,
+		func(context.Context, pagination.Query) (pagination.Page[*item], error) {
+			return pagination.Page[*item]{}, nil
+		},
+		func(i *item) uuid.UUID { return i.ID },
+		pagination.Spec{},
+		WithVersion(func(i *item) time.Time { return i.UpdatedAt }),
+	)
+}
+
+func errorCode(err error) errorbuilder.ErrorCode {
+	var appErr *errorbuilder.CustomError
+	if errors.As(err, &appErr) {
+		return appErr.Code
+	}
+	return 0
+}
+
+func TestGetHonoursIfNoneMatch(t *testing.T) {
+	current := &item{ID: uuid.New(), UpdatedAt: time.Now()}
+	h := newItemHandler(current, new(time.Time))
+	etag := precondition.ETag(current.UpdatedAt)
+
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	resp, err := h.Get(IDRequest{ID: current.ID}, r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := resp.(handler.Response).Header.Get("Etag"); got != etag {
+		t.Errorf("ETag = %s, want %s", got, etag)
+	}
+
+	r.Header.Set("If-None-Match", etag)
+	resp, err = h.Get(IDRequest{ID: current.ID}, r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status := resp.(handler.Response).Status; status != http.StatusNotModified {
+		t.Errorf("status = %d, want %d", status, http.StatusNotModified)
+	}
+}
+
+func TestUpdateRequiresIfMatch(t *testing.T) {
+	current := &item{ID: uuid.New(), UpdatedAt: time.Now().Truncate(time.Microsecond)}
+
+	tests := []struct {
+		name        string
+		ifMatch     string
+		wantCode    errorbuilder.ErrorCode
+		wantVersion time.Time
+	}{
+		{name: "missing", wantCode: errorbuilder.ErrPreconditionRequired},
+		{name: "malformed", ifMatch: "abc", wantCode: errorbuilder.ErrPreconditionFailed},
+		{name: "weak version", ifMatch: "W/" + precondition.ETag(current.UpdatedAt), wantCode: errorbuilder.ErrPreconditionFailed},
+		{name: "any version", ifMatch: "*"},
+		{name: "version", ifMatch: precondition.ETag(current.UpdatedAt), wantVersion: current.UpdatedAt},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var written time.Time
+			h := newItemHandler(current, &written)
+
+			r := httptest.NewRequest(http.MethodPut, "/", nil)
+			r = mux.SetURLVars(r, map[string]string{"id": current.ID.String()})
+			if tt.ifMatch != "" {
+				r.Header.Set("If-Match", tt.ifMatch)
+			}
+
+			_, err := h.Update(struct{}{}, r)
+			if code := errorCode(err); code != tt.wantCode {
+				t.Fatalf("Update() error = %v, want code %d", err, tt.wantCode)
+			}
+			if !written.Equal(tt.wantVersion) {
+				t.Errorf("written under version %v, want %v", written, tt.wantVersion)
+			}
+		})
+	}
+}
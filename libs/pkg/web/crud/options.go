@@ -0,0 +1,70 @@
+package crud
+
+import (
+	"context"
+	"localloop/libs/pkg/precondition"
+	"localloop/libs/pkg/web/handler"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Option configures a CRUDHandler or PatchHandler of domain type D
+type Option[D any] func(*options[D])
+
+type options[D any] struct {
+	version func(*D) time.Time
+}
+
+// WithVersion enables optimistic concurrency for resources whose version is
+// returned by version, typically their UpdatedAt timestamp. Responses carry
+// the version as an ETag, GET honours If-None-Match and writes require an
+// If-Match header, which reaches the domain through precondition.IfMatch.
+func WithVersion[D any](version func(*D) time.Time) Option[D] {
+	return func(o *options[D]) {
+		o.version = version
+	}
+}
+
+func newOptions[D any](opts []Option[D]) options[D] {
+	var o options[D]
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// ifMatch returns the context writes to the resource are made under. For
+// versioned resources it carries the version named by the If-Match header.
+// "*" matches any version of an existing resource.
+func (o options[D]) ifMatch(r *http.Request) (context.Context, error) {
+	ctx := r.Context()
+	if o.version == nil {
+		return ctx, nil
+	}
+
+	header := strings.TrimSpace(r.Header.Get("If-Match"))
+	if header == "" {
+		return nil, precondition.ErrPreconditionRequired()
+	}
+	if header == "*" {
+		return ctx, nil
+	}
+
+	version, ok := precondition.ParseETag(header)
+	if !ok {
+		return nil, precondition.ErrPreconditionFailed()
+	}
+	return precondition.WithIfMatch(ctx, version), nil
+}
+
+// respond wraps the response to result with its ETag, if it is versioned
+func (o options[D]) respond(result *D, response any) any {
+	if o.version == nil {
+		return response
+	}
+	return handler.Response{
+		Data:   response,
+		Header: http.Header{"Etag": {precondition.ETag(o.version(result))}},
+	}
+}
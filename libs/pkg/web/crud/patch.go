@@ -0,0 +1,64 @@
+package crud
+
+import (
+	"context"
+	"fmt"
+	"localloop/libs/pkg/errorbuilder"
+	"localloop/libs/pkg/patch"
+	"localloop/libs/pkg/web/handler"
+	"mime"
+	"net/http"
+
+	"github.com/google/uuid"
+)
+
+var ErrUnsupportedMediaType = errorbuilder.NewError("unsupported media type", errorbuilder.ErrUnsupportedMediaType)
+
+// Generic type parameters:
+// D: Domain type
+// P: Patch request type, whose members are patch.Field values
+// R: Response type
+type PatchHandler[D any, P any, R any] struct {
+	patch      func(context.Context, uuid.UUID, P) (*D, error)
+	toResponse func(*D) R
+	options    options[D]
+}
+
+func NewPatchHandler[D any, P any, R any](
+	patch func(context.Context, uuid.UUID, P) (*D, error),
+	toResponse func(*D) R,
+	opts ...Option[D],
+) *PatchHandler[D, P, R] {
+	return &PatchHandler[D, P, R]{
+		patch:      patch,
+		toResponse: toResponse,
+		options:    newOptions(opts),
+	}
+}
+
+// Patch applies a JSON merge patch (RFC 7396) to the resource addressed by
+// the id route variable. Members absent from the body are left unchanged.
+func (h *PatchHandler[D, P, R]) Patch(req P, r *http.Request) (any, error) {
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if mediaType != patch.ContentType && mediaType != "application/json" {
+		return nil, ErrUnsupportedMediaType(
+			errorbuilder.WithDetail(fmt.Sprintf("content type must be %s", patch.ContentType)),
+		)
+	}
+
+	id, err := handler.ParseIDParam(r, "id")
+	if err != nil {
+		return nil, fmt.Errorf("invalid ID: %w", err)
+	}
+
+	ctx, err := h.options.ifMatch(r)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := h.patch(ctx, id, req)
+	if err != nil {
+		return nil, err
+	}
+	return h.options.respond(result, h.toResponse(result)), nil
+}
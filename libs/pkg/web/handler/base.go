@@ -2,16 +2,39 @@ package handler
 
 import (
 	"encoding/json"
+	"errors"
+	"log"
+	"mime"
 	"net/http"
+	"strings"
+
+	"localloop/libs/pkg/errorbuilder"
 
 	"github.com/google/uuid"
 	"github.com/gorilla/mux"
 )
 
+var (
+	ErrInvalidParam = errorbuilder.NewError("invalid path parameter", errorbuilder.ErrValidation)
+	ErrInvalidQuery = errorbuilder.NewError("invalid query parameter", errorbuilder.ErrValidation)
+)
+
+// ApiResponse is the envelope of successful responses. Failures are written
+// as problem details, see RespondWithProblem.
 type ApiResponse struct {
 	Message string      `json:"message,omitempty"`
 	Data    interface{} `json:"data,omitempty"`
-	Error   string      `json:"error,omitempty"`
+	Meta    interface{} `json:"meta,omitempty"`
+}
+
+// Response lets a handler return metadata, such as pagination cursors, next
+// to its data. Header is added to the response headers and a non-zero Status
+// replaces the default status of the method. 304 responses carry no body.
+type Response struct {
+	Data   any
+	Meta   any
+	Header http.Header
+	Status int
 }
 
 func RespondWithJSON(w http.ResponseWriter, status int, response ApiResponse) {
@@ -20,8 +43,65 @@ func RespondWithJSON(w http.ResponseWriter, status int, response ApiResponse) {
 	json.NewEncoder(w).Encode(response)
 }
 
+// RespondWithProblem writes problem as an application/problem+json response
+func RespondWithProblem(w http.ResponseWriter, problem errorbuilder.Problem) {
+	w.Header().Set("Content-Type", errorbuilder.ProblemContentType)
+	w.WriteHeader(problem.Status)
+	json.NewEncoder(w).Encode(problem)
+}
+
+// RespondWithError writes a problem without a specific type, explained by
+// message
 func RespondWithError(w http.ResponseWriter, status int, message string) {
-	RespondWithJSON(w, status, ApiResponse{Error: message})
+	RespondWithProblem(w, errorbuilder.Problem{
+		Type:   errorbuilder.BlankProblemType,
+		Title:  http.StatusText(status),
+		Status: status,
+		Detail: message,
+	})
+}
+
+// RespondWithAppError renders err as the problem of the errorbuilder.CustomError
+// it wraps. Other errors, and the context of server errors, are not exposed.
+func RespondWithAppError(w http.ResponseWriter, r *http.Request, err error) {
+	status := StatusFromError(err)
+	if status >= http.StatusInternalServerError {
+		log.Println("request failed:", err)
+	}
+
+	var customErr *errorbuilder.CustomError
+	if !errors.As(err, &customErr) {
+		RespondWithProblem(w, errorbuilder.Problem{
+			Type:     errorbuilder.BlankProblemType,
+			Title:    http.StatusText(status),
+			Status:   status,
+			Instance: r.URL.Path,
+		})
+		return
+	}
+
+	problem := customErr.Problem()
+	problem.Status = status
+	if problem.Instance == "" {
+		problem.Instance = r.URL.Path
+	}
+	if status >= http.StatusInternalServerError {
+		problem.Detail = ""
+		problem.Extensions = nil
+	}
+	RespondWithProblem(w, problem)
+}
+
+// StatusFromError returns the HTTP status for err, 500 unless it wraps an
+// errorbuilder.CustomError with a client or server error code
+func StatusFromError(err error) int {
+	var customErr *errorbuilder.CustomError
+	if errors.As(err, &customErr) {
+		if code := int(customErr.Code); code >= 400 && code <= 599 {
+			return code
+		}
+	}
+	return http.StatusInternalServerError
 }
 
 func DecodeRequest[T any](r *http.Request) (T, error) {
@@ -32,16 +112,29 @@ func DecodeRequest[T any](r *http.Request) (T, error) {
 
 func ParseIDParam(r *http.Request, param string) (uuid.UUID, error) {
 	vars := mux.Vars(r)
-	return uuid.Parse(vars[param])
+	id, err := uuid.Parse(vars[param])
+	if err != nil {
+		return uuid.Nil, ErrInvalidParam(
+			errorbuilder.WithContext(map[string]any{
+				"param":  param,
+				"reason": "must be a UUID",
+			}),
+			errorbuilder.WithOriginal(err),
+		)
+	}
+	return id, nil
 }
 
 type HandlerFunc[Req any] func(Req, *http.Request) (any, error)
 
+// HandleRequest decodes the JSON body of the request, binds and validates it,
+// and writes what handler returns. Multipart bodies are left to the handler,
+// which reads them from the request.
 func HandleRequest[Req any](handler HandlerFunc[Req]) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		var req Req
 
-		if r.Method != http.MethodGet && r.Method != http.MethodDelete {
+		if r.Method != http.MethodGet && r.Method != http.MethodDelete && !isMultipart(r) {
 			decodedReq, err := DecodeRequest[Req](r)
 			if err != nil {
 				RespondWithError(w, http.StatusBadRequest, "Invalid request payload")
@@ -50,10 +143,19 @@ func HandleRequest[Req any](handler HandlerFunc[Req]) http.HandlerFunc {
 			req = decodedReq
 		}
 
+		if err := Bind(r, &req); err != nil {
+			RespondWithAppError(w, r, err)
+			return
+		}
+
+		if err := Validate(req); err != nil {
+			RespondWithAppError(w, r, err)
+			return
+		}
+
 		data, err := handler(req, r)
 		if err != nil {
-			status := http.StatusInternalServerError
-			RespondWithError(w, status, err.Error())
+			RespondWithAppError(w, r, err)
 			return
 		}
 
@@ -61,19 +163,38 @@ func HandleRequest[Req any](handler HandlerFunc[Req]) http.HandlerFunc {
 		switch r.Method {
 		case http.MethodPost:
 			message = "created successfully"
-		case http.MethodPut:
+		case http.MethodPut, http.MethodPatch:
 			message = "updated successfully"
 		case http.MethodDelete:
 			message = "deleted successfully"
 		}
 
-		RespondWithJSON(w, getSuccessStatus(r.Method), ApiResponse{
-			Message: message,
-			Data:    data,
-		})
+		status := getSuccessStatus(r.Method)
+		response := ApiResponse{Message: message, Data: data}
+		if resp, ok := data.(Response); ok {
+			for key, values := range resp.Header {
+				w.Header()[key] = values
+			}
+			if resp.Status == http.StatusNotModified {
+				w.WriteHeader(resp.Status)
+				return
+			}
+			if resp.Status != 0 {
+				status = resp.Status
+			}
+			response.Data = resp.Data
+			response.Meta = resp.Meta
+		}
+
+		RespondWithJSON(w, status, response)
 	}
 }
 
+func isMultipart(r *http.Request) bool {
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	return strings.HasPrefix(mediaType, "multipart/")
+}
+
 func getSuccessStatus(method string) int {
 	switch method {
 	case http.MethodPost:
@@ -0,0 +1,84 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"localloop/libs/pkg/errorbuilder"
+)
+
+var errNameTaken = errorbuilder.NewError("name already taken", errorbuilder.ErrConflict)
+
+func TestStatusFromError(t *testing.T) {
+	tests := []struct {
+		err  error
+		want int
+	}{
+		{err: errNameTaken(), want: http.StatusConflict},
+		{err: fmt.Errorf("creating: %w", errNameTaken()), want: http.StatusConflict},
+		{err: errorbuilder.NewError("not yours", errorbuilder.ErrForbidden)(), want: http.StatusForbidden},
+		{err: errorbuilder.NewError("odd", errorbuilder.ErrorCode(42))(), want: http.StatusInternalServerError},
+		{err: errors.New("connection refused"), want: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		if got := StatusFromError(tt.err); got != tt.want {
+			t.Errorf("StatusFromError(%v) = %d, want %d", tt.err, got, tt.want)
+		}
+	}
+}
+
+func respondWithAppError(t *testing.T, err error) (*httptest.ResponseRecorder, errorbuilder.Problem) {
+	t.Helper()
+
+	w := httptest.NewRecorder()
+	RespondWithAppError(w, httptest.NewRequest(http.MethodPost, "/categories", nil), err)
+
+	if got := w.Header().Get("Content-Type"); got != errorbuilder.ProblemContentType {
+		t.Errorf("Content-Type = %s, want %s", got, errorbuilder.ProblemContentType)
+	}
+	var problem errorbuilder.Problem
+	if err := json.NewDecoder(w.Body).Decode(&problem); err != nil {
+		t.Fatal(err)
+	}
+	return w, problem
+}
+
+func TestRespondWithAppError(t *testing.T) {
+	w, problem := respondWithAppError(t, errNameTaken(
+		errorbuilder.WithDetail("a category named Bikes exists"),
+		errorbuilder.WithContext(map[string]any{"name": "Bikes"}),
+	))
+
+	if w.Code != http.StatusConflict || problem.Status != http.StatusConflict {
+		t.Errorf("status = %d, problem status = %d, want %d", w.Code, problem.Status, http.StatusConflict)
+	}
+	if problem.Type != "urn:localloop:problem:name-already-taken" || problem.Title != "name already taken" {
+		t.Errorf("type = %s, title = %s", problem.Type, problem.Title)
+	}
+	if problem.Detail != "a category named Bikes exists" || problem.Extensions["name"] != "Bikes" {
+		t.Errorf("detail = %q, extensions = %v", problem.Detail, problem.Extensions)
+	}
+	if problem.Instance != "/categories" {
+		t.Errorf("instance = %s, want the request path", problem.Instance)
+	}
+}
+
+func TestRespondWithAppErrorHidesServerErrors(t *testing.T) {
+	_, problem := respondWithAppError(t, errors.New("pq: password authentication failed"))
+	if problem.Status != http.StatusInternalServerError || problem.Type != errorbuilder.BlankProblemType || problem.Detail != "" {
+		t.Errorf("problem = %+v, want a blank internal server error", problem)
+	}
+
+	_, problem = respondWithAppError(t, errorbuilder.NewError("database operation failed", errorbuilder.ErrInternal)(
+		errorbuilder.WithDetail("pq: password authentication failed"),
+		errorbuilder.WithContext(map[string]any{"table": "users"}),
+	))
+	if problem.Detail != "" || len(problem.Extensions) != 0 {
+		t.Errorf("problem exposes detail %q and extensions %v", problem.Detail, problem.Extensions)
+	}
+}
@@ -0,0 +1,228 @@
+package handler
+
+import (
+	"encoding"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+
+	"localloop/libs/pkg/errorbuilder"
+)
+
+var ErrInvalidHeader = errorbuilder.NewError("invalid header", errorbuilder.ErrValidation)
+
+var (
+	uuidType     = reflect.TypeOf(uuid.UUID{})
+	timeType     = reflect.TypeOf(time.Time{})
+	durationType = reflect.TypeOf(time.Duration(0))
+	unmarshaler  = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// bindSource is a part of the request that struct fields can be bound to
+type bindSource struct {
+	tag    string
+	values func(r *http.Request, name string) []string
+	err    func(opts ...errorbuilder.ErrorOption) *errorbuilder.CustomError
+	// key names the malformed value in the error context
+	key string
+}
+
+var bindSources = []bindSource{
+	{
+		tag: "param",
+		values: func(r *http.Request, name string) []string {
+			if value, ok := mux.Vars(r)[name]; ok {
+				return []string{value}
+			}
+			return nil
+		},
+		err: ErrInvalidParam,
+		key: "param",
+	},
+	{
+		tag: "query",
+		values: func(r *http.Request, name string) []string {
+			return r.URL.Query()[name]
+		},
+		err: ErrInvalidQuery,
+		key: "param",
+	},
+	{
+		tag: "header",
+		values: func(r *http.Request, name string) []string {
+			return r.Header.Values(name)
+		},
+		err: ErrInvalidHeader,
+		key: "header",
+	},
+}
+
+// Bind sets the fields of the struct req points to that are tagged with
+// param, query or header from the route variables, the URL query and the
+// headers of r. Fields whose value is absent keep their value. Supported
+// field types are strings, bools, numbers, uuid.UUID, time.Time (RFC 3339 or
+// a plain date), time.Duration, encoding.TextUnmarshaler implementations,
+// pointers to them and, for query and header, slices of them. Slices accept
+// repeated and comma separated values.
+func Bind(r *http.Request, req any) error {
+	v := reflect.ValueOf(req)
+	if v.Kind() != reflect.Pointer || v.Elem().Kind() != reflect.Struct {
+		return nil
+	}
+	return bindStruct(r, v.Elem())
+}
+
+func bindStruct(r *http.Request, v reflect.Value) error {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		if field.Anonymous && field.Type.Kind() == reflect.Struct {
+			if err := bindStruct(r, v.Field(i)); err != nil {
+				return err
+			}
+			continue
+		}
+
+		for _, source := range bindSources {
+			name, _, _ := strings.Cut(field.Tag.Get(source.tag), ",")
+			if name == "" || name == "-" {
+				continue
+			}
+
+			values := source.values(r, name)
+			if len(values) == 0 {
+				continue
+			}
+
+			if err := setField(v.Field(i), values); err != nil {
+				return source.err(
+					errorbuilder.WithContext(map[string]any{
+						source.key: name,
+						"reason":   err.Error(),
+					}),
+					errorbuilder.WithOriginal(err),
+				)
+			}
+		}
+	}
+	return nil
+}
+
+func setField(field reflect.Value, values []string) error {
+	if field.Kind() == reflect.Slice && field.Type() != reflect.TypeOf([]byte(nil)) {
+		var items []string
+		for _, value := range values {
+			for _, item := range strings.Split(value, ",") {
+				if item = strings.TrimSpace(item); item != "" {
+					items = append(items, item)
+				}
+			}
+		}
+
+		slice := reflect.MakeSlice(field.Type(), len(items), len(items))
+		for i, item := range items {
+			if err := setValue(slice.Index(i), item); err != nil {
+				return err
+			}
+		}
+		field.Set(slice)
+		return nil
+	}
+
+	return setValue(field, values[0])
+}
+
+func setValue(field reflect.Value, value string) error {
+	if field.Kind() == reflect.Pointer {
+		ptr := reflect.New(field.Type().Elem())
+		if err := setValue(ptr.Elem(), value); err != nil {
+			return err
+		}
+		field.Set(ptr)
+		return nil
+	}
+
+	switch field.Type() {
+	case uuidType:
+		id, err := uuid.Parse(value)
+		if err != nil {
+			return errors.New("must be a UUID")
+		}
+		field.Set(reflect.ValueOf(id))
+		return nil
+	case timeType:
+		t, err := parseTime(value)
+		if err != nil {
+			return err
+		}
+		field.Set(reflect.ValueOf(t))
+		return nil
+	case durationType:
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return errors.New("must be a duration")
+		}
+		field.SetInt(int64(d))
+		return nil
+	}
+
+	if field.CanAddr() && field.Addr().Type().Implements(unmarshaler) {
+		if err := field.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(value)); err != nil {
+			return fmt.Errorf("is malformed: %v", err)
+		}
+		return nil
+	}
+
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(value)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return errors.New("must be a boolean")
+		}
+		field.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(value, 10, field.Type().Bits())
+		if err != nil {
+			return errors.New("must be an integer")
+		}
+		field.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(value, 10, field.Type().Bits())
+		if err != nil {
+			return errors.New("must be a non-negative integer")
+		}
+		field.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(value, field.Type().Bits())
+		if err != nil {
+			return errors.New("must be a number")
+		}
+		field.SetFloat(f)
+	default:
+		return fmt.Errorf("cannot be bound to %s", field.Type())
+	}
+	return nil
+}
+
+func parseTime(value string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339Nano, value); err == nil {
+		return t, nil
+	}
+	if t, err := time.Parse(time.DateOnly, value); err == nil {
+		return t, nil
+	}
+	return time.Time{}, errors.New("must be an RFC 3339 timestamp or a date")
+}
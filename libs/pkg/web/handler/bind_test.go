@@ -0,0 +1,114 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"localloop/libs/pkg/errorbuilder"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+type level int
+
+func (l *level) UnmarshalText(text []byte) error {
+	*l = level(len(text))
+	return nil
+}
+
+type Paging struct {
+	Limit int `query:"limit"`
+}
+
+type bindRequest struct {
+	Paging
+	ID       uuid.UUID     `param:"id"`
+	Name     string        `json:"name"`
+	Tags     []string      `query:"tag"`
+	Since    time.Time     `query:"since"`
+	Within   time.Duration `query:"within"`
+	Draft    *bool         `query:"draft"`
+	Level    level         `query:"level"`
+	IfMatch  string        `header:"If-Match"`
+	Untagged string
+}
+
+func newBindRequest(target string, vars map[string]string) *http.Request {
+	r := httptest.NewRequest(http.MethodGet, target, nil)
+	return mux.SetURLVars(r, vars)
+}
+
+func TestBind(t *testing.T) {
+	id := uuid.New()
+	r := newBindRequest("/?limit=10&tag=a,b&tag=c&since=2024-05-01&within=1h&draft=true&level=high&Untagged=x", map[string]string{"id": id.String()})
+	r.Header.Set("If-Match", `"v1"`)
+
+	req := bindRequest{Name: "decoded from the body"}
+	if err := Bind(r, &req); err != nil {
+		t.Fatalf("Bind() error = %v", err)
+	}
+
+	draft := true
+	want := bindRequest{
+		Paging:  Paging{Limit: 10},
+		ID:      id,
+		Name:    "decoded from the body",
+		Tags:    []string{"a", "b", "c"},
+		Since:   time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC),
+		Within:  time.Hour,
+		Draft:   &draft,
+		Level:   4,
+		IfMatch: `"v1"`,
+	}
+	if !reflect.DeepEqual(req, want) {
+		t.Errorf("Bind() = %+v, want %+v", req, want)
+	}
+}
+
+func TestBindRejectsMalformedValues(t *testing.T) {
+	tests := []struct {
+		name   string
+		target string
+		vars   map[string]string
+		header string
+		want   string
+		key    string
+		value  string
+	}{
+		{name: "param", target: "/", vars: map[string]string{"id": "42"}, want: "invalid path parameter", key: "param", value: "id"},
+		{name: "query", target: "/?limit=ten", want: "invalid query parameter", key: "param", value: "limit"},
+		{name: "time", target: "/?since=yesterday", want: "invalid query parameter", key: "param", value: "since"},
+		{name: "header", target: "/", header: "maybe", want: "invalid header", key: "header", value: "X-Strict"},
+	}
+
+	type request struct {
+		ID     uuid.UUID `param:"id"`
+		Limit  int       `query:"limit"`
+		Since  time.Time `query:"since"`
+		Strict bool      `header:"X-Strict"`
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newBindRequest(tt.target, tt.vars)
+			if tt.header != "" {
+				r.Header.Set("X-Strict", tt.header)
+			}
+
+			err := Bind(r, &request{})
+			if StatusFromError(err) != http.StatusBadRequest || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("Bind() error = %v, want %s", err, tt.want)
+			}
+			var appErr *errorbuilder.CustomError
+			if !errors.As(err, &appErr) || appErr.Context[tt.key] != tt.value {
+				t.Errorf("Bind() error context = %v, want %s %s", appErr.Context, tt.key, tt.value)
+			}
+		})
+	}
+}
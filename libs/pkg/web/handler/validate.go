@@ -0,0 +1,145 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+
+	"localloop/libs/pkg/errorbuilder"
+	"localloop/libs/pkg/patch"
+)
+
+var ErrRequestValidation = errorbuilder.NewError("request validation failed", errorbuilder.ErrValidation)
+
+// InvalidParam describes a request field that failed validation. Fields are
+// named as they appear in the request, e.g. by their json tag.
+type InvalidParam struct {
+	Name   string `json:"name"`
+	Reason string `json:"reason"`
+}
+
+var validate = newValidator()
+
+func newValidator() *validator.Validate {
+	v := validator.New(validator.WithRequiredStructEnabled())
+	v.RegisterTagNameFunc(fieldName)
+	v.RegisterCustomTypeFunc(patchValue,
+		patch.Field[string]{}, patch.Field[int32]{}, patch.Field[float64]{},
+	)
+	return v
+}
+
+// patchValue validates merge patch members by the value they set, members
+// that are absent or null are empty
+func patchValue(field reflect.Value) any {
+	if f, ok := field.Interface().(interface{ Validated() any }); ok {
+		return f.Validated()
+	}
+	return nil
+}
+
+// fieldName names a field by the first tag it is bound with
+func fieldName(field reflect.StructField) string {
+	for _, key := range []string{"json", "param", "query", "header"} {
+		name, _, _ := strings.Cut(field.Tag.Get(key), ",")
+		if name == "-" {
+			return ""
+		}
+		if name != "" {
+			return name
+		}
+	}
+	return field.Name
+}
+
+// Validate evaluates the validate struct tags of req and reports every
+// invalid field in the "invalidParams" context of a single error. Values that
+// are not structs have nothing to validate.
+func Validate(req any) error {
+	err := validate.Struct(req)
+	if err == nil {
+		return nil
+	}
+
+	var invalidValidation *validator.InvalidValidationError
+	if errors.As(err, &invalidValidation) {
+		return nil
+	}
+
+	var fieldErrs validator.ValidationErrors
+	if !errors.As(err, &fieldErrs) {
+		return ErrRequestValidation(errorbuilder.WithOriginal(err))
+	}
+
+	params := make([]InvalidParam, 0, len(fieldErrs))
+	for _, fieldErr := range fieldErrs {
+		params = append(params, InvalidParam{
+			Name:   paramName(fieldErr),
+			Reason: reason(fieldErr),
+		})
+	}
+
+	return ErrRequestValidation(
+		errorbuilder.WithContext(map[string]any{
+			"invalidParams": params,
+		}),
+		errorbuilder.WithOriginal(err),
+	)
+}
+
+// paramName drops the name of the request type from the field's namespace
+func paramName(fieldErr validator.FieldError) string {
+	_, name, found := strings.Cut(fieldErr.Namespace(), ".")
+	if !found {
+		return fieldErr.Field()
+	}
+	return name
+}
+
+func reason(fieldErr validator.FieldError) string {
+	param := fieldErr.Param()
+	switch fieldErr.Tag() {
+	case "required":
+		return "is required"
+	case "min":
+		return bound("at least", fieldErr)
+	case "max":
+		return bound("at most", fieldErr)
+	case "len":
+		return bound("exactly", fieldErr)
+	case "gt":
+		return "must be greater than " + param
+	case "gte":
+		return "must be greater than or equal to " + param
+	case "lt":
+		return "must be less than " + param
+	case "lte":
+		return "must be less than or equal to " + param
+	case "oneof":
+		return "must be one of " + strings.Join(strings.Fields(param), ", ")
+	case "email":
+		return "must be a valid email address"
+	case "url":
+		return "must be a valid URL"
+	case "uuid":
+		return "must be a UUID"
+	default:
+		return fmt.Sprintf("failed %q validation", fieldErr.Tag())
+	}
+}
+
+// bound explains a min, max or len rule, which limits the length of strings
+// and collections and the value of numbers
+func bound(limit string, fieldErr validator.FieldError) string {
+	switch fieldErr.Kind() {
+	case reflect.String:
+		return fmt.Sprintf("must be %s %s characters long", limit, fieldErr.Param())
+	case reflect.Slice, reflect.Map, reflect.Array:
+		return fmt.Sprintf("must contain %s %s elements", limit, fieldErr.Param())
+	default:
+		return fmt.Sprintf("must be %s %s", limit, fieldErr.Param())
+	}
+}
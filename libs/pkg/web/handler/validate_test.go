@@ -0,0 +1,75 @@
+package handler
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"localloop/libs/pkg/errorbuilder"
+	"localloop/libs/pkg/patch"
+)
+
+type createRequest struct {
+	Name  string `json:"name" validate:"required,max=5"`
+	Count int    `query:"count" validate:"gte=0"`
+}
+
+type patchRequest struct {
+	Name patch.Field[string] `json:"name" validate:"omitempty,max=5"`
+}
+
+func invalidParams(t *testing.T, err error) []InvalidParam {
+	t.Helper()
+	if err == nil {
+		return nil
+	}
+
+	var appErr *errorbuilder.CustomError
+	if !errors.As(err, &appErr) || appErr.Code != errorbuilder.ErrValidation {
+		t.Fatalf("Validate() error = %v, want a validation error", err)
+	}
+	params, _ := appErr.Context["invalidParams"].([]InvalidParam)
+	return params
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name string
+		req  any
+		want []InvalidParam
+	}{
+		{name: "valid", req: createRequest{Name: "shoe"}},
+		{
+			name: "every invalid field",
+			req:  createRequest{Count: -1},
+			want: []InvalidParam{
+				{Name: "name", Reason: "is required"},
+				{Name: "count", Reason: "must be greater than or equal to 0"},
+			},
+		},
+		{
+			name: "too long",
+			req:  createRequest{Name: strings.Repeat("a", 6)},
+			want: []InvalidParam{{Name: "name", Reason: "must be at most 5 characters long"}},
+		},
+		{name: "absent patch member", req: patchRequest{}},
+		{name: "null patch member", req: patchRequest{Name: patch.Null[string]()}},
+		{name: "valid patch member", req: patchRequest{Name: patch.Value("shoe")}},
+		{
+			name: "invalid patch member",
+			req:  patchRequest{Name: patch.Value(strings.Repeat("a", 6))},
+			want: []InvalidParam{{Name: "name", Reason: "must be at most 5 characters long"}},
+		},
+		{name: "not a struct", req: "name"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := invalidParams(t, Validate(tt.req))
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("invalid params = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,163 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/golang-jwt/jwt"
+
+	"localloop/libs/pkg/jwks"
+	"localloop/libs/pkg/web/handler"
+)
+
+// Claims are the access token claims issued by the user service. Subject
+// holds the user ID.
+type Claims struct {
+	Email string   `json:"email"`
+	Roles []string `json:"roles,omitempty"`
+	jwt.StandardClaims
+}
+
+// HasRole reports whether the token grants role
+func (c *Claims) HasRole(role string) bool {
+	for _, r := range c.Roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+type claimsKey struct{}
+
+// ContextWithClaims returns a copy of ctx carrying the authenticated claims
+func ContextWithClaims(ctx context.Context, claims *Claims) context.Context {
+	return context.WithValue(ctx, claimsKey{}, claims)
+}
+
+// ClaimsFromContext returns the claims stored by Authenticate
+func ClaimsFromContext(ctx context.Context) (*Claims, bool) {
+	claims, ok := ctx.Value(claimsKey{}).(*Claims)
+	return claims, ok
+}
+
+var (
+	ErrMissingToken = errors.New("no token provided")
+	ErrTokenExpired = errors.New("token has expired")
+	ErrInvalidToken = errors.New("invalid token")
+)
+
+// Authenticator validates bearer tokens and stores their claims in the
+// request context.
+type Authenticator struct {
+	keyFunc jwt.Keyfunc
+}
+
+// NewAuthenticator creates an Authenticator that resolves verification keys
+// with keyFunc. The keyFunc is responsible for checking the signing method.
+func NewAuthenticator(keyFunc jwt.Keyfunc) *Authenticator {
+	return &Authenticator{keyFunc: keyFunc}
+}
+
+// NewSecretAuthenticator verifies HS256 tokens signed with a shared secret
+func NewSecretAuthenticator(secret []byte) *Authenticator {
+	return NewAuthenticator(func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method %s", token.Method.Alg())
+		}
+		return secret, nil
+	})
+}
+
+// NewJWKSAuthenticator verifies tokens against the keys published by the
+// issuer, selected by the token's kid header
+func NewJWKSAuthenticator(keys *jwks.Client) *Authenticator {
+	return NewAuthenticator(func(token *jwt.Token) (interface{}, error) {
+		kid, _ := token.Header["kid"].(string)
+		if kid == "" {
+			return nil, errors.New("token has no kid header")
+		}
+
+		key, alg, err := keys.Key(context.Background(), kid)
+		if err != nil {
+			return nil, err
+		}
+		if alg != "" && token.Method.Alg() != alg {
+			return nil, fmt.Errorf("unexpected signing method %s for key %q", token.Method.Alg(), kid)
+		}
+		return key, nil
+	})
+}
+
+// Verify parses and validates a raw token
+func (a *Authenticator) Verify(tokenString string) (*Claims, error) {
+	claims := &Claims{}
+	token, err := jwt.ParseWithClaims(tokenString, claims, a.keyFunc)
+	if err != nil {
+		var validationErr *jwt.ValidationError
+		if errors.As(err, &validationErr) && validationErr.Errors&jwt.ValidationErrorExpired != 0 {
+			return nil, ErrTokenExpired
+		}
+		return nil, fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+
+	if !token.Valid {
+		return nil, ErrInvalidToken
+	}
+
+	return claims, nil
+}
+
+// Authenticate rejects requests without a valid bearer token
+func (a *Authenticator) Authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		claims, err := a.authenticate(r)
+		if err != nil {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="localloop"`)
+			handler.RespondWithError(w, http.StatusUnauthorized, "Unauthorized - "+err.Error())
+			return
+		}
+
+		next.ServeHTTP(w, r.WithContext(ContextWithClaims(r.Context(), claims)))
+	})
+}
+
+func (a *Authenticator) authenticate(r *http.Request) (*Claims, error) {
+	token := r.Header.Get("Authorization")
+	if token == "" {
+		return nil, ErrMissingToken
+	}
+
+	// Remove "Bearer " prefix if present
+	if len(token) > 7 && strings.EqualFold(token[:7], "Bearer ") {
+		token = token[7:]
+	}
+
+	return a.Verify(token)
+}
+
+// RequireRole rejects requests whose claims hold none of the given roles. It
+// must run after Authenticate.
+func RequireRole(roles ...string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			claims, ok := ClaimsFromContext(r.Context())
+			if !ok {
+				handler.RespondWithError(w, http.StatusUnauthorized, "Unauthorized - "+ErrMissingToken.Error())
+				return
+			}
+
+			for _, role := range roles {
+				if claims.HasRole(role) {
+					next.ServeHTTP(w, r)
+					return
+				}
+			}
+
+			handler.RespondWithError(w, http.StatusForbidden, "Forbidden - requires role "+strings.Join(roles, " or "))
+		})
+	}
+}
@@ -0,0 +1,125 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+)
+
+var secret = []byte("secret")
+
+func signToken(t *testing.T, key []byte, roles []string, expiresAt time.Time) string {
+	t.Helper()
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, Claims{
+		Email:          "jane@localloop.dev",
+		Roles:          roles,
+		StandardClaims: jwt.StandardClaims{Subject: "jane", ExpiresAt: expiresAt.Unix()},
+	})
+	signed, err := token.SignedString(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return signed
+}
+
+func TestVerify(t *testing.T) {
+	a := NewSecretAuthenticator(secret)
+	hour := time.Now().Add(time.Hour)
+
+	claims, err := a.Verify(signToken(t, secret, nil, hour))
+	if err != nil {
+		t.Fatalf("Verify() error = %v", err)
+	}
+	if claims.Subject != "jane" || claims.Email != "jane@localloop.dev" {
+		t.Errorf("Verify() = %+v", claims)
+	}
+
+	if _, err := a.Verify(signToken(t, secret, nil, time.Now().Add(-time.Hour))); !errors.Is(err, ErrTokenExpired) {
+		t.Errorf("Verify() of an expired token error = %v, want %v", err, ErrTokenExpired)
+	}
+	if _, err := a.Verify(signToken(t, []byte("other"), nil, hour)); !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("Verify() of a forged token error = %v, want %v", err, ErrInvalidToken)
+	}
+}
+
+func TestAuthenticate(t *testing.T) {
+	a := NewSecretAuthenticator(secret)
+	h := a.Authenticate(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if claims, ok := ClaimsFromContext(r.Context()); !ok || claims.Subject != "jane" {
+			t.Errorf("handler ran with claims %+v", claims)
+		}
+	}))
+	token := signToken(t, secret, nil, time.Now().Add(time.Hour))
+
+	tests := []struct {
+		name          string
+		authorization string
+		want          int
+	}{
+		{name: "no token", want: http.StatusUnauthorized},
+		{name: "malformed token", authorization: "Bearer abc", want: http.StatusUnauthorized},
+		{name: "bearer token", authorization: "Bearer " + token, want: http.StatusOK},
+		{name: "without the Bearer prefix", authorization: token, want: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodPost, "/listings", nil)
+			if tt.authorization != "" {
+				r.Header.Set("Authorization", tt.authorization)
+			}
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, r)
+
+			if w.Code != tt.want {
+				t.Errorf("status = %d, want %d", w.Code, tt.want)
+			}
+			if w.Code == http.StatusUnauthorized && w.Header().Get("WWW-Authenticate") == "" {
+				t.Error("401 response without a WWW-Authenticate header")
+			}
+		})
+	}
+}
+
+func TestRequireRole(t *testing.T) {
+	a := NewSecretAuthenticator(secret)
+	h := a.Authenticate(RequireRole("admin")(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {})))
+	hour := time.Now().Add(time.Hour)
+
+	tests := []struct {
+		roles []string
+		want  int
+	}{
+		{roles: nil, want: http.StatusForbidden},
+		{roles: []string{"member"}, want: http.StatusForbidden},
+		{roles: []string{"member", "admin"}, want: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		r := httptest.NewRequest(http.MethodPost, "/categories", nil)
+		r.Header.Set("Authorization", "Bearer "+signToken(t, secret, tt.roles, hour))
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+
+		if w.Code != tt.want {
+			t.Errorf("roles %v: status = %d, want %d", tt.roles, w.Code, tt.want)
+		}
+	}
+}
+
+func TestRequireRoleWithoutAuthenticate(t *testing.T) {
+	h := RequireRole("admin")(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		t.Error("handler ran without claims")
+	}))
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("status = %d, want %d", w.Code, http.StatusUnauthorized)
+	}
+}
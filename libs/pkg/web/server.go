@@ -17,6 +17,7 @@ import (
 type Server struct {
 	httpServer *http.Server
 	Router     *mux.Router
+	onShutdown []func()
 }
 
 // NewServer creates a new Server instance
@@ -29,6 +30,12 @@ func NewServer() *Server {
 	}
 }
 
+// OnShutdown registers fn to be called after the server stopped serving,
+// e.g. to stop background work
+func (s *Server) OnShutdown(fn func()) {
+	s.onShutdown = append(s.onShutdown, fn)
+}
+
 // Run starts the server and handles graceful shutdown
 func (s *Server) Run(port string) error {
 	if port == "" {
@@ -60,7 +67,11 @@ func (s *Server) Run(port string) error {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
-	if err := s.httpServer.Shutdown(ctx); err != nil {
+	err := s.httpServer.Shutdown(ctx)
+	for _, fn := range s.onShutdown {
+		fn()
+	}
+	if err != nil {
 		log.Printf("Server forced to shutdown: %v\n", err)
 		return err
 	}
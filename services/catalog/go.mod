@@ -2,4 +2,8 @@ module localloop/services/catalog
 
 go 1.23.2
 
-require github.com/google/uuid v1.6.0
+require (
+	github.com/google/uuid v1.6.0
+	github.com/gorilla/mux v1.8.1
+	github.com/lib/pq v1.10.9
+)
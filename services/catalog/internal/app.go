@@ -5,13 +5,17 @@ import (
 	"database/sql"
 	"errors"
 	"log"
+	"os"
 	"time"
 
 	"localloop/libs/pkg/db"
+	"localloop/libs/pkg/jwks"
+	"localloop/libs/pkg/web/middleware"
 	"localloop/services/catalog/internal/config"
 	catalog "localloop/services/catalog/internal/domain"
 	"localloop/services/catalog/internal/infrastructure/repository/postgresql"
 	"localloop/services/catalog/internal/infrastructure/web"
+	"localloop/services/catalog/migrations"
 )
 
 type App struct {
@@ -19,6 +23,7 @@ type App struct {
 	PostgresDB     *sql.DB
 	CatalogRepo    catalog.Repository
 	CatalogService *catalog.Service
+	Authenticator  *middleware.Authenticator
 	Server         *web.CatalogManagementServer
 }
 
@@ -61,6 +66,43 @@ func WithPostgresDatabase() Option {
 	}
 }
 
+// WithMigrations applies the pending schema migrations when the config asks
+// for it, see Migrate for running them by hand
+func WithMigrations() Option {
+	return func(app *App) error {
+		if !app.config.MigrateOnStart {
+			return nil
+		}
+		if app.PostgresDB == nil {
+			return errors.New("PostgresDB is not initialized. Make sure to call WithPostgresDatabase first")
+		}
+
+		migrator, err := db.NewMigrator(app.PostgresDB, migrations.FS)
+		if err != nil {
+			return err
+		}
+
+		log.Println("Applying schema migrations")
+		return migrator.Up(context.Background())
+	}
+}
+
+// Migrate runs the arguments of the migrate subcommand, see
+// db.RunMigrateCommand
+func Migrate(cfg *config.Config, args []string) error {
+	app, err := NewApp(cfg, WithPostgresDatabase())
+	if err != nil {
+		return err
+	}
+	defer app.PostgresDB.Close()
+
+	migrator, err := db.NewMigrator(app.PostgresDB, migrations.FS)
+	if err != nil {
+		return err
+	}
+	return db.RunMigrateCommand(context.Background(), migrator, args, os.Stdout)
+}
+
 func WithPostgresCatalogRepository() Option {
 	return func(app *App) error {
 		if app.PostgresDB == nil {
@@ -83,13 +125,31 @@ func WithCatalogService() Option {
 	}
 }
 
+// WithAuthenticator sets up bearer token verification, either against the
+// keys published by the user service or a shared secret
+func WithAuthenticator() Option {
+	return func(app *App) error {
+		if app.config.AuthJWTSecret != "" {
+			app.Authenticator = middleware.NewSecretAuthenticator([]byte(app.config.AuthJWTSecret))
+			return nil
+		}
+
+		app.Authenticator = middleware.NewJWKSAuthenticator(jwks.NewClient(app.config.AuthJWKSURL))
+		return nil
+	}
+}
+
 func WithWebServer() Option {
 	return func(app *App) error {
 		if app.CatalogService == nil {
 			return errors.New("CatalogService is not initialized. Make sure to call WithCatalogService first")
 		}
 
-		app.Server = web.NewCatalogManagementServer(app.CatalogService)
+		if app.Authenticator == nil {
+			return errors.New("Authenticator is not initialized. Make sure to call WithAuthenticator first")
+		}
+
+		app.Server = web.NewCatalogManagementServer(app.CatalogService, app.Authenticator)
 		return nil
 	}
 }
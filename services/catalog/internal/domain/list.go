@@ -0,0 +1,30 @@
+package catalog
+
+import "localloop/libs/pkg/pagination"
+
+// Sort fields supported by the list operations
+const (
+	SortByName      = "name"
+	SortByCreatedAt = "createdAt"
+)
+
+// List specs of the catalog resources. Name filters match case-insensitive
+// substrings, ID filters match exactly.
+var (
+	CategoryListSpec = pagination.Spec{
+		Sorts:   []string{SortByName, SortByCreatedAt},
+		Filters: []string{"parentId", "name"},
+	}
+	FieldListSpec = pagination.Spec{
+		Sorts:   []string{SortByName, SortByCreatedAt},
+		Filters: []string{"fieldTypeId", "name"},
+	}
+	FieldTypeListSpec = pagination.Spec{
+		Sorts:   []string{SortByName, SortByCreatedAt},
+		Filters: []string{"typeDiscriminatorId", "name"},
+	}
+	FieldTypeDiscriminatorListSpec = pagination.Spec{
+		Sorts:   []string{SortByName, SortByCreatedAt},
+		Filters: []string{"name"},
+	}
+)
@@ -3,6 +3,8 @@ package catalog
 import (
 	"context"
 
+	"localloop/libs/pkg/pagination"
+
 	"github.com/google/uuid"
 )
 
@@ -11,32 +13,80 @@ type Repository interface {
 	CreateCategory(ctx context.Context, category *Category) error
 	GetCategory(ctx context.Context, id uuid.UUID) (*Category, error)
 	UpdateCategory(ctx context.Context, category *Category) error
+	PatchCategory(ctx context.Context, params PatchCategoryParams) (*Category, error)
 	DeleteCategory(ctx context.Context, id uuid.UUID) error
-	ListCategories(ctx context.Context) ([]*Category, error)
+	ListCategories(ctx context.Context, query pagination.Query) (pagination.Page[*Category], error)
+	MoveCategory(ctx context.Context, id uuid.UUID, parentID *uuid.UUID) (*Category, error)
+
+	// LockCategory locks a category until the transaction ends and returns
+	// its parent
+	LockCategory(ctx context.Context, id uuid.UUID) (*uuid.UUID, error)
+	// ListChildCategoryIDs returns the direct children of a category
+	ListChildCategoryIDs(ctx context.Context, id uuid.UUID) ([]uuid.UUID, error)
+	// ReparentChildCategories moves the children of a category to parentID
+	ReparentChildCategories(ctx context.Context, id uuid.UUID, parentID *uuid.UUID) error
+	// DeleteCategoryDescendants deletes every category below a category,
+	// together with their field assignments
+	DeleteCategoryDescendants(ctx context.Context, id uuid.UUID) error
+
+	// Category tree operations, returned flat and ordered by depth
+	GetCategoryTree(ctx context.Context) ([]*CategoryNode, error)
+	GetCategorySubtree(ctx context.Context, id uuid.UUID) ([]*CategoryNode, error)
+	GetCategoryAncestors(ctx context.Context, id uuid.UUID) ([]*Category, error)
 
 	// Field operations
 	CreateField(ctx context.Context, field *Field) error
 	GetField(ctx context.Context, id uuid.UUID) (*Field, error)
 	UpdateField(ctx context.Context, field *Field) error
+	PatchField(ctx context.Context, params PatchFieldParams) (*Field, error)
 	DeleteField(ctx context.Context, id uuid.UUID) error
-	ListFields(ctx context.Context) ([]*Field, error)
+	// LockField locks a field until the transaction ends
+	LockField(ctx context.Context, id uuid.UUID) error
+	ListFields(ctx context.Context, query pagination.Query) (pagination.Page[*Field], error)
+	// ListFieldIDsByFieldType returns the fields of a field type
+	ListFieldIDsByFieldType(ctx context.Context, fieldTypeID uuid.UUID) ([]uuid.UUID, error)
+	// DeleteFieldsByFieldType deletes the fields of a field type, together with
+	// their assignments
+	DeleteFieldsByFieldType(ctx context.Context, fieldTypeID uuid.UUID) error
+	// AssignFieldToCategory fails with sql.ErrNoRows when the field is
+	// already assigned to the category
 	AssignFieldToCategory(ctx context.Context, params AssignFieldParams) error
+	// ListAssignedFieldIDs returns the fields assigned to a category itself
+	ListAssignedFieldIDs(ctx context.Context, categoryID uuid.UUID) ([]uuid.UUID, error)
+	// ListAssigningCategoryIDs returns the categories a field is assigned to
+	ListAssigningCategoryIDs(ctx context.Context, fieldID uuid.UUID) ([]uuid.UUID, error)
+	// UpdateCategoryField and UnassignFieldFromCategory fail with
+	// sql.ErrNoRows when the field is not assigned to the category
+	UpdateCategoryField(ctx context.Context, params UpdateCategoryFieldParams) error
+	UnassignFieldFromCategory(ctx context.Context, categoryID, fieldID uuid.UUID) error
+	// ReorderCategoryFields sets the display order of the fields of a
+	// category to their position in fieldIDs
+	ReorderCategoryFields(ctx context.Context, categoryID uuid.UUID, fieldIDs []uuid.UUID) error
+	DeleteCategoryAssignments(ctx context.Context, categoryID uuid.UUID) error
+	DeleteFieldAssignments(ctx context.Context, fieldID uuid.UUID) error
 	GetCategoryFields(ctx context.Context, categoryID uuid.UUID) ([]*CategoryFieldInfo, error)
+	// GetEffectiveCategoryFields returns the closest assignment of every field
+	// along the parent chain of a category, hidden ones included
+	GetEffectiveCategoryFields(ctx context.Context, categoryID uuid.UUID) ([]*EffectiveCategoryField, error)
 
 	// Field Type operations
 	CreateFieldType(ctx context.Context, fieldType *FieldType) error
 	GetFieldType(ctx context.Context, id uuid.UUID) (*FieldType, error)
 	UpdateFieldType(ctx context.Context, fieldType *FieldType) error
+	PatchFieldType(ctx context.Context, params PatchFieldTypeParams) (*FieldType, error)
 	DeleteFieldType(ctx context.Context, id uuid.UUID) error
-	ListFieldTypes(ctx context.Context) ([]*FieldType, error)
+	// LockFieldType locks a field type until the transaction ends
+	LockFieldType(ctx context.Context, id uuid.UUID) error
+	ListFieldTypes(ctx context.Context, query pagination.Query) (pagination.Page[*FieldType], error)
 
 	// Field Type Discriminator operations
 	CreateFieldTypeDiscriminator(ctx context.Context, discriminator *FieldTypeDiscriminator) error
 	GetFieldTypeDiscriminator(ctx context.Context, id uuid.UUID) (*FieldTypeDiscriminator, error)
-	ListFieldTypeDiscriminators(ctx context.Context) ([]*FieldTypeDiscriminator, error)
+	ListFieldTypeDiscriminators(ctx context.Context, query pagination.Query) (pagination.Page[*FieldTypeDiscriminator], error)
 
-	// Transaction support
-	// WithTx(ctx context.Context, fn func(repo Repository) error) error
 	UpdateFieldTypeDiscriminator(ctx context.Context, discriminator *FieldTypeDiscriminator) error
 	DeleteFieldTypeDiscriminator(ctx context.Context, id uuid.UUID) error
+
+	// Transaction support
+	WithTx(ctx context.Context, fn func(repo Repository) error) error
 }
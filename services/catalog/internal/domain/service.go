@@ -3,8 +3,15 @@ package catalog
 import (
 	"context"
 	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
 
 	"localloop/libs/pkg/errorbuilder"
+	"localloop/libs/pkg/jsonschema"
+	"localloop/libs/pkg/pagination"
+	"localloop/libs/pkg/patch"
+	"localloop/libs/pkg/precondition"
 	apperror "localloop/services/catalog/internal/shared/error"
 
 	"github.com/google/uuid"
@@ -26,6 +33,57 @@ func NewService(repo Repository, cfg ServiceConfig) *Service {
 	}
 }
 
+// withTx runs fn with a Service bound to a single transaction so that the
+// existence checks and writes of an operation are applied atomically.
+func (s *Service) withTx(ctx context.Context, fn func(tx *Service) error) error {
+	err := s.repo.WithTx(ctx, func(repo Repository) error {
+		return fn(&Service{repo: repo, cfg: s.cfg})
+	})
+
+	var appErr *errorbuilder.CustomError
+	if err != nil && !errors.As(err, &appErr) {
+		return apperror.ErrDatabaseOperation(
+			errorbuilder.WithOriginal(err),
+		)
+	}
+	return err
+}
+
+// conditionalWrite reports a write that matched no rows as a failed
+// precondition. Writes run after the resource was read in the same
+// transaction, so the row was changed by a concurrent writer.
+func conditionalWrite(err error) error {
+	if errors.Is(err, sql.ErrNoRows) {
+		return precondition.ErrPreconditionFailed(
+			errorbuilder.WithOriginal(err),
+		)
+	}
+	return err
+}
+
+// deleteStrategy resolves the strategy of a deletion, which defaults to
+// DeleteRestrict, against the strategies the entity supports besides it
+func deleteStrategy(strategy DeleteStrategy, supported ...DeleteStrategy) (DeleteStrategy, error) {
+	if strategy == "" || strategy == DeleteRestrict {
+		return DeleteRestrict, nil
+	}
+	if slices.Contains(supported, strategy) {
+		return strategy, nil
+	}
+
+	return "", apperror.ErrInvalidStrategy(
+		apperror.WithValidation("strategy", fmt.Sprintf("unsupported strategy %q", strategy)),
+	)
+}
+
+func idStrings(ids []uuid.UUID) []string {
+	strs := make([]string, len(ids))
+	for i, id := range ids {
+		strs[i] = id.String()
+	}
+	return strs
+}
+
 // Category operations
 func (s *Service) CreateCategory(ctx context.Context, params CreateCategoryParams) (*Category, error) {
 	if params.Name == "" {
@@ -34,17 +92,6 @@ func (s *Service) CreateCategory(ctx context.Context, params CreateCategoryParam
 		)
 	}
 
-	if params.ParentID != nil {
-		// Verify parent exists
-		_, err := s.GetCategory(ctx, *params.ParentID)
-		if err != nil {
-			return nil, apperror.ErrCategoryNotFound(
-				apperror.WithCategory(params.ParentID.String()),
-				errorbuilder.WithOriginal(err),
-			)
-		}
-	}
-
 	category := &Category{
 		ID:          uuid.New(),
 		Name:        params.Name,
@@ -52,10 +99,27 @@ func (s *Service) CreateCategory(ctx context.Context, params CreateCategoryParam
 		ParentID:    params.ParentID,
 	}
 
-	if err := s.repo.CreateCategory(ctx, category); err != nil {
-		return nil, apperror.ErrDatabaseOperation(
-			errorbuilder.WithOriginal(err),
-		)
+	err := s.withTx(ctx, func(tx *Service) error {
+		if params.ParentID != nil {
+			// Verify parent exists
+			_, err := tx.GetCategory(ctx, *params.ParentID)
+			if err != nil {
+				return apperror.ErrCategoryNotFound(
+					apperror.WithCategory(params.ParentID.String()),
+					errorbuilder.WithOriginal(err),
+				)
+			}
+		}
+
+		if err := tx.repo.CreateCategory(ctx, category); err != nil {
+			return apperror.ErrDatabaseOperation(
+				errorbuilder.WithOriginal(err),
+			)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return category, nil
@@ -84,19 +148,262 @@ func (s *Service) UpdateCategory(ctx context.Context, params UpdateCategoryParam
 		ParentID:    params.ParentID,
 	}
 
-	if err := s.repo.UpdateCategory(ctx, category); err != nil {
+	err := s.withTx(ctx, func(tx *Service) error {
+		current, err := tx.GetCategory(ctx, params.ID)
+		if err != nil {
+			return err
+		}
+		if err := precondition.Check(ctx, current.UpdatedAt); err != nil {
+			return err
+		}
+		category.CreatedAt = current.CreatedAt
+
+		if err := tx.checkParent(ctx, params.ID, params.ParentID); err != nil {
+			return err
+		}
+		return conditionalWrite(tx.repo.UpdateCategory(ctx, category))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return category, nil
+}
+
+// PatchCategory applies a merge patch to a category, writing only the
+// members it sets
+func (s *Service) PatchCategory(ctx context.Context, params PatchCategoryParams) (*Category, error) {
+	if params.Name.Set && params.Name.Value == "" {
+		return nil, apperror.ErrInvalidCategoryName(
+			apperror.WithValidation("name", "name cannot be empty"),
+		)
+	}
+
+	var category *Category
+	err := s.withTx(ctx, func(tx *Service) error {
+		current, err := tx.GetCategory(ctx, params.ID)
+		if err != nil {
+			return err
+		}
+		if err := precondition.Check(ctx, current.UpdatedAt); err != nil {
+			return err
+		}
+
+		if params.ParentID.Set {
+			if err := tx.checkParent(ctx, params.ID, params.ParentID.ApplyPtr(nil)); err != nil {
+				return err
+			}
+		}
+
+		category, err = tx.repo.PatchCategory(ctx, params)
+		return conditionalWrite(err)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return category, nil
+}
+
+// DeleteCategory deletes a category. Child categories and field assignments
+// keep it from being deleted unless the strategy cascades the deletion to the
+// subtree or reparents the children. Its own field assignments are removed
+// in both cases. The category is locked before its dependents are checked,
+// which holds off children and assignments added concurrently.
+func (s *Service) DeleteCategory(ctx context.Context, params DeleteCategoryParams) error {
+	strategy, err := deleteStrategy(params.Strategy, DeleteCascade, DeleteReparent)
+	if err != nil {
+		return err
+	}
+
+	return s.withTx(ctx, func(tx *Service) error {
+		current, err := tx.GetCategory(ctx, params.ID)
+		if err != nil {
+			return err
+		}
+		if err := precondition.Check(ctx, current.UpdatedAt); err != nil {
+			return err
+		}
+		if _, err := tx.repo.LockCategory(ctx, params.ID); err != nil {
+			return categoryLockError(params.ID, err)
+		}
+
+		switch strategy {
+		case DeleteRestrict:
+			children, err := tx.repo.ListChildCategoryIDs(ctx, params.ID)
+			if err != nil {
+				return err
+			}
+			fields, err := tx.repo.ListAssignedFieldIDs(ctx, params.ID)
+			if err != nil {
+				return err
+			}
+			if len(children) > 0 || len(fields) > 0 {
+				return apperror.ErrCategoryInUse(
+					apperror.WithDependents(
+						map[string][]string{
+							"categories": idStrings(children),
+							"fields":     idStrings(fields),
+						},
+						string(DeleteCascade), string(DeleteReparent),
+					),
+				)
+			}
+		case DeleteCascade:
+			if err := tx.repo.DeleteCategoryDescendants(ctx, params.ID); err != nil {
+				return err
+			}
+		case DeleteReparent:
+			if err := tx.repo.ReparentChildCategories(ctx, params.ID, current.ParentID); err != nil {
+				return err
+			}
+		}
+
+		if err := tx.repo.DeleteCategoryAssignments(ctx, params.ID); err != nil {
+			return err
+		}
+		return conditionalWrite(tx.repo.DeleteCategory(ctx, params.ID))
+	})
+}
+
+func (s *Service) ListCategories(ctx context.Context, query pagination.Query) (pagination.Page[*Category], error) {
+	return s.repo.ListCategories(ctx, query)
+}
+
+// MoveCategory re-parents a category. A nil ParentID turns it into a root.
+func (s *Service) MoveCategory(ctx context.Context, params MoveCategoryParams) (*Category, error) {
+	var category *Category
+	err := s.withTx(ctx, func(tx *Service) error {
+		current, err := tx.GetCategory(ctx, params.ID)
+		if err != nil {
+			return err
+		}
+		if err := precondition.Check(ctx, current.UpdatedAt); err != nil {
+			return err
+		}
+
+		if err := tx.checkParent(ctx, params.ID, params.ParentID); err != nil {
+			return err
+		}
+
+		category, err = tx.repo.MoveCategory(ctx, params.ID, params.ParentID)
+		return conditionalWrite(err)
+	})
+	if err != nil {
 		return nil, err
 	}
 
 	return category, nil
 }
 
-func (s *Service) DeleteCategory(ctx context.Context, id uuid.UUID) error {
-	return s.repo.DeleteCategory(ctx, id)
+// GetCategoryTree returns the whole category hierarchy as a forest of roots
+func (s *Service) GetCategoryTree(ctx context.Context) ([]*CategoryNode, error) {
+	nodes, err := s.repo.GetCategoryTree(ctx)
+	if err != nil {
+		return nil, apperror.ErrDatabaseOperation(
+			errorbuilder.WithOriginal(err),
+		)
+	}
+
+	return buildCategoryTree(nodes), nil
+}
+
+// GetCategorySubtree returns a category together with all of its descendants
+func (s *Service) GetCategorySubtree(ctx context.Context, id uuid.UUID) (*CategoryNode, error) {
+	nodes, err := s.repo.GetCategorySubtree(ctx, id)
+	if err != nil {
+		return nil, apperror.ErrDatabaseOperation(
+			errorbuilder.WithOriginal(err),
+		)
+	}
+
+	if len(nodes) == 0 {
+		return nil, apperror.ErrCategoryNotFound(
+			apperror.WithCategory(id.String()),
+		)
+	}
+
+	return buildCategoryTree(nodes)[0], nil
+}
+
+// GetCategoryAncestors returns the breadcrumb trail of a category, ordered
+// from the root down to its direct parent.
+func (s *Service) GetCategoryAncestors(ctx context.Context, id uuid.UUID) ([]*Category, error) {
+	if _, err := s.GetCategory(ctx, id); err != nil {
+		return nil, err
+	}
+
+	ancestors, err := s.repo.GetCategoryAncestors(ctx, id)
+	if err != nil {
+		return nil, apperror.ErrDatabaseOperation(
+			errorbuilder.WithOriginal(err),
+		)
+	}
+
+	return ancestors, nil
+}
+
+// checkParent verifies that parentID exists and is neither the category
+// itself nor one of its descendants, which would create a cycle. The category
+// and the path from the parent up to the root are locked first, so moves
+// that could close a cycle together run one after the other.
+func (s *Service) checkParent(ctx context.Context, id uuid.UUID, parentID *uuid.UUID) error {
+	if parentID == nil {
+		return nil
+	}
+
+	if *parentID == id {
+		return apperror.ErrInvalidParent(
+			apperror.WithValidation("parentId", "category cannot be its own parent"),
+		)
+	}
+
+	if _, err := s.GetCategory(ctx, *parentID); err != nil {
+		return err
+	}
+
+	if _, err := s.repo.LockCategory(ctx, id); err != nil {
+		return categoryLockError(id, err)
+	}
+	seen := map[uuid.UUID]bool{id: true}
+	for current := parentID; current != nil && !seen[*current]; {
+		seen[*current] = true
+		next, err := s.repo.LockCategory(ctx, *current)
+		if err != nil {
+			return categoryLockError(*current, err)
+		}
+		current = next
+	}
+
+	subtree, err := s.repo.GetCategorySubtree(ctx, id)
+	if err != nil {
+		return apperror.ErrDatabaseOperation(
+			errorbuilder.WithOriginal(err),
+		)
+	}
+
+	for _, node := range subtree {
+		if node.ID == *parentID {
+			return apperror.ErrInvalidParent(
+				apperror.WithValidation("parentId", "category cannot be moved below one of its descendants"),
+			)
+		}
+	}
+
+	return nil
 }
 
-func (s *Service) ListCategories(ctx context.Context) ([]*Category, error) {
-	return s.repo.ListCategories(ctx)
+// categoryLockError reports a category that was deleted before it could be
+// locked as not found
+func categoryLockError(id uuid.UUID, err error) error {
+	if errors.Is(err, sql.ErrNoRows) {
+		return apperror.ErrCategoryNotFound(
+			apperror.WithCategory(id.String()),
+		)
+	}
+	return apperror.ErrDatabaseOperation(
+		errorbuilder.WithOriginal(err),
+	)
 }
 
 // Field operations
@@ -138,51 +445,286 @@ func (s *Service) UpdateField(ctx context.Context, params UpdateFieldParams) (*F
 		FieldTypeID: params.FieldTypeID,
 	}
 
-	if err := s.repo.UpdateField(ctx, field); err != nil {
+	err := s.withTx(ctx, func(tx *Service) error {
+		current, err := tx.GetField(ctx, params.ID)
+		if err != nil {
+			return err
+		}
+		if err := precondition.Check(ctx, current.UpdatedAt); err != nil {
+			return err
+		}
+		field.CreatedAt = current.CreatedAt
+
+		return conditionalWrite(tx.repo.UpdateField(ctx, field))
+	})
+	if err != nil {
 		return nil, err
 	}
 
 	return field, nil
 }
 
-func (s *Service) DeleteField(ctx context.Context, id uuid.UUID) error {
-	return s.repo.DeleteField(ctx, id)
-}
+// PatchField applies a merge patch to a field, writing only the members it
+// sets
+func (s *Service) PatchField(ctx context.Context, params PatchFieldParams) (*Field, error) {
+	if params.Name.Set && params.Name.Value == "" {
+		return nil, apperror.ErrInvalidFieldName(
+			apperror.WithValidation("name", "name cannot be empty"),
+		)
+	}
+	if params.FieldTypeID.Set && params.FieldTypeID.Value == uuid.Nil {
+		return nil, apperror.ErrInvalidFieldType(
+			apperror.WithValidation("fieldTypeId", "field type cannot be removed"),
+		)
+	}
+
+	var field *Field
+	err := s.withTx(ctx, func(tx *Service) error {
+		current, err := tx.GetField(ctx, params.ID)
+		if err != nil {
+			return err
+		}
+		if err := precondition.Check(ctx, current.UpdatedAt); err != nil {
+			return err
+		}
+
+		if params.FieldTypeID.Set {
+			if _, err := tx.GetFieldType(ctx, params.FieldTypeID.Value); err != nil {
+				return err
+			}
+		}
+
+		field, err = tx.repo.PatchField(ctx, params)
+		return conditionalWrite(err)
+	})
+	if err != nil {
+		return nil, err
+	}
 
-func (s *Service) ListFields(ctx context.Context) ([]*Field, error) {
-	return s.repo.ListFields(ctx)
+	return field, nil
 }
 
-func (s *Service) AssignFieldToCategory(ctx context.Context, params AssignFieldParams) error {
-	// Verify category exists
-	if _, err := s.GetCategory(ctx, params.CategoryID); err != nil {
-		return apperror.ErrCategoryNotFound(
-			apperror.WithCategory(params.CategoryID.String()),
-			errorbuilder.WithOriginal(err),
-		)
+// DeleteField deletes a field. Assignments to categories keep it from being
+// deleted unless the strategy cascades the deletion to them. The field is
+// locked before its assignments are checked.
+func (s *Service) DeleteField(ctx context.Context, params DeleteFieldParams) error {
+	strategy, err := deleteStrategy(params.Strategy, DeleteCascade)
+	if err != nil {
+		return err
 	}
 
-	// Verify field exists
-	if _, err := s.GetField(ctx, params.FieldID); err != nil {
+	return s.withTx(ctx, func(tx *Service) error {
+		current, err := tx.GetField(ctx, params.ID)
+		if err != nil {
+			return err
+		}
+		if err := precondition.Check(ctx, current.UpdatedAt); err != nil {
+			return err
+		}
+		if err := tx.repo.LockField(ctx, params.ID); err != nil {
+			return fieldLockError(params.ID, err)
+		}
+
+		switch strategy {
+		case DeleteRestrict:
+			categories, err := tx.repo.ListAssigningCategoryIDs(ctx, params.ID)
+			if err != nil {
+				return err
+			}
+			if len(categories) > 0 {
+				return apperror.ErrFieldInUse(
+					apperror.WithDependents(
+						map[string][]string{"categories": idStrings(categories)},
+						string(DeleteCascade),
+					),
+				)
+			}
+		case DeleteCascade:
+			if err := tx.repo.DeleteFieldAssignments(ctx, params.ID); err != nil {
+				return err
+			}
+		}
+
+		return conditionalWrite(tx.repo.DeleteField(ctx, params.ID))
+	})
+}
+
+func fieldLockError(id uuid.UUID, err error) error {
+	if errors.Is(err, sql.ErrNoRows) {
 		return apperror.ErrFieldNotFound(
-			apperror.WithField(params.FieldID.String()),
-			errorbuilder.WithOriginal(err),
+			apperror.WithField(id.String()),
 		)
 	}
+	return apperror.ErrDatabaseOperation(
+		errorbuilder.WithOriginal(err),
+	)
+}
 
-	if err := s.repo.AssignFieldToCategory(ctx, params); err != nil {
-		return apperror.ErrDatabaseOperation(
-			errorbuilder.WithOriginal(err),
-		)
+func (s *Service) ListFields(ctx context.Context, query pagination.Query) (pagination.Page[*Field], error) {
+	return s.repo.ListFields(ctx, query)
+}
+
+func (s *Service) AssignFieldToCategory(ctx context.Context, params AssignFieldParams) error {
+	return s.withTx(ctx, func(tx *Service) error {
+		// Verify category exists
+		if _, err := tx.GetCategory(ctx, params.CategoryID); err != nil {
+			return apperror.ErrCategoryNotFound(
+				apperror.WithCategory(params.CategoryID.String()),
+				errorbuilder.WithOriginal(err),
+			)
+		}
+
+		// Verify field exists
+		if _, err := tx.GetField(ctx, params.FieldID); err != nil {
+			return apperror.ErrFieldNotFound(
+				apperror.WithField(params.FieldID.String()),
+				errorbuilder.WithOriginal(err),
+			)
+		}
+
+		// The insert skips existing assignments, which also catches ones
+		// made concurrently
+		err := tx.repo.AssignFieldToCategory(ctx, params)
+		if errors.Is(err, sql.ErrNoRows) {
+			return apperror.ErrFieldAssigned(
+				errorbuilder.WithContext(map[string]any{
+					"categoryId": params.CategoryID.String(),
+					"fieldId":    params.FieldID.String(),
+				}),
+			)
+		}
+		if err != nil {
+			return apperror.ErrDatabaseOperation(
+				errorbuilder.WithOriginal(err),
+			)
+		}
+
+		return nil
+	})
+}
+
+// UpdateCategoryField replaces the settings of a field assignment
+func (s *Service) UpdateCategoryField(ctx context.Context, params UpdateCategoryFieldParams) error {
+	err := s.repo.UpdateCategoryField(ctx, params)
+	return assignmentError(err, params.CategoryID, params.FieldID)
+}
+
+// UnassignFieldFromCategory removes a field assignment. Assignments of the
+// same field on ancestors and descendants are left alone.
+func (s *Service) UnassignFieldFromCategory(ctx context.Context, categoryID, fieldID uuid.UUID) error {
+	err := s.repo.UnassignFieldFromCategory(ctx, categoryID, fieldID)
+	return assignmentError(err, categoryID, fieldID)
+}
+
+// ReorderCategoryFields rewrites the display order of the fields assigned to
+// a category. FieldIDs must list each of them exactly once.
+func (s *Service) ReorderCategoryFields(ctx context.Context, params ReorderCategoryFieldsParams) ([]*CategoryFieldInfo, error) {
+	var fields []*CategoryFieldInfo
+	err := s.withTx(ctx, func(tx *Service) error {
+		if _, err := tx.GetCategory(ctx, params.CategoryID); err != nil {
+			return err
+		}
+
+		assigned, err := tx.repo.ListAssignedFieldIDs(ctx, params.CategoryID)
+		if err != nil {
+			return err
+		}
+		if err := checkFieldOrder(assigned, params.FieldIDs); err != nil {
+			return err
+		}
+
+		if err := tx.repo.ReorderCategoryFields(ctx, params.CategoryID, params.FieldIDs); err != nil {
+			return err
+		}
+
+		fields, err = tx.repo.GetCategoryFields(ctx, params.CategoryID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return fields, nil
+}
+
+// checkFieldOrder verifies that order is a permutation of assigned
+func checkFieldOrder(assigned, order []uuid.UUID) error {
+	seen := make(map[uuid.UUID]bool, len(order))
+	for _, id := range order {
+		if seen[id] {
+			return apperror.ErrInvalidFieldOrder(
+				apperror.WithValidation("fieldIds", fmt.Sprintf("field %s is listed twice", id)),
+			)
+		}
+		if !slices.Contains(assigned, id) {
+			return apperror.ErrInvalidFieldOrder(
+				apperror.WithValidation("fieldIds", fmt.Sprintf("field %s is not assigned to the category", id)),
+			)
+		}
+		seen[id] = true
 	}
 
+	for _, id := range assigned {
+		if !seen[id] {
+			return apperror.ErrInvalidFieldOrder(
+				apperror.WithValidation("fieldIds", fmt.Sprintf("field %s is missing", id)),
+			)
+		}
+	}
 	return nil
 }
 
+// assignmentError converts errors of writes to a single field assignment
+func assignmentError(err error, categoryID, fieldID uuid.UUID) error {
+	if err == nil {
+		return nil
+	}
+	if errors.Is(err, sql.ErrNoRows) {
+		return apperror.ErrFieldNotAssigned(
+			errorbuilder.WithContext(map[string]any{
+				"categoryId": categoryID.String(),
+				"fieldId":    fieldID.String(),
+			}),
+		)
+	}
+	return apperror.ErrDatabaseOperation(
+		errorbuilder.WithOriginal(err),
+	)
+}
+
 func (s *Service) GetCategoryFields(ctx context.Context, categoryID uuid.UUID) ([]*CategoryFieldInfo, error) {
 	return s.repo.GetCategoryFields(ctx, categoryID)
 }
 
+// GetEffectiveCategoryFields merges the field assignments of a category with
+// those inherited from its ancestors. An assignment on a descendant overrides
+// IsRequired and DisplayOrder of the inherited one, and hides the field when
+// IsHidden is set. Hidden fields are only returned when includeHidden is true.
+func (s *Service) GetEffectiveCategoryFields(ctx context.Context, categoryID uuid.UUID, includeHidden bool) ([]*EffectiveCategoryField, error) {
+	if _, err := s.GetCategory(ctx, categoryID); err != nil {
+		return nil, err
+	}
+
+	fields, err := s.repo.GetEffectiveCategoryFields(ctx, categoryID)
+	if err != nil {
+		return nil, apperror.ErrDatabaseOperation(
+			errorbuilder.WithOriginal(err),
+		)
+	}
+
+	if includeHidden {
+		return fields, nil
+	}
+
+	visible := make([]*EffectiveCategoryField, 0, len(fields))
+	for _, field := range fields {
+		if !field.IsHidden {
+			visible = append(visible, field)
+		}
+	}
+	return visible, nil
+}
+
 // Field Type operations
 func (s *Service) CreateFieldType(ctx context.Context, params CreateFieldTypeParams) (*FieldType, error) {
 	fieldType := &FieldType{
@@ -192,7 +734,13 @@ func (s *Service) CreateFieldType(ctx context.Context, params CreateFieldTypePar
 		Properties:          params.Properties,
 	}
 
-	if err := s.repo.CreateFieldType(ctx, fieldType); err != nil {
+	err := s.withTx(ctx, func(tx *Service) error {
+		if err := tx.validateProperties(ctx, params.TypeDiscriminatorID, params.Properties); err != nil {
+			return err
+		}
+		return tx.repo.CreateFieldType(ctx, fieldType)
+	})
+	if err != nil {
 		return nil, err
 	}
 
@@ -200,11 +748,24 @@ func (s *Service) CreateFieldType(ctx context.Context, params CreateFieldTypePar
 }
 
 func (s *Service) GetFieldType(ctx context.Context, id uuid.UUID) (*FieldType, error) {
-	return s.repo.GetFieldType(ctx, id)
+	fieldType, err := s.repo.GetFieldType(ctx, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, apperror.ErrFieldTypeNotFound(
+				errorbuilder.WithContext(map[string]any{
+					"fieldTypeId": id.String(),
+				}),
+			)
+		}
+		return nil, apperror.ErrDatabaseOperation(
+			errorbuilder.WithOriginal(err),
+		)
+	}
+	return fieldType, nil
 }
 
-func (s *Service) ListFieldTypes(ctx context.Context) ([]*FieldType, error) {
-	return s.repo.ListFieldTypes(ctx)
+func (s *Service) ListFieldTypes(ctx context.Context, query pagination.Query) (pagination.Page[*FieldType], error) {
+	return s.repo.ListFieldTypes(ctx, query)
 }
 
 func (s *Service) UpdateFieldType(ctx context.Context, params UpdateFieldTypeParams) (*FieldType, error) {
@@ -215,19 +776,175 @@ func (s *Service) UpdateFieldType(ctx context.Context, params UpdateFieldTypePar
 		Properties:          params.Properties,
 	}
 
-	if err := s.repo.UpdateFieldType(ctx, fieldType); err != nil {
+	err := s.withTx(ctx, func(tx *Service) error {
+		current, err := tx.GetFieldType(ctx, params.ID)
+		if err != nil {
+			return err
+		}
+		if err := precondition.Check(ctx, current.UpdatedAt); err != nil {
+			return err
+		}
+		fieldType.CreatedAt = current.CreatedAt
+
+		if err := tx.validateProperties(ctx, params.TypeDiscriminatorID, params.Properties); err != nil {
+			return err
+		}
+		return conditionalWrite(tx.repo.UpdateFieldType(ctx, fieldType))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return fieldType, nil
+}
+
+// PatchFieldType applies a merge patch to a field type. The resulting
+// properties are validated against the schema of the resulting discriminator.
+func (s *Service) PatchFieldType(ctx context.Context, params PatchFieldTypeParams) (*FieldType, error) {
+	if params.Name.Set && params.Name.Value == "" {
+		return nil, apperror.ErrInvalidFieldType(
+			apperror.WithValidation("name", "name cannot be empty"),
+		)
+	}
+	if params.TypeDiscriminatorID.Set && params.TypeDiscriminatorID.Value == uuid.Nil {
+		return nil, apperror.ErrInvalidFieldType(
+			apperror.WithValidation("typeDiscriminatorId", "discriminator cannot be removed"),
+		)
+	}
+	if params.Properties.Null {
+		return nil, apperror.ErrInvalidProperties(
+			apperror.WithValidation("properties", "properties cannot be removed"),
+		)
+	}
+
+	var fieldType *FieldType
+	err := s.withTx(ctx, func(tx *Service) error {
+		current, err := tx.GetFieldType(ctx, params.ID)
+		if err != nil {
+			return err
+		}
+		if err := precondition.Check(ctx, current.UpdatedAt); err != nil {
+			return err
+		}
+
+		if params.Properties.Set {
+			params.Properties.Value = patch.Merge(current.Properties, params.Properties.Value)
+		}
+
+		if params.TypeDiscriminatorID.Set || params.Properties.Set {
+			discriminatorID := params.TypeDiscriminatorID.Apply(current.TypeDiscriminatorID)
+			properties := params.Properties.Apply(current.Properties)
+			if err := tx.validateProperties(ctx, discriminatorID, properties); err != nil {
+				return err
+			}
+		}
+
+		fieldType, err = tx.repo.PatchFieldType(ctx, params)
+		return conditionalWrite(err)
+	})
+	if err != nil {
 		return nil, err
 	}
 
 	return fieldType, nil
 }
 
-func (s *Service) DeleteFieldType(ctx context.Context, id uuid.UUID) error {
-	return s.repo.DeleteFieldType(ctx, id)
+// DeleteFieldType deletes a field type. Its fields keep it from being deleted
+// unless the strategy cascades the deletion to them and their assignments.
+// The field type is locked before its fields are checked.
+func (s *Service) DeleteFieldType(ctx context.Context, params DeleteFieldTypeParams) error {
+	strategy, err := deleteStrategy(params.Strategy, DeleteCascade)
+	if err != nil {
+		return err
+	}
+
+	return s.withTx(ctx, func(tx *Service) error {
+		current, err := tx.GetFieldType(ctx, params.ID)
+		if err != nil {
+			return err
+		}
+		if err := precondition.Check(ctx, current.UpdatedAt); err != nil {
+			return err
+		}
+		if err := tx.repo.LockFieldType(ctx, params.ID); err != nil {
+			return fieldTypeLockError(params.ID, err)
+		}
+
+		switch strategy {
+		case DeleteRestrict:
+			fields, err := tx.repo.ListFieldIDsByFieldType(ctx, params.ID)
+			if err != nil {
+				return err
+			}
+			if len(fields) > 0 {
+				return apperror.ErrFieldTypeInUse(
+					apperror.WithDependents(
+						map[string][]string{"fields": idStrings(fields)},
+						string(DeleteCascade),
+					),
+				)
+			}
+		case DeleteCascade:
+			if err := tx.repo.DeleteFieldsByFieldType(ctx, params.ID); err != nil {
+				return err
+			}
+		}
+
+		return conditionalWrite(tx.repo.DeleteFieldType(ctx, params.ID))
+	})
+}
+
+func fieldTypeLockError(id uuid.UUID, err error) error {
+	if errors.Is(err, sql.ErrNoRows) {
+		return apperror.ErrFieldTypeNotFound(
+			errorbuilder.WithContext(map[string]any{
+				"fieldTypeId": id.String(),
+			}),
+		)
+	}
+	return apperror.ErrDatabaseOperation(
+		errorbuilder.WithOriginal(err),
+	)
+}
+
+// validateProperties checks field type properties against the validation
+// schema of the referenced discriminator.
+func (s *Service) validateProperties(ctx context.Context, discriminatorID uuid.UUID, properties map[string]interface{}) error {
+	discriminator, err := s.GetFieldTypeDiscriminator(ctx, discriminatorID)
+	if err != nil {
+		return err
+	}
+
+	if properties == nil {
+		properties = map[string]interface{}{}
+	}
+
+	violations, err := jsonschema.Validate(discriminator.ValidationSchema, properties)
+	if err != nil {
+		return apperror.ErrInvalidSchema(
+			errorbuilder.WithContext(map[string]any{
+				"discriminatorId": discriminatorID.String(),
+				"reason":          err.Error(),
+			}),
+			errorbuilder.WithOriginal(err),
+		)
+	}
+
+	if len(violations) > 0 {
+		return apperror.ErrInvalidProperties(
+			apperror.WithViolations(violations),
+		)
+	}
+
+	return nil
 }
 
 // Field Type Discriminator operations
 func (s *Service) CreateFieldTypeDiscriminator(ctx context.Context, params CreateFieldTypeDiscriminatorParams) (*FieldTypeDiscriminator, error) {
+	if err := checkValidationSchema(params.ValidationSchema); err != nil {
+		return nil, err
+	}
+
 	discriminator := &FieldTypeDiscriminator{
 		ID:               uuid.New(),
 		Name:             params.Name,
@@ -243,17 +960,28 @@ func (s *Service) CreateFieldTypeDiscriminator(ctx context.Context, params Creat
 }
 
 func (s *Service) GetFieldTypeDiscriminator(ctx context.Context, id uuid.UUID) (*FieldTypeDiscriminator, error) {
-	return s.repo.GetFieldTypeDiscriminator(ctx, id)
+	discriminator, err := s.repo.GetFieldTypeDiscriminator(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, apperror.ErrDiscriminatorNotFound(
+				errorbuilder.WithContext(map[string]any{
+					"discriminatorId": id.String(),
+				}),
+			)
+		}
+		return nil, apperror.ErrDatabaseOperation(
+			errorbuilder.WithOriginal(err),
+		)
+	}
+	return discriminator, nil
 }
 
-func (s *Service) ListFieldTypeDiscriminators(ctx context.Context) ([]*FieldTypeDiscriminator, error) {
-	return s.repo.ListFieldTypeDiscriminators(ctx)
+func (s *Service) ListFieldTypeDiscriminators(ctx context.Context, query pagination.Query) (pagination.Page[*FieldTypeDiscriminator], error) {
+	return s.repo.ListFieldTypeDiscriminators(ctx, query)
 }
 
 func (s *Service) UpdateFieldTypeDiscriminator(ctx context.Context, params UpdateFieldTypeDiscriminatorParams) (*FieldTypeDiscriminator, error) {
-	// Check if discriminator exists
-	existing, err := s.repo.GetFieldTypeDiscriminator(ctx, params.ID)
-	if err != nil {
+	if err := checkValidationSchema(params.ValidationSchema); err != nil {
 		return nil, err
 	}
 
@@ -262,10 +990,19 @@ func (s *Service) UpdateFieldTypeDiscriminator(ctx context.Context, params Updat
 		Name:             params.Name,
 		Description:      params.Description,
 		ValidationSchema: params.ValidationSchema,
-		CreatedAt:        existing.CreatedAt,
 	}
 
-	if err := s.repo.UpdateFieldTypeDiscriminator(ctx, discriminator); err != nil {
+	err := s.withTx(ctx, func(tx *Service) error {
+		// Check if discriminator exists
+		existing, err := tx.GetFieldTypeDiscriminator(ctx, params.ID)
+		if err != nil {
+			return err
+		}
+		discriminator.CreatedAt = existing.CreatedAt
+
+		return tx.repo.UpdateFieldTypeDiscriminator(ctx, discriminator)
+	})
+	if err != nil {
 		return nil, err
 	}
 
@@ -273,10 +1010,22 @@ func (s *Service) UpdateFieldTypeDiscriminator(ctx context.Context, params Updat
 }
 
 func (s *Service) DeleteFieldTypeDiscriminator(ctx context.Context, id uuid.UUID) error {
-	// Check if discriminator exists
-	if _, err := s.repo.GetFieldTypeDiscriminator(ctx, id); err != nil {
-		return err
-	}
+	return s.withTx(ctx, func(tx *Service) error {
+		// Check if discriminator exists
+		if _, err := tx.GetFieldTypeDiscriminator(ctx, id); err != nil {
+			return err
+		}
 
-	return s.repo.DeleteFieldTypeDiscriminator(ctx, id)
+		return tx.repo.DeleteFieldTypeDiscriminator(ctx, id)
+	})
+}
+
+func checkValidationSchema(schema map[string]interface{}) error {
+	if err := jsonschema.Check(schema); err != nil {
+		return apperror.ErrInvalidSchema(
+			apperror.WithValidation("validationSchema", err.Error()),
+			errorbuilder.WithOriginal(err),
+		)
+	}
+	return nil
 }
@@ -0,0 +1,676 @@
+package catalog
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"localloop/libs/pkg/errorbuilder"
+	"localloop/libs/pkg/precondition"
+
+	"github.com/google/uuid"
+)
+
+// treeRepository keeps a category hierarchy in memory. Methods the tests do
+// not use panic through the embedded nil Repository.
+type treeRepository struct {
+	Repository
+	parents  map[uuid.UUID]*uuid.UUID
+	locked   []uuid.UUID
+	assigned map[[2]uuid.UUID]bool
+}
+
+func (r *treeRepository) WithTx(_ context.Context, fn func(repo Repository) error) error {
+	return fn(r)
+}
+
+func (r *treeRepository) GetCategory(_ context.Context, id uuid.UUID) (*Category, error) {
+	parent, ok := r.parents[id]
+	if !ok {
+		return nil, sql.ErrNoRows
+	}
+	return &Category{ID: id, ParentID: parent}, nil
+}
+
+func (r *treeRepository) LockCategory(_ context.Context, id uuid.UUID) (*uuid.UUID, error) {
+	parent, ok := r.parents[id]
+	if !ok {
+		return nil, sql.ErrNoRows
+	}
+	r.locked = append(r.locked, id)
+	return parent, nil
+}
+
+func (r *treeRepository) GetCategorySubtree(_ context.Context, id uuid.UUID) ([]*CategoryNode, error) {
+	nodes := []*CategoryNode{{Category: Category{ID: id}}}
+	for i := 0; i < len(nodes); i++ {
+		for child, parent := range r.parents {
+			if parent != nil && *parent == nodes[i].ID {
+				nodes = append(nodes, &CategoryNode{Category: Category{ID: child}})
+			}
+		}
+	}
+	return nodes, nil
+}
+
+func (r *treeRepository) GetFieldTypeDiscriminator(context.Context, uuid.UUID) (*FieldTypeDiscriminator, error) {
+	return nil, sql.ErrNoRows
+}
+
+func (r *treeRepository) MoveCategory(_ context.Context, id uuid.UUID, parentID *uuid.UUID) (*Category, error) {
+	r.parents[id] = parentID
+	return &Category{ID: id, ParentID: parentID}, nil
+}
+
+// ListChildCategoryIDs fails unless the category was locked first
+func (r *treeRepository) ListChildCategoryIDs(_ context.Context, id uuid.UUID) ([]uuid.UUID, error) {
+	if len(r.locked) == 0 || r.locked[len(r.locked)-1] != id {
+		return nil, errors.New("dependents checked before the category was locked")
+	}
+
+	var children []uuid.UUID
+	for child, parent := range r.parents {
+		if parent != nil && *parent == id {
+			children = append(children, child)
+		}
+	}
+	return children, nil
+}
+
+func (r *treeRepository) ListAssignedFieldIDs(context.Context, uuid.UUID) ([]uuid.UUID, error) {
+	return nil, nil
+}
+
+func (r *treeRepository) DeleteCategoryAssignments(context.Context, uuid.UUID) error {
+	return nil
+}
+
+func (r *treeRepository) DeleteCategory(_ context.Context, id uuid.UUID) error {
+	delete(r.parents, id)
+	return nil
+}
+
+func (r *treeRepository) GetField(_ context.Context, id uuid.UUID) (*Field, error) {
+	return &Field{ID: id}, nil
+}
+
+func (r *treeRepository) AssignFieldToCategory(_ context.Context, params AssignFieldParams) error {
+	key := [2]uuid.UUID{params.CategoryID, params.FieldID}
+	if r.assigned[key] {
+		return sql.ErrNoRows
+	}
+	r.assigned[key] = true
+	return nil
+}
+
+func TestCheckParent(t *testing.T) {
+	// root
+	// ├── a
+	// │   └── b
+	// └── c
+	root, a, b, c := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	missing := uuid.New()
+	newRepo := func() *treeRepository {
+		return &treeRepository{parents: map[uuid.UUID]*uuid.UUID{
+			root: nil, a: &root, b: &a, c: &root,
+		}}
+	}
+
+	tests := []struct {
+		name       string
+		id         uuid.UUID
+		parentID   *uuid.UUID
+		wantCode   errorbuilder.ErrorCode
+		wantLocked []uuid.UUID
+	}{
+		{name: "to the root", id: b, parentID: nil},
+		{name: "below a sibling", id: a, parentID: &c, wantLocked: []uuid.UUID{a, c, root}},
+		{name: "below a grandparent", id: b, parentID: &root, wantLocked: []uuid.UUID{b, root}},
+		{name: "below itself", id: a, parentID: &a, wantCode: errorbuilder.ErrValidation},
+		{name: "below a descendant", id: a, parentID: &b, wantCode: errorbuilder.ErrValidation, wantLocked: []uuid.UUID{a, b}},
+		{name: "below a missing category", id: a, parentID: &missing, wantCode: errorbuilder.ErrNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newRepo()
+			err := NewService(repo, ServiceConfig{}).checkParent(context.Background(), tt.id, tt.parentID)
+
+			if code := errorCode(err); code != tt.wantCode {
+				t.Fatalf("checkParent() error = %v, want code %d", err, tt.wantCode)
+			}
+			if len(repo.locked) != len(tt.wantLocked) {
+				t.Fatalf("locked %v, want %v", repo.locked, tt.wantLocked)
+			}
+			for i := range repo.locked {
+				if repo.locked[i] != tt.wantLocked[i] {
+					t.Fatalf("locked %v, want %v", repo.locked, tt.wantLocked)
+				}
+			}
+		})
+	}
+}
+
+func TestMissingDiscriminatorIsNotFound(t *testing.T) {
+	s := NewService(&treeRepository{}, ServiceConfig{})
+	ctx := context.Background()
+
+	if _, err := s.GetFieldTypeDiscriminator(ctx, uuid.New()); errorCode(err) != errorbuilder.ErrNotFound {
+		t.Errorf("GetFieldTypeDiscriminator() error = %v, want not found", err)
+	}
+	if err := s.DeleteFieldTypeDiscriminator(ctx, uuid.New()); errorCode(err) != errorbuilder.ErrNotFound {
+		t.Errorf("DeleteFieldTypeDiscriminator() error = %v, want not found", err)
+	}
+}
+
+func TestMoveCategoryChecksIfMatch(t *testing.T) {
+	root, a := uuid.New(), uuid.New()
+	repo := &treeRepository{parents: map[uuid.UUID]*uuid.UUID{root: nil, a: nil}}
+	s := NewService(repo, ServiceConfig{})
+	params := MoveCategoryParams{ID: a, ParentID: &root}
+
+	stale := precondition.WithIfMatch(context.Background(), time.Now())
+	if _, err := s.MoveCategory(stale, params); errorCode(err) != errorbuilder.ErrPreconditionFailed {
+		t.Fatalf("MoveCategory() with a stale version error = %v, want a failed precondition", err)
+	}
+	if repo.parents[a] != nil {
+		t.Fatal("MoveCategory() with a stale version moved the category")
+	}
+
+	current := precondition.WithIfMatch(context.Background(), time.Time{})
+	if _, err := s.MoveCategory(current, params); err != nil {
+		t.Fatalf("MoveCategory() error = %v", err)
+	}
+	if parent := repo.parents[a]; parent == nil || *parent != root {
+		t.Errorf("parent = %v, want %v", parent, root)
+	}
+}
+
+func TestDeleteCategoryLocksBeforeCheckingDependents(t *testing.T) {
+	root, a := uuid.New(), uuid.New()
+	repo := &treeRepository{parents: map[uuid.UUID]*uuid.UUID{root: nil, a: &root}}
+	s := NewService(repo, ServiceConfig{})
+	ctx := context.Background()
+
+	if err := s.DeleteCategory(ctx, DeleteCategoryParams{ID: root}); errorCode(err) != errorbuilder.ErrConflict {
+		t.Fatalf("DeleteCategory() of a parent error = %v, want a conflict", err)
+	}
+	if err := s.DeleteCategory(ctx, DeleteCategoryParams{ID: a}); err != nil {
+		t.Fatalf("DeleteCategory() error = %v", err)
+	}
+	if _, ok := repo.parents[a]; ok {
+		t.Error("DeleteCategory() kept the category")
+	}
+}
+
+func TestAssignFieldToCategoryRejectsDuplicates(t *testing.T) {
+	category := uuid.New()
+	repo := &treeRepository{
+		parents:  map[uuid.UUID]*uuid.UUID{category: nil},
+		assigned: map[[2]uuid.UUID]bool{},
+	}
+	s := NewService(repo, ServiceConfig{})
+	params := AssignFieldParams{CategoryID: category, FieldID: uuid.New()}
+
+	if err := s.AssignFieldToCategory(context.Background(), params); err != nil {
+		t.Fatalf("AssignFieldToCategory() error = %v", err)
+	}
+	if err := s.AssignFieldToCategory(context.Background(), params); errorCode(err) != errorbuilder.ErrConflict {
+		t.Errorf("AssignFieldToCategory() again error = %v, want a conflict", err)
+	}
+}
+
+// fieldsRepository resolves the same effective fields for each of its
+// categories
+type fieldsRepository struct {
+	Repository
+	categories map[uuid.UUID]bool
+	effective  []*EffectiveCategoryField
+}
+
+func (r *fieldsRepository) GetCategory(_ context.Context, id uuid.UUID) (*Category, error) {
+	if !r.categories[id] {
+		return nil, sql.ErrNoRows
+	}
+	return &Category{ID: id}, nil
+}
+
+func (r *fieldsRepository) GetEffectiveCategoryFields(context.Context, uuid.UUID) ([]*EffectiveCategoryField, error) {
+	return r.effective, nil
+}
+
+func TestGetEffectiveCategoryFieldsHidesHiddenFields(t *testing.T) {
+	root, child := uuid.New(), uuid.New()
+	shown := &EffectiveCategoryField{CategoryFieldInfo: CategoryFieldInfo{Field: &Field{ID: uuid.New()}}, SourceCategoryID: root, Inherited: true}
+	hidden := &EffectiveCategoryField{CategoryFieldInfo: CategoryFieldInfo{Field: &Field{ID: uuid.New()}, IsHidden: true}, SourceCategoryID: child, Overridden: true}
+	repo := &fieldsRepository{
+		categories: map[uuid.UUID]bool{root: true, child: true},
+		effective:  []*EffectiveCategoryField{shown, hidden},
+	}
+	s := NewService(repo, ServiceConfig{})
+	ctx := context.Background()
+
+	fields, err := s.GetEffectiveCategoryFields(ctx, child, false)
+	if err != nil {
+		t.Fatalf("GetEffectiveCategoryFields() error = %v", err)
+	}
+	if len(fields) != 1 || fields[0] != shown {
+		t.Errorf("GetEffectiveCategoryFields() = %v, want only the visible field", fields)
+	}
+
+	fields, err = s.GetEffectiveCategoryFields(ctx, child, true)
+	if err != nil {
+		t.Fatalf("GetEffectiveCategoryFields() error = %v", err)
+	}
+	if len(fields) != 2 {
+		t.Errorf("GetEffectiveCategoryFields() with hidden fields returned %d fields, want 2", len(fields))
+	}
+
+	if _, err := s.GetEffectiveCategoryFields(ctx, uuid.New(), true); errorCode(err) != errorbuilder.ErrNotFound {
+		t.Errorf("GetEffectiveCategoryFields() of a missing category error = %v, want not found", err)
+	}
+}
+
+// dependentsRepository keeps fields, their field types and their assignments
+// in memory. Dependents can only be listed once their owner is locked.
+type dependentsRepository struct {
+	Repository
+	fieldTypes  map[uuid.UUID]bool
+	fields      map[uuid.UUID]uuid.UUID
+	assignments map[uuid.UUID][]uuid.UUID
+	locked      []uuid.UUID
+}
+
+func newDependentsRepository() *dependentsRepository {
+	return &dependentsRepository{
+		fieldTypes:  make(map[uuid.UUID]bool),
+		fields:      make(map[uuid.UUID]uuid.UUID),
+		assignments: make(map[uuid.UUID][]uuid.UUID),
+	}
+}
+
+func (r *dependentsRepository) lastLocked(id uuid.UUID) error {
+	if len(r.locked) == 0 || r.locked[len(r.locked)-1] != id {
+		return errors.New("dependents checked before their owner was locked")
+	}
+	return nil
+}
+
+func (r *dependentsRepository) WithTx(_ context.Context, fn func(repo Repository) error) error {
+	return fn(r)
+}
+
+func (r *dependentsRepository) GetField(_ context.Context, id uuid.UUID) (*Field, error) {
+	fieldTypeID, ok := r.fields[id]
+	if !ok {
+		return nil, sql.ErrNoRows
+	}
+	return &Field{ID: id, FieldTypeID: fieldTypeID}, nil
+}
+
+func (r *dependentsRepository) LockField(_ context.Context, id uuid.UUID) error {
+	if _, ok := r.fields[id]; !ok {
+		return sql.ErrNoRows
+	}
+	r.locked = append(r.locked, id)
+	return nil
+}
+
+func (r *dependentsRepository) ListAssigningCategoryIDs(_ context.Context, id uuid.UUID) ([]uuid.UUID, error) {
+	if err := r.lastLocked(id); err != nil {
+		return nil, err
+	}
+	return r.assignments[id], nil
+}
+
+func (r *dependentsRepository) DeleteFieldAssignments(_ context.Context, id uuid.UUID) error {
+	if err := r.lastLocked(id); err != nil {
+		return err
+	}
+	delete(r.assignments, id)
+	return nil
+}
+
+func (r *dependentsRepository) DeleteField(_ context.Context, id uuid.UUID) error {
+	delete(r.fields, id)
+	return nil
+}
+
+func (r *dependentsRepository) GetFieldType(_ context.Context, id uuid.UUID) (*FieldType, error) {
+	if !r.fieldTypes[id] {
+		return nil, sql.ErrNoRows
+	}
+	return &FieldType{ID: id}, nil
+}
+
+func (r *dependentsRepository) LockFieldType(_ context.Context, id uuid.UUID) error {
+	if !r.fieldTypes[id] {
+		return sql.ErrNoRows
+	}
+	r.locked = append(r.locked, id)
+	return nil
+}
+
+func (r *dependentsRepository) ListFieldIDsByFieldType(_ context.Context, id uuid.UUID) ([]uuid.UUID, error) {
+	if err := r.lastLocked(id); err != nil {
+		return nil, err
+	}
+	var fields []uuid.UUID
+	for field, fieldTypeID := range r.fields {
+		if fieldTypeID == id {
+			fields = append(fields, field)
+		}
+	}
+	return fields, nil
+}
+
+func (r *dependentsRepository) DeleteFieldsByFieldType(_ context.Context, id uuid.UUID) error {
+	if err := r.lastLocked(id); err != nil {
+		return err
+	}
+	for field, fieldTypeID := range r.fields {
+		if fieldTypeID == id {
+			delete(r.fields, field)
+			delete(r.assignments, field)
+		}
+	}
+	return nil
+}
+
+func (r *dependentsRepository) DeleteFieldType(_ context.Context, id uuid.UUID) error {
+	delete(r.fieldTypes, id)
+	return nil
+}
+
+func TestDeleteField(t *testing.T) {
+	tests := []struct {
+		name     string
+		assigned bool
+		strategy DeleteStrategy
+		missing  bool
+		wantCode errorbuilder.ErrorCode
+	}{
+		{name: "unassigned field"},
+		{name: "assigned field", assigned: true, wantCode: errorbuilder.ErrConflict},
+		{name: "restrict", assigned: true, strategy: DeleteRestrict, wantCode: errorbuilder.ErrConflict},
+		{name: "cascade", assigned: true, strategy: DeleteCascade},
+		{name: "reparent", strategy: DeleteReparent, wantCode: errorbuilder.ErrValidation},
+		{name: "missing field", missing: true, wantCode: errorbuilder.ErrNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newDependentsRepository()
+			id, fieldTypeID := uuid.New(), uuid.New()
+			repo.fieldTypes[fieldTypeID] = true
+			if !tt.missing {
+				repo.fields[id] = fieldTypeID
+			}
+			if tt.assigned {
+				repo.assignments[id] = []uuid.UUID{uuid.New()}
+			}
+			s := NewService(repo, ServiceConfig{})
+
+			err := s.DeleteField(context.Background(), DeleteFieldParams{ID: id, Strategy: tt.strategy})
+			if code := errorCode(err); code != tt.wantCode {
+				t.Fatalf("DeleteField() error = %v, want code %d", err, tt.wantCode)
+			}
+			if tt.missing {
+				return
+			}
+			if _, ok := repo.fields[id]; ok == (tt.wantCode == 0) {
+				t.Errorf("field kept = %v after DeleteField() error %v", ok, err)
+			}
+			if tt.wantCode == 0 && len(repo.assignments[id]) != 0 {
+				t.Error("DeleteField() kept the assignments of the field")
+			}
+		})
+	}
+}
+
+func TestDeleteFieldType(t *testing.T) {
+	tests := []struct {
+		name     string
+		used     bool
+		strategy DeleteStrategy
+		missing  bool
+		wantCode errorbuilder.ErrorCode
+	}{
+		{name: "unused field type"},
+		{name: "field type with fields", used: true, wantCode: errorbuilder.ErrConflict},
+		{name: "cascade", used: true, strategy: DeleteCascade},
+		{name: "reparent", strategy: DeleteReparent, wantCode: errorbuilder.ErrValidation},
+		{name: "missing field type", missing: true, wantCode: errorbuilder.ErrNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newDependentsRepository()
+			id, field := uuid.New(), uuid.New()
+			repo.fieldTypes[id] = !tt.missing
+			if tt.used {
+				repo.fields[field] = id
+				repo.assignments[field] = []uuid.UUID{uuid.New()}
+			}
+			s := NewService(repo, ServiceConfig{})
+
+			err := s.DeleteFieldType(context.Background(), DeleteFieldTypeParams{ID: id, Strategy: tt.strategy})
+			if code := errorCode(err); code != tt.wantCode {
+				t.Fatalf("DeleteFieldType() error = %v, want code %d", err, tt.wantCode)
+			}
+			if tt.missing {
+				return
+			}
+			if repo.fieldTypes[id] == (tt.wantCode == 0) {
+				t.Errorf("field type kept = %v after DeleteFieldType() error %v", repo.fieldTypes[id], err)
+			}
+			if _, ok := repo.fields[field]; ok && tt.wantCode == 0 {
+				t.Error("DeleteFieldType() kept the fields of the field type")
+			}
+		})
+	}
+}
+
+func TestMissingFieldTypeIsNotFound(t *testing.T) {
+	repo := newDependentsRepository()
+	id := uuid.New()
+	repo.fieldTypes[id] = true
+	s := NewService(repo, ServiceConfig{})
+	ctx := context.Background()
+
+	if fieldType, err := s.GetFieldType(ctx, id); err != nil || fieldType.ID != id {
+		t.Fatalf("GetFieldType() = %v, %v", fieldType, err)
+	}
+	if _, err := s.GetFieldType(ctx, uuid.New()); errorCode(err) != errorbuilder.ErrNotFound {
+		t.Errorf("GetFieldType() error = %v, want not found", err)
+	}
+}
+
+// assignmentsRepository keeps the fields assigned to each category itself,
+// in display order. Fields assigned to an ancestor are not listed.
+type assignmentsRepository struct {
+	Repository
+	assigned map[uuid.UUID][]*CategoryFieldInfo
+}
+
+func (r *assignmentsRepository) assignment(categoryID, fieldID uuid.UUID) int {
+	return slices.IndexFunc(r.assigned[categoryID], func(info *CategoryFieldInfo) bool {
+		return info.Field.ID == fieldID
+	})
+}
+
+func (r *assignmentsRepository) WithTx(_ context.Context, fn func(repo Repository) error) error {
+	return fn(r)
+}
+
+func (r *assignmentsRepository) GetCategory(_ context.Context, id uuid.UUID) (*Category, error) {
+	if _, ok := r.assigned[id]; !ok {
+		return nil, sql.ErrNoRows
+	}
+	return &Category{ID: id}, nil
+}
+
+func (r *assignmentsRepository) UpdateCategoryField(_ context.Context, params UpdateCategoryFieldParams) error {
+	i := r.assignment(params.CategoryID, params.FieldID)
+	if i < 0 {
+		return sql.ErrNoRows
+	}
+	info := r.assigned[params.CategoryID][i]
+	info.IsRequired, info.DisplayOrder, info.IsHidden = params.IsRequired, params.DisplayOrder, params.IsHidden
+	return nil
+}
+
+func (r *assignmentsRepository) UnassignFieldFromCategory(_ context.Context, categoryID, fieldID uuid.UUID) error {
+	i := r.assignment(categoryID, fieldID)
+	if i < 0 {
+		return sql.ErrNoRows
+	}
+	r.assigned[categoryID] = slices.Delete(r.assigned[categoryID], i, i+1)
+	return nil
+}
+
+func (r *assignmentsRepository) ListAssignedFieldIDs(_ context.Context, id uuid.UUID) ([]uuid.UUID, error) {
+	var ids []uuid.UUID
+	for _, info := range r.assigned[id] {
+		ids = append(ids, info.Field.ID)
+	}
+	return ids, nil
+}
+
+func (r *assignmentsRepository) ReorderCategoryFields(_ context.Context, id uuid.UUID, fieldIDs []uuid.UUID) error {
+	for order, fieldID := range fieldIDs {
+		r.assigned[id][r.assignment(id, fieldID)].DisplayOrder = int32(order)
+	}
+	slices.SortFunc(r.assigned[id], func(a, b *CategoryFieldInfo) int {
+		return int(a.DisplayOrder - b.DisplayOrder)
+	})
+	return nil
+}
+
+func (r *assignmentsRepository) GetCategoryFields(_ context.Context, id uuid.UUID) ([]*CategoryFieldInfo, error) {
+	return r.assigned[id], nil
+}
+
+// newAssignmentsRepository assigns the first field to root and the others to
+// child, which inherits the first
+func newAssignmentsRepository(root, child uuid.UUID, fields [3]uuid.UUID) *assignmentsRepository {
+	return &assignmentsRepository{assigned: map[uuid.UUID][]*CategoryFieldInfo{
+		root:  {{Field: &Field{ID: fields[0]}}},
+		child: {{Field: &Field{ID: fields[1]}}, {Field: &Field{ID: fields[2]}, DisplayOrder: 1}},
+	}}
+}
+
+func TestUpdateCategoryField(t *testing.T) {
+	root, child := uuid.New(), uuid.New()
+	fields := [3]uuid.UUID{uuid.New(), uuid.New(), uuid.New()}
+	repo := newAssignmentsRepository(root, child, fields)
+	s := NewService(repo, ServiceConfig{})
+	ctx := context.Background()
+
+	if err := s.UpdateCategoryField(ctx, UpdateCategoryFieldParams{CategoryID: child, FieldID: fields[1], IsRequired: true, DisplayOrder: 5}); err != nil {
+		t.Fatalf("UpdateCategoryField() error = %v", err)
+	}
+	if info := repo.assigned[child][0]; !info.IsRequired || info.DisplayOrder != 5 {
+		t.Errorf("assignment = %+v, want it required at 5", info)
+	}
+
+	tests := []struct {
+		name       string
+		categoryID uuid.UUID
+		fieldID    uuid.UUID
+	}{
+		{name: "inherited field", categoryID: child, fieldID: fields[0]},
+		{name: "field of a child", categoryID: root, fieldID: fields[1]},
+		{name: "missing category", categoryID: uuid.New(), fieldID: fields[1]},
+	}
+	for _, tt := range tests {
+		err := s.UpdateCategoryField(ctx, UpdateCategoryFieldParams{CategoryID: tt.categoryID, FieldID: tt.fieldID, IsHidden: true})
+		if errorCode(err) != errorbuilder.ErrNotFound {
+			t.Errorf("%s: UpdateCategoryField() error = %v, want not found", tt.name, err)
+		}
+	}
+	if repo.assigned[root][0].IsHidden {
+		t.Error("updating an inherited field changed the assignment of the ancestor")
+	}
+}
+
+func TestUnassignFieldFromCategory(t *testing.T) {
+	root, child := uuid.New(), uuid.New()
+	fields := [3]uuid.UUID{uuid.New(), uuid.New(), uuid.New()}
+	repo := newAssignmentsRepository(root, child, fields)
+	s := NewService(repo, ServiceConfig{})
+	ctx := context.Background()
+
+	if err := s.UnassignFieldFromCategory(ctx, child, fields[0]); errorCode(err) != errorbuilder.ErrNotFound {
+		t.Errorf("UnassignFieldFromCategory() of an inherited field error = %v, want not found", err)
+	}
+	if len(repo.assigned[root]) != 1 {
+		t.Error("unassigning an inherited field removed the assignment of the ancestor")
+	}
+	if err := s.UnassignFieldFromCategory(ctx, uuid.New(), fields[1]); errorCode(err) != errorbuilder.ErrNotFound {
+		t.Errorf("UnassignFieldFromCategory() of a missing category error = %v, want not found", err)
+	}
+
+	if err := s.UnassignFieldFromCategory(ctx, child, fields[1]); err != nil {
+		t.Fatalf("UnassignFieldFromCategory() error = %v", err)
+	}
+	if ids, _ := repo.ListAssignedFieldIDs(ctx, child); len(ids) != 1 || ids[0] != fields[2] {
+		t.Errorf("assigned fields = %v, want only %v", ids, fields[2])
+	}
+	if err := s.UnassignFieldFromCategory(ctx, child, fields[1]); errorCode(err) != errorbuilder.ErrNotFound {
+		t.Errorf("UnassignFieldFromCategory() twice error = %v, want not found", err)
+	}
+}
+
+func TestReorderCategoryFields(t *testing.T) {
+	root, child := uuid.New(), uuid.New()
+	fields := [3]uuid.UUID{uuid.New(), uuid.New(), uuid.New()}
+
+	tests := []struct {
+		name       string
+		missing    bool
+		fieldIDs   []uuid.UUID
+		wantCode   errorbuilder.ErrorCode
+		wantFields []uuid.UUID
+	}{
+		{name: "every field", fieldIDs: []uuid.UUID{fields[2], fields[1]}, wantFields: []uuid.UUID{fields[2], fields[1]}},
+		{name: "duplicate field", fieldIDs: []uuid.UUID{fields[1], fields[1], fields[2]}, wantCode: errorbuilder.ErrValidation},
+		{name: "missing field", fieldIDs: []uuid.UUID{fields[2]}, wantCode: errorbuilder.ErrValidation},
+		{name: "inherited field", fieldIDs: []uuid.UUID{fields[0], fields[1], fields[2]}, wantCode: errorbuilder.ErrValidation},
+		{name: "unknown field", fieldIDs: []uuid.UUID{fields[1], fields[2], uuid.New()}, wantCode: errorbuilder.ErrValidation},
+		{name: "missing category", missing: true, fieldIDs: []uuid.UUID{fields[1], fields[2]}, wantCode: errorbuilder.ErrNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newAssignmentsRepository(root, child, fields)
+			categoryID := child
+			if tt.missing {
+				categoryID = uuid.New()
+			}
+			s := NewService(repo, ServiceConfig{})
+
+			reordered, err := s.ReorderCategoryFields(context.Background(), ReorderCategoryFieldsParams{CategoryID: categoryID, FieldIDs: tt.fieldIDs})
+			if code := errorCode(err); code != tt.wantCode {
+				t.Fatalf("ReorderCategoryFields() error = %v, want code %d", err, tt.wantCode)
+			}
+			if tt.wantCode != 0 {
+				if ids, _ := repo.ListAssignedFieldIDs(context.Background(), child); !slices.Equal(ids, fields[1:]) {
+					t.Errorf("a rejected order changed the fields to %v", ids)
+				}
+				return
+			}
+
+			var got []uuid.UUID
+			for _, info := range reordered {
+				got = append(got, info.Field.ID)
+			}
+			if !slices.Equal(got, tt.wantFields) {
+				t.Errorf("ReorderCategoryFields() = %v, want %v", got, tt.wantFields)
+			}
+		})
+	}
+}
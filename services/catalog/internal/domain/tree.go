@@ -0,0 +1,26 @@
+package catalog
+
+import "github.com/google/uuid"
+
+// buildCategoryTree links a flat, depth-ordered list of nodes into a forest.
+// Nodes whose parent is not part of the list become roots.
+func buildCategoryTree(nodes []*CategoryNode) []*CategoryNode {
+	byID := make(map[uuid.UUID]*CategoryNode, len(nodes))
+	for _, node := range nodes {
+		node.Children = []*CategoryNode{}
+		byID[node.ID] = node
+	}
+
+	roots := []*CategoryNode{}
+	for _, node := range nodes {
+		if node.ParentID != nil {
+			if parent, ok := byID[*node.ParentID]; ok {
+				parent.Children = append(parent.Children, node)
+				continue
+			}
+		}
+		roots = append(roots, node)
+	}
+
+	return roots
+}
@@ -0,0 +1,94 @@
+package catalog
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"localloop/libs/pkg/errorbuilder"
+
+	"github.com/google/uuid"
+)
+
+func node(id uuid.UUID, parentID *uuid.UUID) *CategoryNode {
+	return &CategoryNode{Category: Category{ID: id, ParentID: parentID}}
+}
+
+func childIDs(n *CategoryNode) []uuid.UUID {
+	ids := make([]uuid.UUID, len(n.Children))
+	for i, child := range n.Children {
+		ids[i] = child.ID
+	}
+	return ids
+}
+
+func errorCode(err error) errorbuilder.ErrorCode {
+	var appErr *errorbuilder.CustomError
+	if errors.As(err, &appErr) {
+		return appErr.Code
+	}
+	return 0
+}
+
+func TestBuildCategoryTree(t *testing.T) {
+	// root
+	// ├── a
+	// │   └── c
+	// └── b
+	// orphan, whose parent is not listed
+	root, a, b, c, orphan := uuid.New(), uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	missing := uuid.New()
+
+	roots := buildCategoryTree([]*CategoryNode{
+		node(root, nil),
+		node(orphan, &missing),
+		node(a, &root),
+		node(b, &root),
+		node(c, &a),
+	})
+
+	if len(roots) != 2 || roots[0].ID != root || roots[1].ID != orphan {
+		t.Fatalf("roots = %v, want root and orphan", roots)
+	}
+	if got := childIDs(roots[0]); len(got) != 2 || got[0] != a || got[1] != b {
+		t.Errorf("children of root = %v, want [%v %v]", got, a, b)
+	}
+	if got := childIDs(roots[0].Children[0]); len(got) != 1 || got[0] != c {
+		t.Errorf("children of a = %v, want [%v]", got, c)
+	}
+	// Leaves have an empty list of children rather than none
+	if leaf := roots[0].Children[1]; leaf.Children == nil {
+		t.Error("children of a leaf = nil, want an empty list")
+	}
+}
+
+// subtreeRepository returns the same depth-ordered subtree for every category
+type subtreeRepository struct {
+	Repository
+	nodes []*CategoryNode
+}
+
+func (r *subtreeRepository) GetCategorySubtree(context.Context, uuid.UUID) ([]*CategoryNode, error) {
+	return r.nodes, nil
+}
+
+func TestGetCategorySubtree(t *testing.T) {
+	parent, root, a := uuid.New(), uuid.New(), uuid.New()
+	// The root of a subtree keeps its parent, which is not part of the list
+	s := NewService(&subtreeRepository{nodes: []*CategoryNode{node(root, &parent), node(a, &root)}}, ServiceConfig{})
+
+	subtree, err := s.GetCategorySubtree(context.Background(), root)
+	if err != nil {
+		t.Fatalf("GetCategorySubtree() error = %v", err)
+	}
+	if subtree.ID != root || len(subtree.Children) != 1 || subtree.Children[0].ID != a {
+		t.Errorf("subtree of root has children %v, want [%v]", childIDs(subtree), a)
+	}
+}
+
+func TestGetCategorySubtreeOfMissingCategory(t *testing.T) {
+	s := NewService(&subtreeRepository{}, ServiceConfig{})
+	if _, err := s.GetCategorySubtree(context.Background(), uuid.New()); errorCode(err) != errorbuilder.ErrNotFound {
+		t.Errorf("GetCategorySubtree() error = %v, want not found", err)
+	}
+}
@@ -3,6 +3,8 @@ package catalog
 import (
 	"time"
 
+	"localloop/libs/pkg/patch"
+
 	"github.com/google/uuid"
 )
 
@@ -15,6 +17,14 @@ type Category struct {
 	UpdatedAt   time.Time
 }
 
+// CategoryNode is a category positioned in the category hierarchy. Depth is
+// relative to the root of the fetched tree.
+type CategoryNode struct {
+	Category
+	Depth    int32
+	Children []*CategoryNode
+}
+
 type Field struct {
 	ID          uuid.UUID
 	Name        string
@@ -46,11 +56,47 @@ type UpdateCategoryParams struct {
 	ParentID    *uuid.UUID
 }
 
+// PatchCategoryParams is a merge patch of a category. Unset members are left
+// unchanged, a null parent turns the category into a root.
+type PatchCategoryParams struct {
+	ID          uuid.UUID
+	Name        patch.Field[string]
+	Description patch.Field[string]
+	ParentID    patch.Field[uuid.UUID]
+}
+
+// DeleteStrategy decides what becomes of the entities that depend on a
+// deleted one
+type DeleteStrategy string
+
+const (
+	// DeleteRestrict refuses to delete entities that have dependents. It is
+	// the default.
+	DeleteRestrict DeleteStrategy = "restrict"
+	// DeleteCascade deletes the dependents along with the entity: the subtree
+	// of a category, the assignments of a field and the fields of a field type
+	DeleteCascade DeleteStrategy = "cascade"
+	// DeleteReparent moves the children of a deleted category to its parent.
+	// Only categories support it.
+	DeleteReparent DeleteStrategy = "reparent"
+)
+
+type DeleteCategoryParams struct {
+	ID       uuid.UUID
+	Strategy DeleteStrategy
+}
+
+type MoveCategoryParams struct {
+	ID       uuid.UUID `validate:"required"`
+	ParentID *uuid.UUID
+}
+
 type AssignFieldParams struct {
 	CategoryID   uuid.UUID `validate:"required"`
 	FieldID      uuid.UUID `validate:"required"`
 	IsRequired   bool
-	DisplayOrder int32 `validate:"required"`
+	DisplayOrder int32 `validate:"gte=0"`
+	IsHidden     bool
 }
 
 type CategoryField struct {
@@ -58,6 +104,7 @@ type CategoryField struct {
 	FieldID      uuid.UUID
 	IsRequired   bool
 	DisplayOrder int32
+	IsHidden     bool
 }
 
 type FieldTypeDiscriminator struct {
@@ -81,6 +128,19 @@ type UpdateFieldParams struct {
 	FieldTypeID uuid.UUID `validate:"required"`
 }
 
+// PatchFieldParams is a merge patch of a field
+type PatchFieldParams struct {
+	ID          uuid.UUID
+	Name        patch.Field[string]
+	Description patch.Field[string]
+	FieldTypeID patch.Field[uuid.UUID]
+}
+
+type DeleteFieldParams struct {
+	ID       uuid.UUID
+	Strategy DeleteStrategy
+}
+
 type CreateFieldTypeParams struct {
 	Name                string                 `validate:"required"`
 	TypeDiscriminatorID uuid.UUID              `validate:"required"`
@@ -100,6 +160,20 @@ type UpdateFieldTypeParams struct {
 	Properties          map[string]interface{} `validate:"required"`
 }
 
+// PatchFieldTypeParams is a merge patch of a field type. Properties are
+// merged into the current ones, members set to null are removed.
+type PatchFieldTypeParams struct {
+	ID                  uuid.UUID
+	Name                patch.Field[string]
+	TypeDiscriminatorID patch.Field[uuid.UUID]
+	Properties          patch.Field[map[string]interface{}]
+}
+
+type DeleteFieldTypeParams struct {
+	ID       uuid.UUID
+	Strategy DeleteStrategy
+}
+
 type UpdateFieldTypeDiscriminatorParams struct {
 	ID               uuid.UUID `validate:"required"`
 	Name             string    `validate:"required"`
@@ -107,8 +181,36 @@ type UpdateFieldTypeDiscriminatorParams struct {
 	ValidationSchema map[string]interface{} `validate:"required"`
 }
 
+// UpdateCategoryFieldParams replaces the settings of an assignment
+type UpdateCategoryFieldParams struct {
+	CategoryID   uuid.UUID `validate:"required"`
+	FieldID      uuid.UUID `validate:"required"`
+	IsRequired   bool
+	DisplayOrder int32 `validate:"gte=0"`
+	IsHidden     bool
+}
+
+// ReorderCategoryFieldsParams lists every field assigned to a category in
+// its new display order
+type ReorderCategoryFieldsParams struct {
+	CategoryID uuid.UUID   `validate:"required"`
+	FieldIDs   []uuid.UUID `validate:"required"`
+}
+
 type CategoryFieldInfo struct {
 	Field        *Field
 	IsRequired   bool
 	DisplayOrder int32
+	IsHidden     bool
+}
+
+// EffectiveCategoryField is a field assignment resolved along the parent
+// chain. SourceCategoryID is the category whose assignment is in effect,
+// OriginCategoryID the topmost ancestor the field is assigned to.
+type EffectiveCategoryField struct {
+	CategoryFieldInfo
+	SourceCategoryID uuid.UUID
+	OriginCategoryID uuid.UUID
+	Inherited        bool
+	Overridden       bool
 }
@@ -0,0 +1,51 @@
+package postgresql
+
+import (
+	"database/sql"
+	"time"
+
+	"localloop/libs/pkg/pagination"
+	catalog "localloop/services/catalog/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+// keyset holds the position after which the list queries continue, typed
+// for the column the list is sorted by
+type keyset struct {
+	id        uuid.NullUUID
+	name      sql.NullString
+	createdAt sql.NullTime
+}
+
+func newKeyset(query pagination.Query) (keyset, error) {
+	if query.After == nil {
+		return keyset{}, nil
+	}
+
+	after := keyset{id: uuid.NullUUID{UUID: query.After.ID, Valid: true}}
+	switch query.Sort {
+	case catalog.SortByCreatedAt:
+		createdAt, err := query.After.Time()
+		if err != nil {
+			return keyset{}, err
+		}
+		after.createdAt = sql.NullTime{Time: createdAt, Valid: true}
+	default:
+		after.name = sql.NullString{String: query.After.Key, Valid: true}
+	}
+	return after, nil
+}
+
+// sortKey returns the value an item is positioned by in the list ordering
+func sortKey(query pagination.Query, name string, createdAt time.Time) string {
+	if query.Sort == catalog.SortByCreatedAt {
+		return pagination.TimeKey(createdAt)
+	}
+	return name
+}
+
+func stringFilter(query pagination.Query, name string) sql.NullString {
+	value, ok := query.Filter(name)
+	return sql.NullString{String: value, Valid: ok}
+}
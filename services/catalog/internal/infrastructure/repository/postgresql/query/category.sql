@@ -11,17 +11,157 @@ SELECT * FROM categories
 WHERE id = $1;
 
 -- name: ListCategories :many
-SELECT * FROM categories;
+SELECT * FROM categories
+WHERE (sqlc.narg('parent_id')::uuid IS NULL OR parent_id = sqlc.narg('parent_id')::uuid)
+  AND (sqlc.narg('name')::text IS NULL OR name ILIKE '%' || sqlc.narg('name')::text || '%')
+  AND (
+    sqlc.narg('after_id')::uuid IS NULL
+    OR (@sort_by::text = 'name' AND NOT @descending::bool
+        AND (name, id) > (sqlc.narg('after_name')::text, sqlc.narg('after_id')::uuid))
+    OR (@sort_by::text = 'name' AND @descending::bool
+        AND (name, id) < (sqlc.narg('after_name')::text, sqlc.narg('after_id')::uuid))
+    OR (@sort_by::text = 'createdAt' AND NOT @descending::bool
+        AND (created_at, id) > (sqlc.narg('after_created_at')::timestamp, sqlc.narg('after_id')::uuid))
+    OR (@sort_by::text = 'createdAt' AND @descending::bool
+        AND (created_at, id) < (sqlc.narg('after_created_at')::timestamp, sqlc.narg('after_id')::uuid))
+  )
+ORDER BY
+    CASE WHEN @sort_by::text = 'name' AND NOT @descending::bool THEN name END ASC,
+    CASE WHEN @sort_by::text = 'name' AND @descending::bool THEN name END DESC,
+    CASE WHEN @sort_by::text = 'createdAt' AND NOT @descending::bool THEN created_at END ASC,
+    CASE WHEN @sort_by::text = 'createdAt' AND @descending::bool THEN created_at END DESC,
+    CASE WHEN NOT @descending::bool THEN id END ASC,
+    CASE WHEN @descending::bool THEN id END DESC
+LIMIT @page_limit;
 
 -- name: UpdateCategory :one
 UPDATE categories
-SET name = $2,
-    description = $3,
-    parent_id = $4,
+SET name = @name,
+    description = @description,
+    parent_id = @parent_id,
+    updated_at = NOW()
+WHERE id = @id
+    AND (sqlc.narg('if_updated_at')::timestamp IS NULL OR updated_at = sqlc.narg('if_updated_at'))
+RETURNING *;
+
+-- name: DeleteCategory :execrows
+DELETE FROM categories
+WHERE id = @id
+    AND (sqlc.narg('if_updated_at')::timestamp IS NULL OR updated_at = sqlc.narg('if_updated_at'));
+
+-- name: MoveCategory :one
+UPDATE categories
+SET parent_id = @parent_id,
     updated_at = NOW()
+WHERE id = @id
+    AND (sqlc.narg('if_updated_at')::timestamp IS NULL OR updated_at = sqlc.narg('if_updated_at'))
+RETURNING *;
+
+-- name: LockCategory :one
+SELECT parent_id FROM categories
 WHERE id = $1
+FOR UPDATE;
+
+-- name: GetCategoryTree :many
+WITH RECURSIVE tree AS (
+    SELECT c.id, c.name, c.description, c.parent_id, c.created_at, c.updated_at,
+           0 AS depth, ARRAY[c.id]::uuid[] AS path
+    FROM categories c
+    WHERE c.parent_id IS NULL
+    UNION ALL
+    SELECT c.id, c.name, c.description, c.parent_id, c.created_at, c.updated_at,
+           t.depth + 1, t.path || c.id
+    FROM categories c
+    JOIN tree t ON c.parent_id = t.id
+    WHERE NOT c.id = ANY(t.path)
+)
+SELECT id, name, description, parent_id, created_at, updated_at, depth
+FROM tree
+ORDER BY depth, name;
+
+-- name: GetCategorySubtree :many
+WITH RECURSIVE subtree AS (
+    SELECT c.id, c.name, c.description, c.parent_id, c.created_at, c.updated_at,
+           0 AS depth, ARRAY[c.id]::uuid[] AS path
+    FROM categories c
+    WHERE c.id = $1
+    UNION ALL
+    SELECT c.id, c.name, c.description, c.parent_id, c.created_at, c.updated_at,
+           s.depth + 1, s.path || c.id
+    FROM categories c
+    JOIN subtree s ON c.parent_id = s.id
+    WHERE NOT c.id = ANY(s.path)
+)
+SELECT id, name, description, parent_id, created_at, updated_at, depth
+FROM subtree
+ORDER BY depth, name;
+
+-- name: GetCategoryAncestors :many
+WITH RECURSIVE ancestors AS (
+    SELECT c.id, c.name, c.description, c.parent_id, c.created_at, c.updated_at,
+           0 AS depth, ARRAY[c.id]::uuid[] AS path
+    FROM categories c
+    WHERE c.id = $1
+    UNION ALL
+    SELECT c.id, c.name, c.description, c.parent_id, c.created_at, c.updated_at,
+           a.depth + 1, a.path || c.id
+    FROM categories c
+    JOIN ancestors a ON c.id = a.parent_id
+    WHERE NOT c.id = ANY(a.path)
+)
+SELECT id, name, description, parent_id, created_at, updated_at, depth
+FROM ancestors
+WHERE depth > 0
+ORDER BY depth DESC;
+
+-- name: PatchCategory :one
+UPDATE categories
+SET name = CASE WHEN @set_name::bool THEN @name::text ELSE name END,
+    description = CASE WHEN @set_description::bool THEN sqlc.narg('description')::text ELSE description END,
+    parent_id = CASE WHEN @set_parent_id::bool THEN sqlc.narg('parent_id')::uuid ELSE parent_id END,
+    updated_at = NOW()
+WHERE id = @id
+    AND (sqlc.narg('if_updated_at')::timestamp IS NULL OR updated_at = sqlc.narg('if_updated_at'))
 RETURNING *;
 
--- name: DeleteCategory :exec
+-- name: ListChildCategoryIDs :many
+SELECT id
+FROM categories
+WHERE parent_id = @parent_id::uuid
+ORDER BY name;
+
+-- name: ReparentChildCategories :exec
+UPDATE categories
+SET parent_id = sqlc.narg('new_parent_id'),
+    updated_at = NOW()
+WHERE parent_id = @id::uuid;
+
+-- name: DeleteCategoryDescendantAssignments :exec
+WITH RECURSIVE descendants AS (
+    SELECT c.id, ARRAY[c.id]::uuid[] AS path
+    FROM categories c
+    WHERE c.parent_id = @parent_id::uuid
+    UNION ALL
+    SELECT c.id, d.path || c.id
+    FROM categories c
+    JOIN descendants d ON c.parent_id = d.id
+    WHERE NOT c.id = ANY(d.path)
+)
+DELETE FROM category_fields
+WHERE category_id IN (SELECT id FROM descendants);
+
+-- name: DeleteCategoryDescendants :exec
+-- Foreign keys are checked at the end of the statement, so the subtree can be
+-- deleted in any order.
+WITH RECURSIVE descendants AS (
+    SELECT c.id, ARRAY[c.id]::uuid[] AS path
+    FROM categories c
+    WHERE c.parent_id = @parent_id::uuid
+    UNION ALL
+    SELECT c.id, d.path || c.id
+    FROM categories c
+    JOIN descendants d ON c.parent_id = d.id
+    WHERE NOT c.id = ANY(d.path)
+)
 DELETE FROM categories
-WHERE id = $1; 
\ No newline at end of file
+WHERE id IN (SELECT id FROM descendants);
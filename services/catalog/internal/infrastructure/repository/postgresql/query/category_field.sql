@@ -1,13 +1,95 @@
--- name: AssignFieldToCategory :exec
+-- name: AssignFieldToCategory :execrows
 INSERT INTO category_fields (
-    category_id, field_id, is_required, display_order
+    category_id, field_id, is_required, display_order, is_hidden
 ) VALUES (
-    $1, $2, $3, $4
-);
+    $1, $2, $3, $4, $5
+)
+ON CONFLICT (category_id, field_id) DO NOTHING;
 
 -- name: GetCategoryFields :many
-SELECT f.*, cf.is_required, cf.display_order
+SELECT f.*, cf.is_required, cf.display_order, cf.is_hidden
 FROM fields f
 JOIN category_fields cf ON f.id = cf.field_id
 WHERE cf.category_id = $1
-ORDER BY cf.display_order; 
\ No newline at end of file
+ORDER BY cf.display_order;
+
+-- name: GetEffectiveCategoryFields :many
+-- Resolves the field assignments of a category and all of its ancestors.
+-- For every field the assignment closest to the category wins; the
+-- assignment furthest up the chain is reported as the field's origin.
+WITH RECURSIVE lineage AS (
+    SELECT c.id, c.parent_id, 0 AS depth, ARRAY[c.id]::uuid[] AS path
+    FROM categories c
+    WHERE c.id = $1
+    UNION ALL
+    SELECT c.id, c.parent_id, l.depth + 1, l.path || c.id
+    FROM categories c
+    JOIN lineage l ON c.id = l.parent_id
+    WHERE NOT c.id = ANY(l.path)
+),
+assignments AS (
+    SELECT cf.field_id, cf.category_id, cf.is_required, cf.display_order, cf.is_hidden, l.depth,
+           FIRST_VALUE(cf.category_id) OVER (
+               PARTITION BY cf.field_id ORDER BY l.depth DESC
+           ) AS origin_category_id
+    FROM category_fields cf
+    JOIN lineage l ON cf.category_id = l.id
+),
+resolved AS (
+    SELECT DISTINCT ON (field_id) *
+    FROM assignments
+    ORDER BY field_id, depth
+)
+SELECT f.id, f.name, f.description, f.field_type_id, f.created_at, f.updated_at,
+       r.is_required, r.display_order, r.is_hidden,
+       r.category_id AS source_category_id,
+       r.origin_category_id::uuid AS origin_category_id
+FROM resolved r
+JOIN fields f ON f.id = r.field_id
+ORDER BY r.display_order, f.name;
+
+-- name: ListAssignedFieldIDs :many
+SELECT field_id
+FROM category_fields
+WHERE category_id = $1
+ORDER BY display_order;
+
+-- name: ListAssigningCategoryIDs :many
+SELECT category_id
+FROM category_fields
+WHERE field_id = $1
+ORDER BY category_id;
+
+-- name: DeleteCategoryAssignments :exec
+DELETE FROM category_fields
+WHERE category_id = $1;
+
+-- name: DeleteFieldAssignments :exec
+DELETE FROM category_fields
+WHERE field_id = $1;
+
+-- name: DeleteFieldTypeAssignments :exec
+DELETE FROM category_fields
+WHERE field_id IN (SELECT id FROM fields WHERE field_type_id = $1);
+
+-- name: UpdateCategoryField :execrows
+UPDATE category_fields
+SET is_required = $3,
+    display_order = $4,
+    is_hidden = $5
+WHERE category_id = $1 AND field_id = $2;
+
+-- name: UnassignFieldFromCategory :execrows
+DELETE FROM category_fields
+WHERE category_id = $1 AND field_id = $2;
+
+-- name: ReorderCategoryFields :exec
+-- Sets the display order of the given fields of a category to their position
+-- in field_ids.
+UPDATE category_fields cf
+SET display_order = o.display_order
+FROM (
+    SELECT unnest(@field_ids::uuid[]) AS field_id,
+           unnest(@display_orders::int[]) AS display_order
+) o
+WHERE cf.category_id = @category_id AND cf.field_id = o.field_id;
@@ -10,18 +10,65 @@ RETURNING *;
 SELECT * FROM fields
 WHERE id = $1;
 
+-- name: LockField :one
+SELECT id FROM fields
+WHERE id = $1
+FOR UPDATE;
+
 -- name: ListFields :many
-SELECT * FROM fields;
+SELECT * FROM fields
+WHERE (sqlc.narg('field_type_id')::uuid IS NULL OR field_type_id = sqlc.narg('field_type_id')::uuid)
+  AND (sqlc.narg('name')::text IS NULL OR name ILIKE '%' || sqlc.narg('name')::text || '%')
+  AND (
+    sqlc.narg('after_id')::uuid IS NULL
+    OR (@sort_by::text = 'name' AND NOT @descending::bool
+        AND (name, id) > (sqlc.narg('after_name')::text, sqlc.narg('after_id')::uuid))
+    OR (@sort_by::text = 'name' AND @descending::bool
+        AND (name, id) < (sqlc.narg('after_name')::text, sqlc.narg('after_id')::uuid))
+    OR (@sort_by::text = 'createdAt' AND NOT @descending::bool
+        AND (created_at, id) > (sqlc.narg('after_created_at')::timestamp, sqlc.narg('after_id')::uuid))
+    OR (@sort_by::text = 'createdAt' AND @descending::bool
+        AND (created_at, id) < (sqlc.narg('after_created_at')::timestamp, sqlc.narg('after_id')::uuid))
+  )
+ORDER BY
+    CASE WHEN @sort_by::text = 'name' AND NOT @descending::bool THEN name END ASC,
+    CASE WHEN @sort_by::text = 'name' AND @descending::bool THEN name END DESC,
+    CASE WHEN @sort_by::text = 'createdAt' AND NOT @descending::bool THEN created_at END ASC,
+    CASE WHEN @sort_by::text = 'createdAt' AND @descending::bool THEN created_at END DESC,
+    CASE WHEN NOT @descending::bool THEN id END ASC,
+    CASE WHEN @descending::bool THEN id END DESC
+LIMIT @page_limit;
 
 -- name: UpdateField :one
 UPDATE fields
-SET name = $2,
-    description = $3,
-    field_type_id = $4,
+SET name = @name,
+    description = @description,
+    field_type_id = @field_type_id,
     updated_at = NOW()
-WHERE id = $1
+WHERE id = @id
+    AND (sqlc.narg('if_updated_at')::timestamp IS NULL OR updated_at = sqlc.narg('if_updated_at'))
+RETURNING *;
+
+-- name: DeleteField :execrows
+DELETE FROM fields
+WHERE id = @id
+    AND (sqlc.narg('if_updated_at')::timestamp IS NULL OR updated_at = sqlc.narg('if_updated_at'));
+-- name: PatchField :one
+UPDATE fields
+SET name = CASE WHEN @set_name::bool THEN @name::text ELSE name END,
+    description = CASE WHEN @set_description::bool THEN sqlc.narg('description')::text ELSE description END,
+    field_type_id = CASE WHEN @set_field_type_id::bool THEN @field_type_id::uuid ELSE field_type_id END,
+    updated_at = NOW()
+WHERE id = @id
+    AND (sqlc.narg('if_updated_at')::timestamp IS NULL OR updated_at = sqlc.narg('if_updated_at'))
 RETURNING *;
 
--- name: DeleteField :exec
+-- name: ListFieldIDsByFieldType :many
+SELECT id
+FROM fields
+WHERE field_type_id = $1
+ORDER BY name;
+
+-- name: DeleteFieldsByFieldType :exec
 DELETE FROM fields
-WHERE id = $1; 
\ No newline at end of file
+WHERE field_type_id = $1;
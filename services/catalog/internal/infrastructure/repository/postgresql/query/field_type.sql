@@ -13,18 +13,55 @@ RETURNING *;
 SELECT * FROM field_types
 WHERE id = $1;
 
+-- name: LockFieldType :one
+SELECT id FROM field_types
+WHERE id = $1
+FOR UPDATE;
+
 -- name: ListFieldTypes :many
-SELECT * FROM field_types;
+SELECT * FROM field_types
+WHERE (sqlc.narg('type_discriminator_id')::uuid IS NULL OR type_discriminator_id = sqlc.narg('type_discriminator_id')::uuid)
+  AND (sqlc.narg('name')::text IS NULL OR name ILIKE '%' || sqlc.narg('name')::text || '%')
+  AND (
+    sqlc.narg('after_id')::uuid IS NULL
+    OR (@sort_by::text = 'name' AND NOT @descending::bool
+        AND (name, id) > (sqlc.narg('after_name')::text, sqlc.narg('after_id')::uuid))
+    OR (@sort_by::text = 'name' AND @descending::bool
+        AND (name, id) < (sqlc.narg('after_name')::text, sqlc.narg('after_id')::uuid))
+    OR (@sort_by::text = 'createdAt' AND NOT @descending::bool
+        AND (created_at, id) > (sqlc.narg('after_created_at')::timestamp, sqlc.narg('after_id')::uuid))
+    OR (@sort_by::text = 'createdAt' AND @descending::bool
+        AND (created_at, id) < (sqlc.narg('after_created_at')::timestamp, sqlc.narg('after_id')::uuid))
+  )
+ORDER BY
+    CASE WHEN @sort_by::text = 'name' AND NOT @descending::bool THEN name END ASC,
+    CASE WHEN @sort_by::text = 'name' AND @descending::bool THEN name END DESC,
+    CASE WHEN @sort_by::text = 'createdAt' AND NOT @descending::bool THEN created_at END ASC,
+    CASE WHEN @sort_by::text = 'createdAt' AND @descending::bool THEN created_at END DESC,
+    CASE WHEN NOT @descending::bool THEN id END ASC,
+    CASE WHEN @descending::bool THEN id END DESC
+LIMIT @page_limit;
 
 -- name: UpdateFieldType :one
 UPDATE field_types
-SET name = $2,
-    type_discriminator_id = $3,
-    properties = $4,
+SET name = @name,
+    type_discriminator_id = @type_discriminator_id,
+    properties = @properties,
     updated_at = NOW()
-WHERE id = $1
+WHERE id = @id
+    AND (sqlc.narg('if_updated_at')::timestamp IS NULL OR updated_at = sqlc.narg('if_updated_at'))
 RETURNING *;
 
--- name: DeleteFieldType :exec
+-- name: DeleteFieldType :execrows
 DELETE FROM field_types
-WHERE id = $1; 
\ No newline at end of file
+WHERE id = @id
+    AND (sqlc.narg('if_updated_at')::timestamp IS NULL OR updated_at = sqlc.narg('if_updated_at'));
+-- name: PatchFieldType :one
+UPDATE field_types
+SET name = CASE WHEN @set_name::bool THEN @name::text ELSE name END,
+    type_discriminator_id = CASE WHEN @set_type_discriminator_id::bool THEN @type_discriminator_id::uuid ELSE type_discriminator_id END,
+    properties = CASE WHEN @set_properties::bool THEN @properties::jsonb ELSE properties END,
+    updated_at = NOW()
+WHERE id = @id
+    AND (sqlc.narg('if_updated_at')::timestamp IS NULL OR updated_at = sqlc.narg('if_updated_at'))
+RETURNING *;
@@ -14,7 +14,27 @@ SELECT * FROM field_type_discriminators
 WHERE id = $1;
 
 -- name: ListFieldTypeDiscriminators :many
-SELECT * FROM field_type_discriminators;
+SELECT * FROM field_type_discriminators
+WHERE (sqlc.narg('name')::text IS NULL OR name ILIKE '%' || sqlc.narg('name')::text || '%')
+  AND (
+    sqlc.narg('after_id')::uuid IS NULL
+    OR (@sort_by::text = 'name' AND NOT @descending::bool
+        AND (name, id) > (sqlc.narg('after_name')::text, sqlc.narg('after_id')::uuid))
+    OR (@sort_by::text = 'name' AND @descending::bool
+        AND (name, id) < (sqlc.narg('after_name')::text, sqlc.narg('after_id')::uuid))
+    OR (@sort_by::text = 'createdAt' AND NOT @descending::bool
+        AND (created_at, id) > (sqlc.narg('after_created_at')::timestamp, sqlc.narg('after_id')::uuid))
+    OR (@sort_by::text = 'createdAt' AND @descending::bool
+        AND (created_at, id) < (sqlc.narg('after_created_at')::timestamp, sqlc.narg('after_id')::uuid))
+  )
+ORDER BY
+    CASE WHEN @sort_by::text = 'name' AND NOT @descending::bool THEN name END ASC,
+    CASE WHEN @sort_by::text = 'name' AND @descending::bool THEN name END DESC,
+    CASE WHEN @sort_by::text = 'createdAt' AND NOT @descending::bool THEN created_at END ASC,
+    CASE WHEN @sort_by::text = 'createdAt' AND @descending::bool THEN created_at END DESC,
+    CASE WHEN NOT @descending::bool THEN id END ASC,
+    CASE WHEN @descending::bool THEN id END DESC
+LIMIT @page_limit;
 
 -- name: UpdateFieldTypeDiscriminator :one
 UPDATE field_type_discriminators
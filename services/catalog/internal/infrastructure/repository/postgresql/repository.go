@@ -5,6 +5,8 @@ import (
 	"database/sql"
 	"encoding/json"
 	"fmt"
+	"localloop/libs/pkg/pagination"
+	"localloop/libs/pkg/precondition"
 	catalog "localloop/services/catalog/internal/domain"
 	"localloop/services/catalog/internal/infrastructure/repository/postgresql/sqlc"
 
@@ -12,15 +14,50 @@ import (
 )
 
 type CatalogRepository struct {
-	q *sqlc.Queries
+	db *sql.DB // nil when the repository is bound to a transaction
+	q  *sqlc.Queries
 }
 
 func NewCatalogRepository(db *sql.DB) *CatalogRepository {
 	return &CatalogRepository{
-		q: sqlc.New(db),
+		db: db,
+		q:  sqlc.New(db),
 	}
 }
 
+// WithTx runs fn with a repository bound to a new transaction, which is
+// committed when fn succeeds and rolled back otherwise. Calls made on a
+// repository that is already bound to a transaction join it.
+func (r *CatalogRepository) WithTx(ctx context.Context, fn func(repo catalog.Repository) error) error {
+	if r.db == nil {
+		return fn(r)
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			_ = tx.Rollback()
+			panic(p)
+		}
+	}()
+
+	if err := fn(&CatalogRepository{q: r.q.WithTx(tx)}); err != nil {
+		if rbErr := tx.Rollback(); rbErr != nil {
+			return fmt.Errorf("failed to roll back transaction: %v (original error: %w)", rbErr, err)
+		}
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
 func unmarshalJSON[T any](data json.RawMessage) (T, error) {
 	var result T
 	if err := json.Unmarshal(data, &result); err != nil {
@@ -76,6 +113,7 @@ func (r *CatalogRepository) GetCategory(ctx context.Context, id uuid.UUID) (*cat
 func (r *CatalogRepository) UpdateCategory(ctx context.Context, category *catalog.Category) error {
 	params := sqlc.UpdateCategoryParams{
 		ID:          category.ID,
+		IfUpdatedAt: ifUpdatedAt(ctx),
 		Name:        category.Name,
 		Description: sql.NullString{String: category.Description, Valid: category.Description != ""},
 		ParentID:    uuid.NullUUID{UUID: uuid.Nil, Valid: false},
@@ -94,14 +132,73 @@ func (r *CatalogRepository) UpdateCategory(ctx context.Context, category *catalo
 	return nil
 }
 
+func (r *CatalogRepository) PatchCategory(ctx context.Context, params catalog.PatchCategoryParams) (*catalog.Category, error) {
+	result, err := r.q.PatchCategory(ctx, sqlc.PatchCategoryParams{
+		ID:             params.ID,
+		SetName:        params.Name.Set,
+		Name:           params.Name.Value,
+		SetDescription: params.Description.Set,
+		Description:    sql.NullString{String: params.Description.Value, Valid: params.Description.Value != ""},
+		SetParentID:    params.ParentID.Set,
+		ParentID:       uuid.NullUUID{UUID: params.ParentID.Value, Valid: !params.ParentID.Null},
+		IfUpdatedAt:    ifUpdatedAt(ctx),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var parentID *uuid.UUID
+	if result.ParentID.Valid {
+		id := result.ParentID.UUID
+		parentID = &id
+	}
+
+	return &catalog.Category{
+		ID:          result.ID,
+		Name:        result.Name,
+		Description: result.Description.String,
+		ParentID:    parentID,
+		CreatedAt:   result.CreatedAt,
+		UpdatedAt:   result.UpdatedAt,
+	}, nil
+}
+
 func (r *CatalogRepository) DeleteCategory(ctx context.Context, id uuid.UUID) error {
-	return r.q.DeleteCategory(ctx, id)
+	deleted, err := r.q.DeleteCategory(ctx, sqlc.DeleteCategoryParams{
+		ID:          id,
+		IfUpdatedAt: ifUpdatedAt(ctx),
+	})
+	if err != nil {
+		return err
+	}
+	if deleted == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
 }
 
-func (r *CatalogRepository) ListCategories(ctx context.Context) ([]*catalog.Category, error) {
-	results, err := r.q.ListCategories(ctx)
+func (r *CatalogRepository) ListCategories(ctx context.Context, query pagination.Query) (pagination.Page[*catalog.Category], error) {
+	after, err := newKeyset(query)
 	if err != nil {
-		return nil, err
+		return pagination.Page[*catalog.Category]{}, err
+	}
+	parentID, err := query.UUIDFilter("parentId")
+	if err != nil {
+		return pagination.Page[*catalog.Category]{}, err
+	}
+
+	results, err := r.q.ListCategories(ctx, sqlc.ListCategoriesParams{
+		ParentID:       parentID,
+		Name:           stringFilter(query, "name"),
+		AfterID:        after.id,
+		SortBy:         query.Sort,
+		Descending:     query.Descending,
+		AfterName:      after.name,
+		AfterCreatedAt: after.createdAt,
+		PageLimit:      int32(query.FetchLimit()),
+	})
+	if err != nil {
+		return pagination.Page[*catalog.Category]{}, err
 	}
 
 	categories := make([]*catalog.Category, len(results))
@@ -122,6 +219,134 @@ func (r *CatalogRepository) ListCategories(ctx context.Context) ([]*catalog.Cate
 		}
 	}
 
+	return pagination.NewPage(categories, query, func(c *catalog.Category) (string, uuid.UUID) {
+		return sortKey(query, c.Name, c.CreatedAt), c.ID
+	}), nil
+}
+
+func (r *CatalogRepository) MoveCategory(ctx context.Context, id uuid.UUID, parentID *uuid.UUID) (*catalog.Category, error) {
+	params := sqlc.MoveCategoryParams{
+		ID:          id,
+		IfUpdatedAt: ifUpdatedAt(ctx),
+		ParentID:    uuid.NullUUID{UUID: uuid.Nil, Valid: false},
+	}
+
+	if parentID != nil {
+		params.ParentID = uuid.NullUUID{UUID: *parentID, Valid: true}
+	}
+
+	result, err := r.q.MoveCategory(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	var newParentID *uuid.UUID
+	if result.ParentID.Valid {
+		id := result.ParentID.UUID
+		newParentID = &id
+	}
+
+	return &catalog.Category{
+		ID:          result.ID,
+		Name:        result.Name,
+		Description: result.Description.String,
+		ParentID:    newParentID,
+		CreatedAt:   result.CreatedAt,
+		UpdatedAt:   result.UpdatedAt,
+	}, nil
+}
+
+// toCategoryNode converts a row of any of the recursive category queries,
+// which all share the GetCategoryTreeRow layout.
+func toCategoryNode(row sqlc.GetCategoryTreeRow) *catalog.CategoryNode {
+	var parentID *uuid.UUID
+	if row.ParentID.Valid {
+		id := row.ParentID.UUID
+		parentID = &id
+	}
+
+	return &catalog.CategoryNode{
+		Category: catalog.Category{
+			ID:          row.ID,
+			Name:        row.Name,
+			Description: row.Description.String,
+			ParentID:    parentID,
+			CreatedAt:   row.CreatedAt,
+			UpdatedAt:   row.UpdatedAt,
+		},
+		Depth: row.Depth,
+	}
+}
+
+func (r *CatalogRepository) GetCategoryTree(ctx context.Context) ([]*catalog.CategoryNode, error) {
+	results, err := r.q.GetCategoryTree(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	nodes := make([]*catalog.CategoryNode, len(results))
+	for i, result := range results {
+		nodes[i] = toCategoryNode(result)
+	}
+
+	return nodes, nil
+}
+
+func (r *CatalogRepository) LockCategory(ctx context.Context, id uuid.UUID) (*uuid.UUID, error) {
+	parentID, err := r.q.LockCategory(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !parentID.Valid {
+		return nil, nil
+	}
+	return &parentID.UUID, nil
+}
+
+func (r *CatalogRepository) ListChildCategoryIDs(ctx context.Context, id uuid.UUID) ([]uuid.UUID, error) {
+	return r.q.ListChildCategoryIDs(ctx, id)
+}
+
+func (r *CatalogRepository) ReparentChildCategories(ctx context.Context, id uuid.UUID, parentID *uuid.UUID) error {
+	params := sqlc.ReparentChildCategoriesParams{ID: id}
+	if parentID != nil {
+		params.NewParentID = uuid.NullUUID{UUID: *parentID, Valid: true}
+	}
+	return r.q.ReparentChildCategories(ctx, params)
+}
+
+func (r *CatalogRepository) DeleteCategoryDescendants(ctx context.Context, id uuid.UUID) error {
+	if err := r.q.DeleteCategoryDescendantAssignments(ctx, id); err != nil {
+		return err
+	}
+	return r.q.DeleteCategoryDescendants(ctx, id)
+}
+
+func (r *CatalogRepository) GetCategorySubtree(ctx context.Context, id uuid.UUID) ([]*catalog.CategoryNode, error) {
+	results, err := r.q.GetCategorySubtree(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	nodes := make([]*catalog.CategoryNode, len(results))
+	for i, result := range results {
+		nodes[i] = toCategoryNode(sqlc.GetCategoryTreeRow(result))
+	}
+
+	return nodes, nil
+}
+
+func (r *CatalogRepository) GetCategoryAncestors(ctx context.Context, id uuid.UUID) ([]*catalog.Category, error) {
+	results, err := r.q.GetCategoryAncestors(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	categories := make([]*catalog.Category, len(results))
+	for i, result := range results {
+		categories[i] = &toCategoryNode(sqlc.GetCategoryTreeRow(result)).Category
+	}
+
 	return categories, nil
 }
 
@@ -159,9 +384,15 @@ func (r *CatalogRepository) GetField(ctx context.Context, id uuid.UUID) (*catalo
 	}, nil
 }
 
+func (r *CatalogRepository) LockField(ctx context.Context, id uuid.UUID) error {
+	_, err := r.q.LockField(ctx, id)
+	return err
+}
+
 func (r *CatalogRepository) UpdateField(ctx context.Context, field *catalog.Field) error {
 	params := sqlc.UpdateFieldParams{
 		ID:          field.ID,
+		IfUpdatedAt: ifUpdatedAt(ctx),
 		Name:        field.Name,
 		Description: sql.NullString{String: field.Description, Valid: field.Description != ""},
 		FieldTypeID: field.FieldTypeID,
@@ -176,14 +407,67 @@ func (r *CatalogRepository) UpdateField(ctx context.Context, field *catalog.Fiel
 	return nil
 }
 
+func (r *CatalogRepository) PatchField(ctx context.Context, params catalog.PatchFieldParams) (*catalog.Field, error) {
+	result, err := r.q.PatchField(ctx, sqlc.PatchFieldParams{
+		ID:             params.ID,
+		SetName:        params.Name.Set,
+		Name:           params.Name.Value,
+		SetDescription: params.Description.Set,
+		Description:    sql.NullString{String: params.Description.Value, Valid: params.Description.Value != ""},
+		SetFieldTypeID: params.FieldTypeID.Set,
+		FieldTypeID:    params.FieldTypeID.Value,
+		IfUpdatedAt:    ifUpdatedAt(ctx),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &catalog.Field{
+		ID:          result.ID,
+		Name:        result.Name,
+		Description: result.Description.String,
+		FieldTypeID: result.FieldTypeID,
+		CreatedAt:   result.CreatedAt,
+		UpdatedAt:   result.UpdatedAt,
+	}, nil
+}
+
 func (r *CatalogRepository) DeleteField(ctx context.Context, id uuid.UUID) error {
-	return r.q.DeleteField(ctx, id)
+	deleted, err := r.q.DeleteField(ctx, sqlc.DeleteFieldParams{
+		ID:          id,
+		IfUpdatedAt: ifUpdatedAt(ctx),
+	})
+	if err != nil {
+		return err
+	}
+	if deleted == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
 }
 
-func (r *CatalogRepository) ListFields(ctx context.Context) ([]*catalog.Field, error) {
-	results, err := r.q.ListFields(ctx)
+func (r *CatalogRepository) ListFields(ctx context.Context, query pagination.Query) (pagination.Page[*catalog.Field], error) {
+	after, err := newKeyset(query)
 	if err != nil {
-		return nil, err
+		return pagination.Page[*catalog.Field]{}, err
+	}
+	fieldTypeID, err := query.UUIDFilter("fieldTypeId")
+	if err != nil {
+		return pagination.Page[*catalog.Field]{}, err
+	}
+
+	results, err := r.q.ListFields(ctx, sqlc.ListFieldsParams{
+		FieldTypeID:    fieldTypeID,
+		Name:           stringFilter(query, "name"),
+		AfterID:        after.id,
+		SortBy:         query.Sort,
+		Descending:     query.Descending,
+		AfterName:      after.name,
+		AfterCreatedAt: after.createdAt,
+		PageLimit:      int32(query.FetchLimit()),
+	})
+	if err != nil {
+		return pagination.Page[*catalog.Field]{}, err
 	}
 
 	fields := make([]*catalog.Field, len(results))
@@ -198,14 +482,37 @@ func (r *CatalogRepository) ListFields(ctx context.Context) ([]*catalog.Field, e
 		}
 	}
 
-	return fields, nil
+	return pagination.NewPage(fields, query, func(f *catalog.Field) (string, uuid.UUID) {
+		return sortKey(query, f.Name, f.CreatedAt), f.ID
+	}), nil
+}
+
+func (r *CatalogRepository) ListFieldIDsByFieldType(ctx context.Context, fieldTypeID uuid.UUID) ([]uuid.UUID, error) {
+	return r.q.ListFieldIDsByFieldType(ctx, fieldTypeID)
+}
+
+func (r *CatalogRepository) DeleteFieldsByFieldType(ctx context.Context, fieldTypeID uuid.UUID) error {
+	if err := r.q.DeleteFieldTypeAssignments(ctx, fieldTypeID); err != nil {
+		return err
+	}
+	return r.q.DeleteFieldsByFieldType(ctx, fieldTypeID)
 }
 
 func (r *CatalogRepository) AssignFieldToCategory(ctx context.Context, params catalog.AssignFieldParams) error {
-	return r.q.AssignFieldToCategory(ctx, sqlc.AssignFieldToCategoryParams{
-		CategoryID: params.CategoryID,
-		FieldID:    params.FieldID,
+	inserted, err := r.q.AssignFieldToCategory(ctx, sqlc.AssignFieldToCategoryParams{
+		CategoryID:   params.CategoryID,
+		FieldID:      params.FieldID,
+		IsRequired:   sql.NullBool{Bool: params.IsRequired, Valid: true},
+		DisplayOrder: params.DisplayOrder,
+		IsHidden:     params.IsHidden,
 	})
+	if err != nil {
+		return err
+	}
+	if inserted == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
 }
 
 func (r *CatalogRepository) CreateFieldType(ctx context.Context, fieldType *catalog.FieldType) error {
@@ -253,6 +560,11 @@ func (r *CatalogRepository) GetFieldType(ctx context.Context, id uuid.UUID) (*ca
 	}, nil
 }
 
+func (r *CatalogRepository) LockFieldType(ctx context.Context, id uuid.UUID) error {
+	_, err := r.q.LockFieldType(ctx, id)
+	return err
+}
+
 func (r *CatalogRepository) UpdateFieldType(ctx context.Context, fieldType *catalog.FieldType) error {
 	// Convert map to json.RawMessage
 	jsonProperties, err := json.Marshal(fieldType.Properties)
@@ -262,6 +574,7 @@ func (r *CatalogRepository) UpdateFieldType(ctx context.Context, fieldType *cata
 
 	params := sqlc.UpdateFieldTypeParams{
 		ID:                  fieldType.ID,
+		IfUpdatedAt:         ifUpdatedAt(ctx),
 		Name:                fieldType.Name,
 		TypeDiscriminatorID: fieldType.TypeDiscriminatorID,
 		Properties:          jsonProperties,
@@ -275,21 +588,87 @@ func (r *CatalogRepository) UpdateFieldType(ctx context.Context, fieldType *cata
 	return nil
 }
 
+func (r *CatalogRepository) PatchFieldType(ctx context.Context, params catalog.PatchFieldTypeParams) (*catalog.FieldType, error) {
+	var jsonProperties json.RawMessage
+	if params.Properties.Set {
+		var err error
+		if jsonProperties, err = json.Marshal(params.Properties.Value); err != nil {
+			return nil, fmt.Errorf("failed to marshal properties: %w", err)
+		}
+	}
+
+	result, err := r.q.PatchFieldType(ctx, sqlc.PatchFieldTypeParams{
+		ID:                     params.ID,
+		SetName:                params.Name.Set,
+		Name:                   params.Name.Value,
+		SetTypeDiscriminatorID: params.TypeDiscriminatorID.Set,
+		TypeDiscriminatorID:    params.TypeDiscriminatorID.Value,
+		SetProperties:          params.Properties.Set,
+		Properties:             jsonProperties,
+		IfUpdatedAt:            ifUpdatedAt(ctx),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	properties, err := unmarshalJSON[map[string]interface{}](result.Properties)
+	if err != nil {
+		return nil, err
+	}
+
+	return &catalog.FieldType{
+		ID:                  result.ID,
+		Name:                result.Name,
+		TypeDiscriminatorID: result.TypeDiscriminatorID,
+		Properties:          properties,
+		CreatedAt:           result.CreatedAt,
+		UpdatedAt:           result.UpdatedAt,
+	}, nil
+}
+
 func (r *CatalogRepository) DeleteFieldType(ctx context.Context, id uuid.UUID) error {
-	return r.q.DeleteFieldType(ctx, id)
+	deleted, err := r.q.DeleteFieldType(ctx, sqlc.DeleteFieldTypeParams{
+		ID:          id,
+		IfUpdatedAt: ifUpdatedAt(ctx),
+	})
+	if err != nil {
+		return err
+	}
+	if deleted == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
 }
 
-func (r *CatalogRepository) ListFieldTypes(ctx context.Context) ([]*catalog.FieldType, error) {
-	results, err := r.q.ListFieldTypes(ctx)
+func (r *CatalogRepository) ListFieldTypes(ctx context.Context, query pagination.Query) (pagination.Page[*catalog.FieldType], error) {
+	after, err := newKeyset(query)
 	if err != nil {
-		return nil, err
+		return pagination.Page[*catalog.FieldType]{}, err
+	}
+	discriminatorID, err := query.UUIDFilter("typeDiscriminatorId")
+	if err != nil {
+		return pagination.Page[*catalog.FieldType]{}, err
+	}
+
+	results, err := r.q.ListFieldTypes(ctx, sqlc.ListFieldTypesParams{
+		TypeDiscriminatorID: discriminatorID,
+		Name:                stringFilter(query, "name"),
+		AfterID:             after.id,
+		SortBy:              query.Sort,
+		Descending:          query.Descending,
+		AfterName:           after.name,
+		AfterCreatedAt:      after.createdAt,
+		PageLimit:           int32(query.FetchLimit()),
+	})
+	if err != nil {
+		return pagination.Page[*catalog.FieldType]{}, err
 	}
 
 	fieldTypes := make([]*catalog.FieldType, len(results))
 	for i, result := range results {
 		properties, err := unmarshalJSON[map[string]interface{}](result.Properties)
 		if err != nil {
-			return nil, err
+			return pagination.Page[*catalog.FieldType]{}, err
 		}
 
 		fieldTypes[i] = &catalog.FieldType{
@@ -302,7 +681,9 @@ func (r *CatalogRepository) ListFieldTypes(ctx context.Context) ([]*catalog.Fiel
 		}
 	}
 
-	return fieldTypes, nil
+	return pagination.NewPage(fieldTypes, query, func(ft *catalog.FieldType) (string, uuid.UUID) {
+		return sortKey(query, ft.Name, ft.CreatedAt), ft.ID
+	}), nil
 }
 
 func (r *CatalogRepository) CreateFieldTypeDiscriminator(ctx context.Context, discriminator *catalog.FieldTypeDiscriminator) error {
@@ -347,17 +728,30 @@ func (r *CatalogRepository) GetFieldTypeDiscriminator(ctx context.Context, id uu
 	}, nil
 }
 
-func (r *CatalogRepository) ListFieldTypeDiscriminators(ctx context.Context) ([]*catalog.FieldTypeDiscriminator, error) {
-	results, err := r.q.ListFieldTypeDiscriminators(ctx)
+func (r *CatalogRepository) ListFieldTypeDiscriminators(ctx context.Context, query pagination.Query) (pagination.Page[*catalog.FieldTypeDiscriminator], error) {
+	after, err := newKeyset(query)
 	if err != nil {
-		return nil, err
+		return pagination.Page[*catalog.FieldTypeDiscriminator]{}, err
+	}
+
+	results, err := r.q.ListFieldTypeDiscriminators(ctx, sqlc.ListFieldTypeDiscriminatorsParams{
+		Name:           stringFilter(query, "name"),
+		AfterID:        after.id,
+		SortBy:         query.Sort,
+		Descending:     query.Descending,
+		AfterName:      after.name,
+		AfterCreatedAt: after.createdAt,
+		PageLimit:      int32(query.FetchLimit()),
+	})
+	if err != nil {
+		return pagination.Page[*catalog.FieldTypeDiscriminator]{}, err
 	}
 
 	discriminators := make([]*catalog.FieldTypeDiscriminator, len(results))
 	for i, result := range results {
 		validationSchema, err := unmarshalJSON[map[string]interface{}](result.ValidationSchema)
 		if err != nil {
-			return nil, err
+			return pagination.Page[*catalog.FieldTypeDiscriminator]{}, err
 		}
 
 		discriminators[i] = &catalog.FieldTypeDiscriminator{
@@ -369,7 +763,69 @@ func (r *CatalogRepository) ListFieldTypeDiscriminators(ctx context.Context) ([]
 		}
 	}
 
-	return discriminators, nil
+	return pagination.NewPage(discriminators, query, func(d *catalog.FieldTypeDiscriminator) (string, uuid.UUID) {
+		return sortKey(query, d.Name, d.CreatedAt), d.ID
+	}), nil
+}
+
+func (r *CatalogRepository) ListAssignedFieldIDs(ctx context.Context, categoryID uuid.UUID) ([]uuid.UUID, error) {
+	return r.q.ListAssignedFieldIDs(ctx, categoryID)
+}
+
+func (r *CatalogRepository) ListAssigningCategoryIDs(ctx context.Context, fieldID uuid.UUID) ([]uuid.UUID, error) {
+	return r.q.ListAssigningCategoryIDs(ctx, fieldID)
+}
+
+func (r *CatalogRepository) UpdateCategoryField(ctx context.Context, params catalog.UpdateCategoryFieldParams) error {
+	updated, err := r.q.UpdateCategoryField(ctx, sqlc.UpdateCategoryFieldParams{
+		CategoryID:   params.CategoryID,
+		FieldID:      params.FieldID,
+		IsRequired:   sql.NullBool{Bool: params.IsRequired, Valid: true},
+		DisplayOrder: params.DisplayOrder,
+		IsHidden:     params.IsHidden,
+	})
+	if err != nil {
+		return err
+	}
+	if updated == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+func (r *CatalogRepository) UnassignFieldFromCategory(ctx context.Context, categoryID, fieldID uuid.UUID) error {
+	deleted, err := r.q.UnassignFieldFromCategory(ctx, sqlc.UnassignFieldFromCategoryParams{
+		CategoryID: categoryID,
+		FieldID:    fieldID,
+	})
+	if err != nil {
+		return err
+	}
+	if deleted == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+func (r *CatalogRepository) ReorderCategoryFields(ctx context.Context, categoryID uuid.UUID, fieldIDs []uuid.UUID) error {
+	displayOrders := make([]int32, len(fieldIDs))
+	for i := range fieldIDs {
+		displayOrders[i] = int32(i)
+	}
+
+	return r.q.ReorderCategoryFields(ctx, sqlc.ReorderCategoryFieldsParams{
+		CategoryID:    categoryID,
+		FieldIds:      fieldIDs,
+		DisplayOrders: displayOrders,
+	})
+}
+
+func (r *CatalogRepository) DeleteCategoryAssignments(ctx context.Context, categoryID uuid.UUID) error {
+	return r.q.DeleteCategoryAssignments(ctx, categoryID)
+}
+
+func (r *CatalogRepository) DeleteFieldAssignments(ctx context.Context, fieldID uuid.UUID) error {
+	return r.q.DeleteFieldAssignments(ctx, fieldID)
 }
 
 func (r *CatalogRepository) GetCategoryFields(ctx context.Context, categoryID uuid.UUID) ([]*catalog.CategoryFieldInfo, error) {
@@ -393,6 +849,41 @@ func (r *CatalogRepository) GetCategoryFields(ctx context.Context, categoryID uu
 			Field:        field,
 			IsRequired:   result.IsRequired.Bool,
 			DisplayOrder: result.DisplayOrder,
+			IsHidden:     result.IsHidden,
+		}
+	}
+
+	return fields, nil
+}
+
+func (r *CatalogRepository) GetEffectiveCategoryFields(ctx context.Context, categoryID uuid.UUID) ([]*catalog.EffectiveCategoryField, error) {
+	results, err := r.q.GetEffectiveCategoryFields(ctx, categoryID)
+	if err != nil {
+		return nil, err
+	}
+
+	fields := make([]*catalog.EffectiveCategoryField, len(results))
+	for i, result := range results {
+		field := &catalog.Field{
+			ID:          result.ID,
+			Name:        result.Name,
+			Description: result.Description.String,
+			FieldTypeID: result.FieldTypeID,
+			CreatedAt:   result.CreatedAt,
+			UpdatedAt:   result.UpdatedAt,
+		}
+
+		fields[i] = &catalog.EffectiveCategoryField{
+			CategoryFieldInfo: catalog.CategoryFieldInfo{
+				Field:        field,
+				IsRequired:   result.IsRequired.Bool,
+				DisplayOrder: result.DisplayOrder,
+				IsHidden:     result.IsHidden,
+			},
+			SourceCategoryID: result.SourceCategoryID,
+			OriginCategoryID: result.OriginCategoryID,
+			Inherited:        result.SourceCategoryID != categoryID,
+			Overridden:       result.SourceCategoryID != result.OriginCategoryID,
 		}
 	}
 
@@ -425,22 +916,9 @@ func (r *CatalogRepository) DeleteFieldTypeDiscriminator(ctx context.Context, id
 	return r.q.DeleteFieldTypeDiscriminator(ctx, id)
 }
 
-// func (r *CatalogRepository) WithTx(ctx context.Context, fn func(repo catalog.Repository) error) error {
-// 	tx, err := r.q.db.(*sql.DB).BeginTx(ctx, nil)
-// 	if err != nil {
-// 		return err
-// 	}
-
-// 	txRepo := &CatalogRepository{
-// 		q: r.q.WithTx(tx),
-// 	}
-
-// 	if err := fn(txRepo); err != nil {
-// 		if rbErr := tx.Rollback(); rbErr != nil {
-// 			return fmt.Errorf("tx err: %v, rb err: %v", err, rbErr)
-// 		}
-// 		return err
-// 	}
-
-// 	return tx.Commit()
-// }
+// ifUpdatedAt returns the version a write under ctx is conditional on. Writes
+// against another version match no rows and fail with sql.ErrNoRows.
+func ifUpdatedAt(ctx context.Context) sql.NullTime {
+	version, ok := precondition.IfMatch(ctx)
+	return sql.NullTime{Time: version.UTC(), Valid: ok}
+}
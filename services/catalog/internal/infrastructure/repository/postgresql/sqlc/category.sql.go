@@ -8,6 +8,7 @@ package sqlc
 import (
 	"context"
 	"database/sql"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -47,13 +48,64 @@ func (q *Queries) CreateCategory(ctx context.Context, arg CreateCategoryParams)
 	return i, err
 }
 
-const deleteCategory = `-- name: DeleteCategory :exec
+const deleteCategory = `-- name: DeleteCategory :execrows
 DELETE FROM categories
 WHERE id = $1
+    AND ($2::timestamp IS NULL OR updated_at = $2)
 `
 
-func (q *Queries) DeleteCategory(ctx context.Context, id uuid.UUID) error {
-	_, err := q.db.ExecContext(ctx, deleteCategory, id)
+type DeleteCategoryParams struct {
+	ID          uuid.UUID    `json:"id"`
+	IfUpdatedAt sql.NullTime `json:"ifUpdatedAt"`
+}
+
+func (q *Queries) DeleteCategory(ctx context.Context, arg DeleteCategoryParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteCategory, arg.ID, arg.IfUpdatedAt)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const deleteCategoryDescendantAssignments = `-- name: DeleteCategoryDescendantAssignments :exec
+WITH RECURSIVE descendants AS (
+    SELECT c.id, ARRAY[c.id]::uuid[] AS path
+    FROM categories c
+    WHERE c.parent_id = $1::uuid
+    UNION ALL
+    SELECT c.id, d.path || c.id
+    FROM categories c
+    JOIN descendants d ON c.parent_id = d.id
+    WHERE NOT c.id = ANY(d.path)
+)
+DELETE FROM category_fields
+WHERE category_id IN (SELECT id FROM descendants)
+`
+
+func (q *Queries) DeleteCategoryDescendantAssignments(ctx context.Context, parentID uuid.UUID) error {
+	_, err := q.db.ExecContext(ctx, deleteCategoryDescendantAssignments, parentID)
+	return err
+}
+
+const deleteCategoryDescendants = `-- name: DeleteCategoryDescendants :exec
+WITH RECURSIVE descendants AS (
+    SELECT c.id, ARRAY[c.id]::uuid[] AS path
+    FROM categories c
+    WHERE c.parent_id = $1::uuid
+    UNION ALL
+    SELECT c.id, d.path || c.id
+    FROM categories c
+    JOIN descendants d ON c.parent_id = d.id
+    WHERE NOT c.id = ANY(d.path)
+)
+DELETE FROM categories
+WHERE id IN (SELECT id FROM descendants)
+`
+
+// Foreign keys are checked at the end of the statement, so the subtree can be
+// deleted in any order.
+func (q *Queries) DeleteCategoryDescendants(ctx context.Context, parentID uuid.UUID) error {
+	_, err := q.db.ExecContext(ctx, deleteCategoryDescendants, parentID)
 	return err
 }
 
@@ -76,12 +128,231 @@ func (q *Queries) GetCategory(ctx context.Context, id uuid.UUID) (Category, erro
 	return i, err
 }
 
+const getCategoryAncestors = `-- name: GetCategoryAncestors :many
+WITH RECURSIVE ancestors AS (
+    SELECT c.id, c.name, c.description, c.parent_id, c.created_at, c.updated_at,
+           0 AS depth, ARRAY[c.id]::uuid[] AS path
+    FROM categories c
+    WHERE c.id = $1
+    UNION ALL
+    SELECT c.id, c.name, c.description, c.parent_id, c.created_at, c.updated_at,
+           a.depth + 1, a.path || c.id
+    FROM categories c
+    JOIN ancestors a ON c.id = a.parent_id
+    WHERE NOT c.id = ANY(a.path)
+)
+SELECT id, name, description, parent_id, created_at, updated_at, depth
+FROM ancestors
+WHERE depth > 0
+ORDER BY depth DESC
+`
+
+type GetCategoryAncestorsRow struct {
+	ID          uuid.UUID      `json:"id"`
+	Name        string         `json:"name"`
+	Description sql.NullString `json:"description"`
+	ParentID    uuid.NullUUID  `json:"parentId"`
+	CreatedAt   time.Time      `json:"createdAt"`
+	UpdatedAt   time.Time      `json:"updatedAt"`
+	Depth       int32          `json:"depth"`
+}
+
+func (q *Queries) GetCategoryAncestors(ctx context.Context, id uuid.UUID) ([]GetCategoryAncestorsRow, error) {
+	rows, err := q.db.QueryContext(ctx, getCategoryAncestors, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetCategoryAncestorsRow{}
+	for rows.Next() {
+		var i GetCategoryAncestorsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Description,
+			&i.ParentID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Depth,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getCategorySubtree = `-- name: GetCategorySubtree :many
+WITH RECURSIVE subtree AS (
+    SELECT c.id, c.name, c.description, c.parent_id, c.created_at, c.updated_at,
+           0 AS depth, ARRAY[c.id]::uuid[] AS path
+    FROM categories c
+    WHERE c.id = $1
+    UNION ALL
+    SELECT c.id, c.name, c.description, c.parent_id, c.created_at, c.updated_at,
+           s.depth + 1, s.path || c.id
+    FROM categories c
+    JOIN subtree s ON c.parent_id = s.id
+    WHERE NOT c.id = ANY(s.path)
+)
+SELECT id, name, description, parent_id, created_at, updated_at, depth
+FROM subtree
+ORDER BY depth, name
+`
+
+type GetCategorySubtreeRow struct {
+	ID          uuid.UUID      `json:"id"`
+	Name        string         `json:"name"`
+	Description sql.NullString `json:"description"`
+	ParentID    uuid.NullUUID  `json:"parentId"`
+	CreatedAt   time.Time      `json:"createdAt"`
+	UpdatedAt   time.Time      `json:"updatedAt"`
+	Depth       int32          `json:"depth"`
+}
+
+func (q *Queries) GetCategorySubtree(ctx context.Context, id uuid.UUID) ([]GetCategorySubtreeRow, error) {
+	rows, err := q.db.QueryContext(ctx, getCategorySubtree, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetCategorySubtreeRow{}
+	for rows.Next() {
+		var i GetCategorySubtreeRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Description,
+			&i.ParentID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Depth,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getCategoryTree = `-- name: GetCategoryTree :many
+WITH RECURSIVE tree AS (
+    SELECT c.id, c.name, c.description, c.parent_id, c.created_at, c.updated_at,
+           0 AS depth, ARRAY[c.id]::uuid[] AS path
+    FROM categories c
+    WHERE c.parent_id IS NULL
+    UNION ALL
+    SELECT c.id, c.name, c.description, c.parent_id, c.created_at, c.updated_at,
+           t.depth + 1, t.path || c.id
+    FROM categories c
+    JOIN tree t ON c.parent_id = t.id
+    WHERE NOT c.id = ANY(t.path)
+)
+SELECT id, name, description, parent_id, created_at, updated_at, depth
+FROM tree
+ORDER BY depth, name
+`
+
+type GetCategoryTreeRow struct {
+	ID          uuid.UUID      `json:"id"`
+	Name        string         `json:"name"`
+	Description sql.NullString `json:"description"`
+	ParentID    uuid.NullUUID  `json:"parentId"`
+	CreatedAt   time.Time      `json:"createdAt"`
+	UpdatedAt   time.Time      `json:"updatedAt"`
+	Depth       int32          `json:"depth"`
+}
+
+func (q *Queries) GetCategoryTree(ctx context.Context) ([]GetCategoryTreeRow, error) {
+	rows, err := q.db.QueryContext(ctx, getCategoryTree)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetCategoryTreeRow{}
+	for rows.Next() {
+		var i GetCategoryTreeRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Description,
+			&i.ParentID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Depth,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listCategories = `-- name: ListCategories :many
 SELECT id, name, description, parent_id, created_at, updated_at FROM categories
+WHERE ($1::uuid IS NULL OR parent_id = $1::uuid)
+  AND ($2::text IS NULL OR name ILIKE '%' || $2::text || '%')
+  AND (
+    $3::uuid IS NULL
+    OR ($4::text = 'name' AND NOT $5::bool
+        AND (name, id) > ($6::text, $3::uuid))
+    OR ($4::text = 'name' AND $5::bool
+        AND (name, id) < ($6::text, $3::uuid))
+    OR ($4::text = 'createdAt' AND NOT $5::bool
+        AND (created_at, id) > ($7::timestamp, $3::uuid))
+    OR ($4::text = 'createdAt' AND $5::bool
+        AND (created_at, id) < ($7::timestamp, $3::uuid))
+  )
+ORDER BY
+    CASE WHEN $4::text = 'name' AND NOT $5::bool THEN name END ASC,
+    CASE WHEN $4::text = 'name' AND $5::bool THEN name END DESC,
+    CASE WHEN $4::text = 'createdAt' AND NOT $5::bool THEN created_at END ASC,
+    CASE WHEN $4::text = 'createdAt' AND $5::bool THEN created_at END DESC,
+    CASE WHEN NOT $5::bool THEN id END ASC,
+    CASE WHEN $5::bool THEN id END DESC
+LIMIT $8
 `
 
-func (q *Queries) ListCategories(ctx context.Context) ([]Category, error) {
-	rows, err := q.db.QueryContext(ctx, listCategories)
+type ListCategoriesParams struct {
+	ParentID       uuid.NullUUID  `json:"parentId"`
+	Name           sql.NullString `json:"name"`
+	AfterID        uuid.NullUUID  `json:"afterId"`
+	SortBy         string         `json:"sortBy"`
+	Descending     bool           `json:"descending"`
+	AfterName      sql.NullString `json:"afterName"`
+	AfterCreatedAt sql.NullTime   `json:"afterCreatedAt"`
+	PageLimit      int32          `json:"pageLimit"`
+}
+
+func (q *Queries) ListCategories(ctx context.Context, arg ListCategoriesParams) ([]Category, error) {
+	rows, err := q.db.QueryContext(ctx, listCategories,
+		arg.ParentID,
+		arg.Name,
+		arg.AfterID,
+		arg.SortBy,
+		arg.Descending,
+		arg.AfterName,
+		arg.AfterCreatedAt,
+		arg.PageLimit,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -110,29 +381,166 @@ func (q *Queries) ListCategories(ctx context.Context) ([]Category, error) {
 	return items, nil
 }
 
+const listChildCategoryIDs = `-- name: ListChildCategoryIDs :many
+SELECT id
+FROM categories
+WHERE parent_id = $1::uuid
+ORDER BY name
+`
+
+func (q *Queries) ListChildCategoryIDs(ctx context.Context, parentID uuid.UUID) ([]uuid.UUID, error) {
+	rows, err := q.db.QueryContext(ctx, listChildCategoryIDs, parentID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []uuid.UUID{}
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const lockCategory = `-- name: LockCategory :one
+SELECT parent_id FROM categories
+WHERE id = $1
+FOR UPDATE
+`
+
+func (q *Queries) LockCategory(ctx context.Context, id uuid.UUID) (uuid.NullUUID, error) {
+	row := q.db.QueryRowContext(ctx, lockCategory, id)
+	var parent_id uuid.NullUUID
+	err := row.Scan(&parent_id)
+	return parent_id, err
+}
+
+const moveCategory = `-- name: MoveCategory :one
+UPDATE categories
+SET parent_id = $1,
+    updated_at = NOW()
+WHERE id = $2
+    AND ($3::timestamp IS NULL OR updated_at = $3)
+RETURNING id, name, description, parent_id, created_at, updated_at
+`
+
+type MoveCategoryParams struct {
+	ParentID    uuid.NullUUID `json:"parentId"`
+	ID          uuid.UUID     `json:"id"`
+	IfUpdatedAt sql.NullTime  `json:"ifUpdatedAt"`
+}
+
+func (q *Queries) MoveCategory(ctx context.Context, arg MoveCategoryParams) (Category, error) {
+	row := q.db.QueryRowContext(ctx, moveCategory, arg.ParentID, arg.ID, arg.IfUpdatedAt)
+	var i Category
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Description,
+		&i.ParentID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const patchCategory = `-- name: PatchCategory :one
+UPDATE categories
+SET name = CASE WHEN $1::bool THEN $2::text ELSE name END,
+    description = CASE WHEN $3::bool THEN $4::text ELSE description END,
+    parent_id = CASE WHEN $5::bool THEN $6::uuid ELSE parent_id END,
+    updated_at = NOW()
+WHERE id = $7
+    AND ($8::timestamp IS NULL OR updated_at = $8)
+RETURNING id, name, description, parent_id, created_at, updated_at
+`
+
+type PatchCategoryParams struct {
+	SetName        bool           `json:"setName"`
+	Name           string         `json:"name"`
+	SetDescription bool           `json:"setDescription"`
+	Description    sql.NullString `json:"description"`
+	SetParentID    bool           `json:"setParentId"`
+	ParentID       uuid.NullUUID  `json:"parentId"`
+	ID             uuid.UUID      `json:"id"`
+	IfUpdatedAt    sql.NullTime   `json:"ifUpdatedAt"`
+}
+
+func (q *Queries) PatchCategory(ctx context.Context, arg PatchCategoryParams) (Category, error) {
+	row := q.db.QueryRowContext(ctx, patchCategory,
+		arg.SetName,
+		arg.Name,
+		arg.SetDescription,
+		arg.Description,
+		arg.SetParentID,
+		arg.ParentID,
+		arg.ID,
+		arg.IfUpdatedAt,
+	)
+	var i Category
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Description,
+		&i.ParentID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const reparentChildCategories = `-- name: ReparentChildCategories :exec
+UPDATE categories
+SET parent_id = $1,
+    updated_at = NOW()
+WHERE parent_id = $2::uuid
+`
+
+type ReparentChildCategoriesParams struct {
+	NewParentID uuid.NullUUID `json:"newParentId"`
+	ID          uuid.UUID     `json:"id"`
+}
+
+func (q *Queries) ReparentChildCategories(ctx context.Context, arg ReparentChildCategoriesParams) error {
+	_, err := q.db.ExecContext(ctx, reparentChildCategories, arg.NewParentID, arg.ID)
+	return err
+}
+
 const updateCategory = `-- name: UpdateCategory :one
 UPDATE categories
-SET name = $2,
-    description = $3,
-    parent_id = $4,
+SET name = $1,
+    description = $2,
+    parent_id = $3,
     updated_at = NOW()
-WHERE id = $1
+WHERE id = $4
+    AND ($5::timestamp IS NULL OR updated_at = $5)
 RETURNING id, name, description, parent_id, created_at, updated_at
 `
 
 type UpdateCategoryParams struct {
-	ID          uuid.UUID      `json:"id"`
 	Name        string         `json:"name"`
 	Description sql.NullString `json:"description"`
 	ParentID    uuid.NullUUID  `json:"parentId"`
+	ID          uuid.UUID      `json:"id"`
+	IfUpdatedAt sql.NullTime   `json:"ifUpdatedAt"`
 }
 
 func (q *Queries) UpdateCategory(ctx context.Context, arg UpdateCategoryParams) (Category, error) {
 	row := q.db.QueryRowContext(ctx, updateCategory,
-		arg.ID,
 		arg.Name,
 		arg.Description,
 		arg.ParentID,
+		arg.ID,
+		arg.IfUpdatedAt,
 	)
 	var i Category
 	err := row.Scan(
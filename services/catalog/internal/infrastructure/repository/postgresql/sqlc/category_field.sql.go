@@ -11,14 +11,16 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 )
 
-const assignFieldToCategory = `-- name: AssignFieldToCategory :exec
+const assignFieldToCategory = `-- name: AssignFieldToCategory :execrows
 INSERT INTO category_fields (
-    category_id, field_id, is_required, display_order
+    category_id, field_id, is_required, display_order, is_hidden
 ) VALUES (
-    $1, $2, $3, $4
+    $1, $2, $3, $4, $5
 )
+ON CONFLICT (category_id, field_id) DO NOTHING
 `
 
 type AssignFieldToCategoryParams struct {
@@ -26,20 +28,55 @@ type AssignFieldToCategoryParams struct {
 	FieldID      uuid.UUID    `json:"fieldId"`
 	IsRequired   sql.NullBool `json:"isRequired"`
 	DisplayOrder int32        `json:"displayOrder"`
+	IsHidden     bool         `json:"isHidden"`
 }
 
-func (q *Queries) AssignFieldToCategory(ctx context.Context, arg AssignFieldToCategoryParams) error {
-	_, err := q.db.ExecContext(ctx, assignFieldToCategory,
+func (q *Queries) AssignFieldToCategory(ctx context.Context, arg AssignFieldToCategoryParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, assignFieldToCategory,
 		arg.CategoryID,
 		arg.FieldID,
 		arg.IsRequired,
 		arg.DisplayOrder,
+		arg.IsHidden,
 	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const deleteCategoryAssignments = `-- name: DeleteCategoryAssignments :exec
+DELETE FROM category_fields
+WHERE category_id = $1
+`
+
+func (q *Queries) DeleteCategoryAssignments(ctx context.Context, categoryID uuid.UUID) error {
+	_, err := q.db.ExecContext(ctx, deleteCategoryAssignments, categoryID)
+	return err
+}
+
+const deleteFieldAssignments = `-- name: DeleteFieldAssignments :exec
+DELETE FROM category_fields
+WHERE field_id = $1
+`
+
+func (q *Queries) DeleteFieldAssignments(ctx context.Context, fieldID uuid.UUID) error {
+	_, err := q.db.ExecContext(ctx, deleteFieldAssignments, fieldID)
+	return err
+}
+
+const deleteFieldTypeAssignments = `-- name: DeleteFieldTypeAssignments :exec
+DELETE FROM category_fields
+WHERE field_id IN (SELECT id FROM fields WHERE field_type_id = $1)
+`
+
+func (q *Queries) DeleteFieldTypeAssignments(ctx context.Context, fieldTypeID uuid.UUID) error {
+	_, err := q.db.ExecContext(ctx, deleteFieldTypeAssignments, fieldTypeID)
 	return err
 }
 
 const getCategoryFields = `-- name: GetCategoryFields :many
-SELECT f.id, f.name, f.description, f.field_type_id, f.created_at, f.updated_at, cf.is_required, cf.display_order
+SELECT f.id, f.name, f.description, f.field_type_id, f.created_at, f.updated_at, cf.is_required, cf.display_order, cf.is_hidden
 FROM fields f
 JOIN category_fields cf ON f.id = cf.field_id
 WHERE cf.category_id = $1
@@ -55,6 +92,7 @@ type GetCategoryFieldsRow struct {
 	UpdatedAt    time.Time      `json:"updatedAt"`
 	IsRequired   sql.NullBool   `json:"isRequired"`
 	DisplayOrder int32          `json:"displayOrder"`
+	IsHidden     bool           `json:"isHidden"`
 }
 
 func (q *Queries) GetCategoryFields(ctx context.Context, categoryID uuid.UUID) ([]GetCategoryFieldsRow, error) {
@@ -75,6 +113,92 @@ func (q *Queries) GetCategoryFields(ctx context.Context, categoryID uuid.UUID) (
 			&i.UpdatedAt,
 			&i.IsRequired,
 			&i.DisplayOrder,
+			&i.IsHidden,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getEffectiveCategoryFields = `-- name: GetEffectiveCategoryFields :many
+WITH RECURSIVE lineage AS (
+    SELECT c.id, c.parent_id, 0 AS depth, ARRAY[c.id]::uuid[] AS path
+    FROM categories c
+    WHERE c.id = $1
+    UNION ALL
+    SELECT c.id, c.parent_id, l.depth + 1, l.path || c.id
+    FROM categories c
+    JOIN lineage l ON c.id = l.parent_id
+    WHERE NOT c.id = ANY(l.path)
+),
+assignments AS (
+    SELECT cf.field_id, cf.category_id, cf.is_required, cf.display_order, cf.is_hidden, l.depth,
+           FIRST_VALUE(cf.category_id) OVER (
+               PARTITION BY cf.field_id ORDER BY l.depth DESC
+           ) AS origin_category_id
+    FROM category_fields cf
+    JOIN lineage l ON cf.category_id = l.id
+),
+resolved AS (
+    SELECT DISTINCT ON (field_id) field_id, category_id, is_required, display_order, is_hidden, depth, origin_category_id
+    FROM assignments
+    ORDER BY field_id, depth
+)
+SELECT f.id, f.name, f.description, f.field_type_id, f.created_at, f.updated_at,
+       r.is_required, r.display_order, r.is_hidden,
+       r.category_id AS source_category_id,
+       r.origin_category_id::uuid AS origin_category_id
+FROM resolved r
+JOIN fields f ON f.id = r.field_id
+ORDER BY r.display_order, f.name
+`
+
+type GetEffectiveCategoryFieldsRow struct {
+	ID               uuid.UUID      `json:"id"`
+	Name             string         `json:"name"`
+	Description      sql.NullString `json:"description"`
+	FieldTypeID      uuid.UUID      `json:"fieldTypeId"`
+	CreatedAt        time.Time      `json:"createdAt"`
+	UpdatedAt        time.Time      `json:"updatedAt"`
+	IsRequired       sql.NullBool   `json:"isRequired"`
+	DisplayOrder     int32          `json:"displayOrder"`
+	IsHidden         bool           `json:"isHidden"`
+	SourceCategoryID uuid.UUID      `json:"sourceCategoryId"`
+	OriginCategoryID uuid.UUID      `json:"originCategoryId"`
+}
+
+// Resolves the field assignments of a category and all of its ancestors.
+// For every field the assignment closest to the category wins; the
+// assignment furthest up the chain is reported as the field's origin.
+func (q *Queries) GetEffectiveCategoryFields(ctx context.Context, id uuid.UUID) ([]GetEffectiveCategoryFieldsRow, error) {
+	rows, err := q.db.QueryContext(ctx, getEffectiveCategoryFields, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetEffectiveCategoryFieldsRow{}
+	for rows.Next() {
+		var i GetEffectiveCategoryFieldsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Description,
+			&i.FieldTypeID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.IsRequired,
+			&i.DisplayOrder,
+			&i.IsHidden,
+			&i.SourceCategoryID,
+			&i.OriginCategoryID,
 		); err != nil {
 			return nil, err
 		}
@@ -88,3 +212,134 @@ func (q *Queries) GetCategoryFields(ctx context.Context, categoryID uuid.UUID) (
 	}
 	return items, nil
 }
+
+const listAssignedFieldIDs = `-- name: ListAssignedFieldIDs :many
+SELECT field_id
+FROM category_fields
+WHERE category_id = $1
+ORDER BY display_order
+`
+
+func (q *Queries) ListAssignedFieldIDs(ctx context.Context, categoryID uuid.UUID) ([]uuid.UUID, error) {
+	rows, err := q.db.QueryContext(ctx, listAssignedFieldIDs, categoryID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []uuid.UUID{}
+	for rows.Next() {
+		var field_id uuid.UUID
+		if err := rows.Scan(&field_id); err != nil {
+			return nil, err
+		}
+		items = append(items, field_id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listAssigningCategoryIDs = `-- name: ListAssigningCategoryIDs :many
+SELECT category_id
+FROM category_fields
+WHERE field_id = $1
+ORDER BY category_id
+`
+
+func (q *Queries) ListAssigningCategoryIDs(ctx context.Context, fieldID uuid.UUID) ([]uuid.UUID, error) {
+	rows, err := q.db.QueryContext(ctx, listAssigningCategoryIDs, fieldID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []uuid.UUID{}
+	for rows.Next() {
+		var category_id uuid.UUID
+		if err := rows.Scan(&category_id); err != nil {
+			return nil, err
+		}
+		items = append(items, category_id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const reorderCategoryFields = `-- name: ReorderCategoryFields :exec
+UPDATE category_fields cf
+SET display_order = o.display_order
+FROM (
+    SELECT unnest($2::uuid[]) AS field_id,
+           unnest($3::int[]) AS display_order
+) o
+WHERE cf.category_id = $1 AND cf.field_id = o.field_id
+`
+
+type ReorderCategoryFieldsParams struct {
+	CategoryID    uuid.UUID   `json:"categoryId"`
+	FieldIds      []uuid.UUID `json:"fieldIds"`
+	DisplayOrders []int32     `json:"displayOrders"`
+}
+
+// Sets the display order of the given fields of a category to their position
+// in field_ids.
+func (q *Queries) ReorderCategoryFields(ctx context.Context, arg ReorderCategoryFieldsParams) error {
+	_, err := q.db.ExecContext(ctx, reorderCategoryFields, arg.CategoryID, pq.Array(arg.FieldIds), pq.Array(arg.DisplayOrders))
+	return err
+}
+
+const unassignFieldFromCategory = `-- name: UnassignFieldFromCategory :execrows
+DELETE FROM category_fields
+WHERE category_id = $1 AND field_id = $2
+`
+
+type UnassignFieldFromCategoryParams struct {
+	CategoryID uuid.UUID `json:"categoryId"`
+	FieldID    uuid.UUID `json:"fieldId"`
+}
+
+func (q *Queries) UnassignFieldFromCategory(ctx context.Context, arg UnassignFieldFromCategoryParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, unassignFieldFromCategory, arg.CategoryID, arg.FieldID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const updateCategoryField = `-- name: UpdateCategoryField :execrows
+UPDATE category_fields
+SET is_required = $3,
+    display_order = $4,
+    is_hidden = $5
+WHERE category_id = $1 AND field_id = $2
+`
+
+type UpdateCategoryFieldParams struct {
+	CategoryID   uuid.UUID    `json:"categoryId"`
+	FieldID      uuid.UUID    `json:"fieldId"`
+	IsRequired   sql.NullBool `json:"isRequired"`
+	DisplayOrder int32        `json:"displayOrder"`
+	IsHidden     bool         `json:"isHidden"`
+}
+
+func (q *Queries) UpdateCategoryField(ctx context.Context, arg UpdateCategoryFieldParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, updateCategoryField,
+		arg.CategoryID,
+		arg.FieldID,
+		arg.IsRequired,
+		arg.DisplayOrder,
+		arg.IsHidden,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
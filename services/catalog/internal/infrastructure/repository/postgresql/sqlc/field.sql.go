@@ -47,13 +47,32 @@ func (q *Queries) CreateField(ctx context.Context, arg CreateFieldParams) (Field
 	return i, err
 }
 
-const deleteField = `-- name: DeleteField :exec
+const deleteField = `-- name: DeleteField :execrows
 DELETE FROM fields
 WHERE id = $1
+    AND ($2::timestamp IS NULL OR updated_at = $2)
 `
 
-func (q *Queries) DeleteField(ctx context.Context, id uuid.UUID) error {
-	_, err := q.db.ExecContext(ctx, deleteField, id)
+type DeleteFieldParams struct {
+	ID          uuid.UUID    `json:"id"`
+	IfUpdatedAt sql.NullTime `json:"ifUpdatedAt"`
+}
+
+func (q *Queries) DeleteField(ctx context.Context, arg DeleteFieldParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteField, arg.ID, arg.IfUpdatedAt)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const deleteFieldsByFieldType = `-- name: DeleteFieldsByFieldType :exec
+DELETE FROM fields
+WHERE field_type_id = $1
+`
+
+func (q *Queries) DeleteFieldsByFieldType(ctx context.Context, fieldTypeID uuid.UUID) error {
+	_, err := q.db.ExecContext(ctx, deleteFieldsByFieldType, fieldTypeID)
 	return err
 }
 
@@ -76,12 +95,83 @@ func (q *Queries) GetField(ctx context.Context, id uuid.UUID) (Field, error) {
 	return i, err
 }
 
+const listFieldIDsByFieldType = `-- name: ListFieldIDsByFieldType :many
+SELECT id
+FROM fields
+WHERE field_type_id = $1
+ORDER BY name
+`
+
+func (q *Queries) ListFieldIDsByFieldType(ctx context.Context, fieldTypeID uuid.UUID) ([]uuid.UUID, error) {
+	rows, err := q.db.QueryContext(ctx, listFieldIDsByFieldType, fieldTypeID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []uuid.UUID{}
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listFields = `-- name: ListFields :many
 SELECT id, name, description, field_type_id, created_at, updated_at FROM fields
+WHERE ($1::uuid IS NULL OR field_type_id = $1::uuid)
+  AND ($2::text IS NULL OR name ILIKE '%' || $2::text || '%')
+  AND (
+    $3::uuid IS NULL
+    OR ($4::text = 'name' AND NOT $5::bool
+        AND (name, id) > ($6::text, $3::uuid))
+    OR ($4::text = 'name' AND $5::bool
+        AND (name, id) < ($6::text, $3::uuid))
+    OR ($4::text = 'createdAt' AND NOT $5::bool
+        AND (created_at, id) > ($7::timestamp, $3::uuid))
+    OR ($4::text = 'createdAt' AND $5::bool
+        AND (created_at, id) < ($7::timestamp, $3::uuid))
+  )
+ORDER BY
+    CASE WHEN $4::text = 'name' AND NOT $5::bool THEN name END ASC,
+    CASE WHEN $4::text = 'name' AND $5::bool THEN name END DESC,
+    CASE WHEN $4::text = 'createdAt' AND NOT $5::bool THEN created_at END ASC,
+    CASE WHEN $4::text = 'createdAt' AND $5::bool THEN created_at END DESC,
+    CASE WHEN NOT $5::bool THEN id END ASC,
+    CASE WHEN $5::bool THEN id END DESC
+LIMIT $8
 `
 
-func (q *Queries) ListFields(ctx context.Context) ([]Field, error) {
-	rows, err := q.db.QueryContext(ctx, listFields)
+type ListFieldsParams struct {
+	FieldTypeID    uuid.NullUUID  `json:"fieldTypeId"`
+	Name           sql.NullString `json:"name"`
+	AfterID        uuid.NullUUID  `json:"afterId"`
+	SortBy         string         `json:"sortBy"`
+	Descending     bool           `json:"descending"`
+	AfterName      sql.NullString `json:"afterName"`
+	AfterCreatedAt sql.NullTime   `json:"afterCreatedAt"`
+	PageLimit      int32          `json:"pageLimit"`
+}
+
+func (q *Queries) ListFields(ctx context.Context, arg ListFieldsParams) ([]Field, error) {
+	rows, err := q.db.QueryContext(ctx, listFields,
+		arg.FieldTypeID,
+		arg.Name,
+		arg.AfterID,
+		arg.SortBy,
+		arg.Descending,
+		arg.AfterName,
+		arg.AfterCreatedAt,
+		arg.PageLimit,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -110,29 +200,89 @@ func (q *Queries) ListFields(ctx context.Context) ([]Field, error) {
 	return items, nil
 }
 
+const lockField = `-- name: LockField :one
+SELECT id FROM fields
+WHERE id = $1
+FOR UPDATE
+`
+
+func (q *Queries) LockField(ctx context.Context, id uuid.UUID) (uuid.UUID, error) {
+	row := q.db.QueryRowContext(ctx, lockField, id)
+	err := row.Scan(&id)
+	return id, err
+}
+
+const patchField = `-- name: PatchField :one
+UPDATE fields
+SET name = CASE WHEN $1::bool THEN $2::text ELSE name END,
+    description = CASE WHEN $3::bool THEN $4::text ELSE description END,
+    field_type_id = CASE WHEN $5::bool THEN $6::uuid ELSE field_type_id END,
+    updated_at = NOW()
+WHERE id = $7
+    AND ($8::timestamp IS NULL OR updated_at = $8)
+RETURNING id, name, description, field_type_id, created_at, updated_at
+`
+
+type PatchFieldParams struct {
+	SetName        bool           `json:"setName"`
+	Name           string         `json:"name"`
+	SetDescription bool           `json:"setDescription"`
+	Description    sql.NullString `json:"description"`
+	SetFieldTypeID bool           `json:"setFieldTypeId"`
+	FieldTypeID    uuid.UUID      `json:"fieldTypeId"`
+	ID             uuid.UUID      `json:"id"`
+	IfUpdatedAt    sql.NullTime   `json:"ifUpdatedAt"`
+}
+
+func (q *Queries) PatchField(ctx context.Context, arg PatchFieldParams) (Field, error) {
+	row := q.db.QueryRowContext(ctx, patchField,
+		arg.SetName,
+		arg.Name,
+		arg.SetDescription,
+		arg.Description,
+		arg.SetFieldTypeID,
+		arg.FieldTypeID,
+		arg.ID,
+		arg.IfUpdatedAt,
+	)
+	var i Field
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Description,
+		&i.FieldTypeID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
 const updateField = `-- name: UpdateField :one
 UPDATE fields
-SET name = $2,
-    description = $3,
-    field_type_id = $4,
+SET name = $1,
+    description = $2,
+    field_type_id = $3,
     updated_at = NOW()
-WHERE id = $1
+WHERE id = $4
+    AND ($5::timestamp IS NULL OR updated_at = $5)
 RETURNING id, name, description, field_type_id, created_at, updated_at
 `
 
 type UpdateFieldParams struct {
-	ID          uuid.UUID      `json:"id"`
 	Name        string         `json:"name"`
 	Description sql.NullString `json:"description"`
 	FieldTypeID uuid.UUID      `json:"fieldTypeId"`
+	ID          uuid.UUID      `json:"id"`
+	IfUpdatedAt sql.NullTime   `json:"ifUpdatedAt"`
 }
 
 func (q *Queries) UpdateField(ctx context.Context, arg UpdateFieldParams) (Field, error) {
 	row := q.db.QueryRowContext(ctx, updateField,
-		arg.ID,
 		arg.Name,
 		arg.Description,
 		arg.FieldTypeID,
+		arg.ID,
+		arg.IfUpdatedAt,
 	)
 	var i Field
 	err := row.Scan(
@@ -7,6 +7,7 @@ package sqlc
 
 import (
 	"context"
+	"database/sql"
 	"encoding/json"
 
 	"github.com/google/uuid"
@@ -50,14 +51,23 @@ func (q *Queries) CreateFieldType(ctx context.Context, arg CreateFieldTypeParams
 	return i, err
 }
 
-const deleteFieldType = `-- name: DeleteFieldType :exec
+const deleteFieldType = `-- name: DeleteFieldType :execrows
 DELETE FROM field_types
 WHERE id = $1
+    AND ($2::timestamp IS NULL OR updated_at = $2)
 `
 
-func (q *Queries) DeleteFieldType(ctx context.Context, id uuid.UUID) error {
-	_, err := q.db.ExecContext(ctx, deleteFieldType, id)
-	return err
+type DeleteFieldTypeParams struct {
+	ID          uuid.UUID    `json:"id"`
+	IfUpdatedAt sql.NullTime `json:"ifUpdatedAt"`
+}
+
+func (q *Queries) DeleteFieldType(ctx context.Context, arg DeleteFieldTypeParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteFieldType, arg.ID, arg.IfUpdatedAt)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
 }
 
 const getFieldType = `-- name: GetFieldType :one
@@ -81,10 +91,51 @@ func (q *Queries) GetFieldType(ctx context.Context, id uuid.UUID) (FieldType, er
 
 const listFieldTypes = `-- name: ListFieldTypes :many
 SELECT id, name, type_discriminator_id, properties, created_at, updated_at FROM field_types
+WHERE ($1::uuid IS NULL OR type_discriminator_id = $1::uuid)
+  AND ($2::text IS NULL OR name ILIKE '%' || $2::text || '%')
+  AND (
+    $3::uuid IS NULL
+    OR ($4::text = 'name' AND NOT $5::bool
+        AND (name, id) > ($6::text, $3::uuid))
+    OR ($4::text = 'name' AND $5::bool
+        AND (name, id) < ($6::text, $3::uuid))
+    OR ($4::text = 'createdAt' AND NOT $5::bool
+        AND (created_at, id) > ($7::timestamp, $3::uuid))
+    OR ($4::text = 'createdAt' AND $5::bool
+        AND (created_at, id) < ($7::timestamp, $3::uuid))
+  )
+ORDER BY
+    CASE WHEN $4::text = 'name' AND NOT $5::bool THEN name END ASC,
+    CASE WHEN $4::text = 'name' AND $5::bool THEN name END DESC,
+    CASE WHEN $4::text = 'createdAt' AND NOT $5::bool THEN created_at END ASC,
+    CASE WHEN $4::text = 'createdAt' AND $5::bool THEN created_at END DESC,
+    CASE WHEN NOT $5::bool THEN id END ASC,
+    CASE WHEN $5::bool THEN id END DESC
+LIMIT $8
 `
 
-func (q *Queries) ListFieldTypes(ctx context.Context) ([]FieldType, error) {
-	rows, err := q.db.QueryContext(ctx, listFieldTypes)
+type ListFieldTypesParams struct {
+	TypeDiscriminatorID uuid.NullUUID  `json:"typeDiscriminatorId"`
+	Name                sql.NullString `json:"name"`
+	AfterID             uuid.NullUUID  `json:"afterId"`
+	SortBy              string         `json:"sortBy"`
+	Descending          bool           `json:"descending"`
+	AfterName           sql.NullString `json:"afterName"`
+	AfterCreatedAt      sql.NullTime   `json:"afterCreatedAt"`
+	PageLimit           int32          `json:"pageLimit"`
+}
+
+func (q *Queries) ListFieldTypes(ctx context.Context, arg ListFieldTypesParams) ([]FieldType, error) {
+	rows, err := q.db.QueryContext(ctx, listFieldTypes,
+		arg.TypeDiscriminatorID,
+		arg.Name,
+		arg.AfterID,
+		arg.SortBy,
+		arg.Descending,
+		arg.AfterName,
+		arg.AfterCreatedAt,
+		arg.PageLimit,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -113,29 +164,89 @@ func (q *Queries) ListFieldTypes(ctx context.Context) ([]FieldType, error) {
 	return items, nil
 }
 
+const lockFieldType = `-- name: LockFieldType :one
+SELECT id FROM field_types
+WHERE id = $1
+FOR UPDATE
+`
+
+func (q *Queries) LockFieldType(ctx context.Context, id uuid.UUID) (uuid.UUID, error) {
+	row := q.db.QueryRowContext(ctx, lockFieldType, id)
+	err := row.Scan(&id)
+	return id, err
+}
+
+const patchFieldType = `-- name: PatchFieldType :one
+UPDATE field_types
+SET name = CASE WHEN $1::bool THEN $2::text ELSE name END,
+    type_discriminator_id = CASE WHEN $3::bool THEN $4::uuid ELSE type_discriminator_id END,
+    properties = CASE WHEN $5::bool THEN $6::jsonb ELSE properties END,
+    updated_at = NOW()
+WHERE id = $7
+    AND ($8::timestamp IS NULL OR updated_at = $8)
+RETURNING id, name, type_discriminator_id, properties, created_at, updated_at
+`
+
+type PatchFieldTypeParams struct {
+	SetName                bool            `json:"setName"`
+	Name                   string          `json:"name"`
+	SetTypeDiscriminatorID bool            `json:"setTypeDiscriminatorId"`
+	TypeDiscriminatorID    uuid.UUID       `json:"typeDiscriminatorId"`
+	SetProperties          bool            `json:"setProperties"`
+	Properties             json.RawMessage `json:"properties"`
+	ID                     uuid.UUID       `json:"id"`
+	IfUpdatedAt            sql.NullTime    `json:"ifUpdatedAt"`
+}
+
+func (q *Queries) PatchFieldType(ctx context.Context, arg PatchFieldTypeParams) (FieldType, error) {
+	row := q.db.QueryRowContext(ctx, patchFieldType,
+		arg.SetName,
+		arg.Name,
+		arg.SetTypeDiscriminatorID,
+		arg.TypeDiscriminatorID,
+		arg.SetProperties,
+		arg.Properties,
+		arg.ID,
+		arg.IfUpdatedAt,
+	)
+	var i FieldType
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.TypeDiscriminatorID,
+		&i.Properties,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
 const updateFieldType = `-- name: UpdateFieldType :one
 UPDATE field_types
-SET name = $2,
-    type_discriminator_id = $3,
-    properties = $4,
+SET name = $1,
+    type_discriminator_id = $2,
+    properties = $3,
     updated_at = NOW()
-WHERE id = $1
+WHERE id = $4
+    AND ($5::timestamp IS NULL OR updated_at = $5)
 RETURNING id, name, type_discriminator_id, properties, created_at, updated_at
 `
 
 type UpdateFieldTypeParams struct {
-	ID                  uuid.UUID       `json:"id"`
 	Name                string          `json:"name"`
 	TypeDiscriminatorID uuid.UUID       `json:"typeDiscriminatorId"`
 	Properties          json.RawMessage `json:"properties"`
+	ID                  uuid.UUID       `json:"id"`
+	IfUpdatedAt         sql.NullTime    `json:"ifUpdatedAt"`
 }
 
 func (q *Queries) UpdateFieldType(ctx context.Context, arg UpdateFieldTypeParams) (FieldType, error) {
 	row := q.db.QueryRowContext(ctx, updateFieldType,
-		arg.ID,
 		arg.Name,
 		arg.TypeDiscriminatorID,
 		arg.Properties,
+		arg.ID,
+		arg.IfUpdatedAt,
 	)
 	var i FieldType
 	err := row.Scan(
@@ -80,10 +80,48 @@ func (q *Queries) GetFieldTypeDiscriminator(ctx context.Context, id uuid.UUID) (
 
 const listFieldTypeDiscriminators = `-- name: ListFieldTypeDiscriminators :many
 SELECT id, name, description, validation_schema, created_at FROM field_type_discriminators
+WHERE ($1::text IS NULL OR name ILIKE '%' || $1::text || '%')
+  AND (
+    $2::uuid IS NULL
+    OR ($3::text = 'name' AND NOT $4::bool
+        AND (name, id) > ($5::text, $2::uuid))
+    OR ($3::text = 'name' AND $4::bool
+        AND (name, id) < ($5::text, $2::uuid))
+    OR ($3::text = 'createdAt' AND NOT $4::bool
+        AND (created_at, id) > ($6::timestamp, $2::uuid))
+    OR ($3::text = 'createdAt' AND $4::bool
+        AND (created_at, id) < ($6::timestamp, $2::uuid))
+  )
+ORDER BY
+    CASE WHEN $3::text = 'name' AND NOT $4::bool THEN name END ASC,
+    CASE WHEN $3::text = 'name' AND $4::bool THEN name END DESC,
+    CASE WHEN $3::text = 'createdAt' AND NOT $4::bool THEN created_at END ASC,
+    CASE WHEN $3::text = 'createdAt' AND $4::bool THEN created_at END DESC,
+    CASE WHEN NOT $4::bool THEN id END ASC,
+    CASE WHEN $4::bool THEN id END DESC
+LIMIT $7
 `
 
-func (q *Queries) ListFieldTypeDiscriminators(ctx context.Context) ([]FieldTypeDiscriminator, error) {
-	rows, err := q.db.QueryContext(ctx, listFieldTypeDiscriminators)
+type ListFieldTypeDiscriminatorsParams struct {
+	Name           sql.NullString `json:"name"`
+	AfterID        uuid.NullUUID  `json:"afterId"`
+	SortBy         string         `json:"sortBy"`
+	Descending     bool           `json:"descending"`
+	AfterName      sql.NullString `json:"afterName"`
+	AfterCreatedAt sql.NullTime   `json:"afterCreatedAt"`
+	PageLimit      int32          `json:"pageLimit"`
+}
+
+func (q *Queries) ListFieldTypeDiscriminators(ctx context.Context, arg ListFieldTypeDiscriminatorsParams) ([]FieldTypeDiscriminator, error) {
+	rows, err := q.db.QueryContext(ctx, listFieldTypeDiscriminators,
+		arg.Name,
+		arg.AfterID,
+		arg.SortBy,
+		arg.Descending,
+		arg.AfterName,
+		arg.AfterCreatedAt,
+		arg.PageLimit,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -26,6 +26,7 @@ type CategoryField struct {
 	FieldID      uuid.UUID    `json:"fieldId"`
 	IsRequired   sql.NullBool `json:"isRequired"`
 	DisplayOrder int32        `json:"displayOrder"`
+	IsHidden     bool         `json:"isHidden"`
 }
 
 type Field struct {
@@ -11,25 +11,57 @@ import (
 )
 
 type Querier interface {
-	AssignFieldToCategory(ctx context.Context, arg AssignFieldToCategoryParams) error
+	AssignFieldToCategory(ctx context.Context, arg AssignFieldToCategoryParams) (int64, error)
 	CreateCategory(ctx context.Context, arg CreateCategoryParams) (Category, error)
 	CreateField(ctx context.Context, arg CreateFieldParams) (Field, error)
 	CreateFieldType(ctx context.Context, arg CreateFieldTypeParams) (FieldType, error)
 	CreateFieldTypeDiscriminator(ctx context.Context, arg CreateFieldTypeDiscriminatorParams) (FieldTypeDiscriminator, error)
-	DeleteCategory(ctx context.Context, id uuid.UUID) error
-	DeleteField(ctx context.Context, id uuid.UUID) error
-	DeleteFieldType(ctx context.Context, id uuid.UUID) error
+	DeleteCategory(ctx context.Context, arg DeleteCategoryParams) (int64, error)
+	DeleteCategoryAssignments(ctx context.Context, categoryID uuid.UUID) error
+	DeleteCategoryDescendantAssignments(ctx context.Context, parentID uuid.UUID) error
+	// Foreign keys are checked at the end of the statement, so the subtree can be
+	// deleted in any order.
+	DeleteCategoryDescendants(ctx context.Context, parentID uuid.UUID) error
+	DeleteField(ctx context.Context, arg DeleteFieldParams) (int64, error)
+	DeleteFieldAssignments(ctx context.Context, fieldID uuid.UUID) error
+	DeleteFieldType(ctx context.Context, arg DeleteFieldTypeParams) (int64, error)
+	DeleteFieldTypeAssignments(ctx context.Context, fieldTypeID uuid.UUID) error
 	DeleteFieldTypeDiscriminator(ctx context.Context, id uuid.UUID) error
+	DeleteFieldsByFieldType(ctx context.Context, fieldTypeID uuid.UUID) error
 	GetCategory(ctx context.Context, id uuid.UUID) (Category, error)
+	GetCategoryAncestors(ctx context.Context, id uuid.UUID) ([]GetCategoryAncestorsRow, error)
 	GetCategoryFields(ctx context.Context, categoryID uuid.UUID) ([]GetCategoryFieldsRow, error)
+	GetCategorySubtree(ctx context.Context, id uuid.UUID) ([]GetCategorySubtreeRow, error)
+	GetCategoryTree(ctx context.Context) ([]GetCategoryTreeRow, error)
+	// Resolves the field assignments of a category and all of its ancestors.
+	// For every field the assignment closest to the category wins; the
+	// assignment furthest up the chain is reported as the field's origin.
+	GetEffectiveCategoryFields(ctx context.Context, id uuid.UUID) ([]GetEffectiveCategoryFieldsRow, error)
 	GetField(ctx context.Context, id uuid.UUID) (Field, error)
 	GetFieldType(ctx context.Context, id uuid.UUID) (FieldType, error)
 	GetFieldTypeDiscriminator(ctx context.Context, id uuid.UUID) (FieldTypeDiscriminator, error)
-	ListCategories(ctx context.Context) ([]Category, error)
-	ListFieldTypeDiscriminators(ctx context.Context) ([]FieldTypeDiscriminator, error)
-	ListFieldTypes(ctx context.Context) ([]FieldType, error)
-	ListFields(ctx context.Context) ([]Field, error)
+	ListAssignedFieldIDs(ctx context.Context, categoryID uuid.UUID) ([]uuid.UUID, error)
+	ListAssigningCategoryIDs(ctx context.Context, fieldID uuid.UUID) ([]uuid.UUID, error)
+	ListCategories(ctx context.Context, arg ListCategoriesParams) ([]Category, error)
+	ListChildCategoryIDs(ctx context.Context, parentID uuid.UUID) ([]uuid.UUID, error)
+	ListFieldIDsByFieldType(ctx context.Context, fieldTypeID uuid.UUID) ([]uuid.UUID, error)
+	ListFieldTypeDiscriminators(ctx context.Context, arg ListFieldTypeDiscriminatorsParams) ([]FieldTypeDiscriminator, error)
+	ListFieldTypes(ctx context.Context, arg ListFieldTypesParams) ([]FieldType, error)
+	ListFields(ctx context.Context, arg ListFieldsParams) ([]Field, error)
+	LockCategory(ctx context.Context, id uuid.UUID) (uuid.NullUUID, error)
+	LockField(ctx context.Context, id uuid.UUID) (uuid.UUID, error)
+	LockFieldType(ctx context.Context, id uuid.UUID) (uuid.UUID, error)
+	MoveCategory(ctx context.Context, arg MoveCategoryParams) (Category, error)
+	PatchCategory(ctx context.Context, arg PatchCategoryParams) (Category, error)
+	PatchField(ctx context.Context, arg PatchFieldParams) (Field, error)
+	PatchFieldType(ctx context.Context, arg PatchFieldTypeParams) (FieldType, error)
+	// Sets the display order of the given fields of a category to their position
+	// in field_ids.
+	ReorderCategoryFields(ctx context.Context, arg ReorderCategoryFieldsParams) error
+	ReparentChildCategories(ctx context.Context, arg ReparentChildCategoriesParams) error
+	UnassignFieldFromCategory(ctx context.Context, arg UnassignFieldFromCategoryParams) (int64, error)
 	UpdateCategory(ctx context.Context, arg UpdateCategoryParams) (Category, error)
+	UpdateCategoryField(ctx context.Context, arg UpdateCategoryFieldParams) (int64, error)
 	UpdateField(ctx context.Context, arg UpdateFieldParams) (Field, error)
 	UpdateFieldType(ctx context.Context, arg UpdateFieldTypeParams) (FieldType, error)
 	UpdateFieldTypeDiscriminator(ctx context.Context, arg UpdateFieldTypeDiscriminatorParams) (FieldTypeDiscriminator, error)
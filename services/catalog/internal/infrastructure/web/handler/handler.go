@@ -4,6 +4,7 @@ import (
 	"context"
 	catalog "localloop/services/catalog/internal/domain"
 	"net/http"
+	"time"
 
 	"localloop/libs/pkg/web/crud"
 
@@ -16,6 +17,10 @@ type CatalogHandler struct {
 	Field          *crud.CRUDHandler[catalog.Field, CreateFieldRequest, UpdateFieldRequest, FieldResponse]
 	FieldType      *crud.CRUDHandler[catalog.FieldType, CreateFieldTypeRequest, UpdateFieldTypeRequest, FieldTypeResponse]
 	Discriminator  *crud.CRUDHandler[catalog.FieldTypeDiscriminator, CreateFieldTypeDiscriminatorRequest, UpdateFieldTypeDiscriminatorRequest, FieldTypeDiscriminatorResponse]
+
+	CategoryPatch  *crud.PatchHandler[catalog.Category, PatchCategoryRequest, CategoryResponse]
+	FieldPatch     *crud.PatchHandler[catalog.Field, PatchFieldRequest, FieldResponse]
+	FieldTypePatch *crud.PatchHandler[catalog.FieldType, PatchFieldTypeRequest, FieldTypeResponse]
 }
 
 func NewCatalogHandler(catalogService *catalog.Service) *CatalogHandler {
@@ -41,9 +46,13 @@ func NewCatalogHandler(catalogService *catalog.Service) *CatalogHandler {
 				ParentID:    req.ParentID,
 			})
 		},
-		h.catalogService.DeleteCategory,
+		func(ctx context.Context, id uuid.UUID) error {
+			return h.catalogService.DeleteCategory(ctx, catalog.DeleteCategoryParams{ID: id})
+		},
 		h.catalogService.ListCategories,
 		toCategoryResponse,
+		catalog.CategoryListSpec,
+		crud.WithVersion(categoryVersion),
 	)
 
 	h.Field = crud.NewCRUDHandler(
@@ -63,9 +72,13 @@ func NewCatalogHandler(catalogService *catalog.Service) *CatalogHandler {
 				FieldTypeID: req.FieldTypeID,
 			})
 		},
-		h.catalogService.DeleteField,
+		func(ctx context.Context, id uuid.UUID) error {
+			return h.catalogService.DeleteField(ctx, catalog.DeleteFieldParams{ID: id})
+		},
 		h.catalogService.ListFields,
 		toFieldResponse,
+		catalog.FieldListSpec,
+		crud.WithVersion(fieldVersion),
 	)
 
 	h.FieldType = crud.NewCRUDHandler(
@@ -85,9 +98,13 @@ func NewCatalogHandler(catalogService *catalog.Service) *CatalogHandler {
 				Properties:          req.Properties,
 			})
 		},
-		h.catalogService.DeleteFieldType,
+		func(ctx context.Context, id uuid.UUID) error {
+			return h.catalogService.DeleteFieldType(ctx, catalog.DeleteFieldTypeParams{ID: id})
+		},
 		h.catalogService.ListFieldTypes,
 		toFieldTypeResponse,
+		catalog.FieldTypeListSpec,
+		crud.WithVersion(fieldTypeVersion),
 	)
 
 	h.Discriminator = crud.NewCRUDHandler(
@@ -110,15 +127,157 @@ func NewCatalogHandler(catalogService *catalog.Service) *CatalogHandler {
 		h.catalogService.DeleteFieldTypeDiscriminator,
 		h.catalogService.ListFieldTypeDiscriminators,
 		toFieldTypeDiscriminatorResponse,
+		catalog.FieldTypeDiscriminatorListSpec,
+	)
+
+	h.CategoryPatch = crud.NewPatchHandler(
+		func(ctx context.Context, id uuid.UUID, req PatchCategoryRequest) (*catalog.Category, error) {
+			return h.catalogService.PatchCategory(ctx, catalog.PatchCategoryParams{
+				ID:          id,
+				Name:        req.Name,
+				Description: req.Description,
+				ParentID:    req.ParentID,
+			})
+		},
+		toCategoryResponse,
+		crud.WithVersion(categoryVersion),
+	)
+
+	h.FieldPatch = crud.NewPatchHandler(
+		func(ctx context.Context, id uuid.UUID, req PatchFieldRequest) (*catalog.Field, error) {
+			return h.catalogService.PatchField(ctx, catalog.PatchFieldParams{
+				ID:          id,
+				Name:        req.Name,
+				Description: req.Description,
+				FieldTypeID: req.FieldTypeID,
+			})
+		},
+		toFieldResponse,
+		crud.WithVersion(fieldVersion),
+	)
+
+	h.FieldTypePatch = crud.NewPatchHandler(
+		func(ctx context.Context, id uuid.UUID, req PatchFieldTypeRequest) (*catalog.FieldType, error) {
+			return h.catalogService.PatchFieldType(ctx, catalog.PatchFieldTypeParams{
+				ID:                  id,
+				Name:                req.Name,
+				TypeDiscriminatorID: req.TypeDiscriminatorID,
+				Properties:          req.Properties,
+			})
+		},
+		toFieldTypeResponse,
+		crud.WithVersion(fieldTypeVersion),
 	)
 
 	return h
 }
 
-type ApiResponse struct {
-	Message string      `json:"message,omitempty"`
-	Data    interface{} `json:"data,omitempty"`
-	Error   string      `json:"error,omitempty"`
+// Versions of the resources that take part in optimistic concurrency, see
+// crud.WithVersion
+func categoryVersion(c *catalog.Category) time.Time   { return c.UpdatedAt }
+func fieldVersion(f *catalog.Field) time.Time         { return f.UpdatedAt }
+func fieldTypeVersion(t *catalog.FieldType) time.Time { return t.UpdatedAt }
+
+// DeleteCategory replaces the CRUD delete of categories to let the request
+// choose a delete strategy
+func (h *CatalogHandler) DeleteCategory(req DeleteCategoryRequest, r *http.Request) (any, error) {
+	ctx, err := h.Category.IfMatch(r)
+	if err != nil {
+		return nil, err
+	}
+
+	err = h.catalogService.DeleteCategory(ctx, catalog.DeleteCategoryParams{
+		ID:       req.ID,
+		Strategy: req.Strategy,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return map[string]string{"id": req.ID.String()}, nil
+}
+
+func (h *CatalogHandler) DeleteField(req DeleteFieldRequest, r *http.Request) (any, error) {
+	ctx, err := h.Field.IfMatch(r)
+	if err != nil {
+		return nil, err
+	}
+
+	err = h.catalogService.DeleteField(ctx, catalog.DeleteFieldParams{
+		ID:       req.ID,
+		Strategy: req.Strategy,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return map[string]string{"id": req.ID.String()}, nil
+}
+
+func (h *CatalogHandler) DeleteFieldType(req DeleteFieldTypeRequest, r *http.Request) (any, error) {
+	ctx, err := h.FieldType.IfMatch(r)
+	if err != nil {
+		return nil, err
+	}
+
+	err = h.catalogService.DeleteFieldType(ctx, catalog.DeleteFieldTypeParams{
+		ID:       req.ID,
+		Strategy: req.Strategy,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return map[string]string{"id": req.ID.String()}, nil
+}
+
+func (h *CatalogHandler) GetCategoryTree(req GetCategoryTreeRequest, r *http.Request) (any, error) {
+	roots, err := h.catalogService.GetCategoryTree(r.Context())
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]CategoryTreeNodeResponse, 0, len(roots))
+	for _, root := range roots {
+		responses = append(responses, toCategoryTreeNodeResponse(root))
+	}
+	return responses, nil
+}
+
+func (h *CatalogHandler) GetCategorySubtree(req GetCategoryRelativesRequest, r *http.Request) (any, error) {
+	subtree, err := h.catalogService.GetCategorySubtree(r.Context(), req.ID)
+	if err != nil {
+		return nil, err
+	}
+	return toCategoryTreeNodeResponse(subtree), nil
+}
+
+func (h *CatalogHandler) GetCategoryAncestors(req GetCategoryRelativesRequest, r *http.Request) (any, error) {
+	ancestors, err := h.catalogService.GetCategoryAncestors(r.Context(), req.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]CategoryResponse, 0, len(ancestors))
+	for _, ancestor := range ancestors {
+		responses = append(responses, toCategoryResponse(ancestor))
+	}
+	return responses, nil
+}
+
+// MoveCategory re-parents a category, it is a write to the category like
+// its update and shares its preconditions
+func (h *CatalogHandler) MoveCategory(req MoveCategoryRequest, r *http.Request) (any, error) {
+	ctx, err := h.Category.IfMatch(r)
+	if err != nil {
+		return nil, err
+	}
+
+	category, err := h.catalogService.MoveCategory(ctx, catalog.MoveCategoryParams{
+		ID:       req.ID,
+		ParentID: req.ParentID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return h.Category.Respond(category), nil
 }
 
 func (h *CatalogHandler) GetCategoryFields(req GetCategoryFieldsRequest, r *http.Request) (any, error) {
@@ -127,19 +286,33 @@ func (h *CatalogHandler) GetCategoryFields(req GetCategoryFieldsRequest, r *http
 		return nil, err
 	}
 
-	var responses []CategoryFieldResponse
+	responses := make([]CategoryFieldResponse, 0, len(fields))
 	for _, fieldInfo := range fields {
 		responses = append(responses, toCategoryFieldResponse(fieldInfo))
 	}
 	return responses, nil
 }
 
+func (h *CatalogHandler) GetEffectiveCategoryFields(req GetEffectiveCategoryFieldsRequest, r *http.Request) (any, error) {
+	fields, err := h.catalogService.GetEffectiveCategoryFields(r.Context(), req.CategoryID, req.IncludeHidden)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]EffectiveCategoryFieldResponse, 0, len(fields))
+	for _, field := range fields {
+		responses = append(responses, toEffectiveCategoryFieldResponse(field))
+	}
+	return responses, nil
+}
+
 func (h *CatalogHandler) AssignFieldToCategory(req AssignFieldToCategoryRequest, r *http.Request) (any, error) {
 	params := catalog.AssignFieldParams{
 		CategoryID:   req.CategoryID,
 		FieldID:      req.FieldID,
 		IsRequired:   req.IsRequired,
 		DisplayOrder: req.DisplayOrder,
+		IsHidden:     req.IsHidden,
 	}
 
 	if err := h.catalogService.AssignFieldToCategory(r.Context(), params); err != nil {
@@ -151,3 +324,49 @@ func (h *CatalogHandler) AssignFieldToCategory(req AssignFieldToCategoryRequest,
 		"fieldId":    req.FieldID,
 	}, nil
 }
+
+func (h *CatalogHandler) UpdateCategoryField(req UpdateCategoryFieldRequest, r *http.Request) (any, error) {
+	params := catalog.UpdateCategoryFieldParams{
+		CategoryID:   req.CategoryID,
+		FieldID:      req.FieldID,
+		IsRequired:   req.IsRequired,
+		DisplayOrder: req.DisplayOrder,
+		IsHidden:     req.IsHidden,
+	}
+
+	if err := h.catalogService.UpdateCategoryField(r.Context(), params); err != nil {
+		return nil, err
+	}
+
+	return map[string]interface{}{
+		"categoryId": req.CategoryID,
+		"fieldId":    req.FieldID,
+	}, nil
+}
+
+func (h *CatalogHandler) UnassignFieldFromCategory(req UnassignFieldFromCategoryRequest, r *http.Request) (any, error) {
+	if err := h.catalogService.UnassignFieldFromCategory(r.Context(), req.CategoryID, req.FieldID); err != nil {
+		return nil, err
+	}
+
+	return map[string]interface{}{
+		"categoryId": req.CategoryID,
+		"fieldId":    req.FieldID,
+	}, nil
+}
+
+func (h *CatalogHandler) ReorderCategoryFields(req ReorderCategoryFieldsRequest, r *http.Request) (any, error) {
+	fields, err := h.catalogService.ReorderCategoryFields(r.Context(), catalog.ReorderCategoryFieldsParams{
+		CategoryID: req.CategoryID,
+		FieldIDs:   req.FieldIDs,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]CategoryFieldResponse, 0, len(fields))
+	for _, fieldInfo := range fields {
+		responses = append(responses, toCategoryFieldResponse(fieldInfo))
+	}
+	return responses, nil
+}
@@ -1,62 +1,118 @@
 // Request types for handlers
 package handler
 
-import "github.com/google/uuid"
+import (
+	"localloop/libs/pkg/patch"
+	catalog "localloop/services/catalog/internal/domain"
+
+	"github.com/google/uuid"
+)
 
 type CreateCategoryRequest struct {
-	Name        string     `json:"name"`
+	Name        string     `json:"name" validate:"required,max=100"`
 	Description string     `json:"description"`
 	ParentID    *uuid.UUID `json:"parentId,omitempty"`
 }
 
 type UpdateCategoryRequest struct {
-	Name        string     `json:"name"`
+	Name        string     `json:"name" validate:"required,max=100"`
 	Description string     `json:"description"`
 	ParentID    *uuid.UUID `json:"parentId,omitempty"`
 }
 
+// DeleteCategoryRequest deletes a category, strategy decides what becomes of
+// its children and field assignments
+type DeleteCategoryRequest struct {
+	ID       uuid.UUID              `param:"id" validate:"required"`
+	Strategy catalog.DeleteStrategy `query:"strategy" validate:"omitempty,oneof=restrict cascade reparent"`
+}
+
+// PatchCategoryRequest is a JSON merge patch of a category, a null
+// description or parentId clears it
+type PatchCategoryRequest struct {
+	Name        patch.Field[string]    `json:"name" validate:"omitempty,max=100"`
+	Description patch.Field[string]    `json:"description"`
+	ParentID    patch.Field[uuid.UUID] `json:"parentId"`
+}
+
+type GetCategoryTreeRequest struct{}
+
+type GetCategoryRelativesRequest struct {
+	ID uuid.UUID `param:"id"`
+}
+
+type MoveCategoryRequest struct {
+	ID       uuid.UUID  `param:"id"`
+	ParentID *uuid.UUID `json:"parentId"`
+}
+
 // Field request/response types
 type CreateFieldRequest struct {
-	Name        string    `json:"name"`
+	Name        string    `json:"name" validate:"required,max=100"`
 	Description string    `json:"description"`
-	FieldTypeID uuid.UUID `json:"fieldTypeId"`
+	FieldTypeID uuid.UUID `json:"fieldTypeId" validate:"required"`
 }
 
 type UpdateFieldRequest struct {
-	Name        string    `json:"name"`
+	Name        string    `json:"name" validate:"required,max=100"`
 	Description string    `json:"description"`
-	FieldTypeID uuid.UUID `json:"fieldTypeId"`
+	FieldTypeID uuid.UUID `json:"fieldTypeId" validate:"required"`
+}
+
+type DeleteFieldRequest struct {
+	ID       uuid.UUID              `param:"id" validate:"required"`
+	Strategy catalog.DeleteStrategy `query:"strategy" validate:"omitempty,oneof=restrict cascade"`
+}
+
+// PatchFieldRequest is a JSON merge patch of a field
+type PatchFieldRequest struct {
+	Name        patch.Field[string]    `json:"name" validate:"omitempty,max=100"`
+	Description patch.Field[string]    `json:"description"`
+	FieldTypeID patch.Field[uuid.UUID] `json:"fieldTypeId"`
 }
 
 // Field Type request/response types
 type CreateFieldTypeRequest struct {
-	Name                string                 `json:"name"`
-	TypeDiscriminatorID uuid.UUID              `json:"typeDiscriminatorId"`
-	Properties          map[string]interface{} `json:"properties"`
+	Name                string                 `json:"name" validate:"required,max=100"`
+	TypeDiscriminatorID uuid.UUID              `json:"typeDiscriminatorId" validate:"required"`
+	Properties          map[string]interface{} `json:"properties" validate:"required"`
 }
 
 type UpdateFieldTypeRequest struct {
-	Name                string                 `json:"name"`
-	TypeDiscriminatorID uuid.UUID              `json:"typeDiscriminatorId"`
-	Properties          map[string]interface{} `json:"properties"`
+	Name                string                 `json:"name" validate:"required,max=100"`
+	TypeDiscriminatorID uuid.UUID              `json:"typeDiscriminatorId" validate:"required"`
+	Properties          map[string]interface{} `json:"properties" validate:"required"`
+}
+
+type DeleteFieldTypeRequest struct {
+	ID       uuid.UUID              `param:"id" validate:"required"`
+	Strategy catalog.DeleteStrategy `query:"strategy" validate:"omitempty,oneof=restrict cascade"`
+}
+
+// PatchFieldTypeRequest is a JSON merge patch of a field type. Properties are
+// merged into the current ones member by member.
+type PatchFieldTypeRequest struct {
+	Name                patch.Field[string]                 `json:"name" validate:"omitempty,max=100"`
+	TypeDiscriminatorID patch.Field[uuid.UUID]              `json:"typeDiscriminatorId"`
+	Properties          patch.Field[map[string]interface{}] `json:"properties"`
 }
 
 // Field Type Discriminator request/response types
 type CreateFieldTypeDiscriminatorRequest struct {
-	Name             string                 `json:"name"`
+	Name             string                 `json:"name" validate:"required,max=50"`
 	Description      string                 `json:"description"`
-	ValidationSchema map[string]interface{} `json:"validationSchema"`
+	ValidationSchema map[string]interface{} `json:"validationSchema" validate:"required"`
 }
 
 type AssignFieldRequest struct {
 	IsRequired   bool  `json:"isRequired"`
-	DisplayOrder int32 `json:"displayOrder"`
+	DisplayOrder int32 `json:"displayOrder" validate:"gte=0"`
 }
 
 type UpdateFieldTypeDiscriminatorRequest struct {
-	Name             string                 `json:"name"`
+	Name             string                 `json:"name" validate:"required,max=50"`
 	Description      string                 `json:"description"`
-	ValidationSchema map[string]interface{} `json:"validationSchema"`
+	ValidationSchema map[string]interface{} `json:"validationSchema" validate:"required"`
 }
 
 // Request/Response types
@@ -64,9 +120,35 @@ type GetCategoryFieldsRequest struct {
 	CategoryID uuid.UUID `param:"categoryId"`
 }
 
+type GetEffectiveCategoryFieldsRequest struct {
+	CategoryID    uuid.UUID `param:"categoryId"`
+	IncludeHidden bool      `query:"includeHidden"`
+}
+
 type AssignFieldToCategoryRequest struct {
 	CategoryID   uuid.UUID `param:"categoryId"`
 	FieldID      uuid.UUID `param:"fieldId"`
 	IsRequired   bool      `json:"isRequired"`
-	DisplayOrder int32     `json:"displayOrder"`
+	DisplayOrder int32     `json:"displayOrder" validate:"gte=0"`
+	IsHidden     bool      `json:"isHidden"`
+}
+
+type UpdateCategoryFieldRequest struct {
+	CategoryID   uuid.UUID `param:"categoryId"`
+	FieldID      uuid.UUID `param:"fieldId"`
+	IsRequired   bool      `json:"isRequired"`
+	DisplayOrder int32     `json:"displayOrder" validate:"gte=0"`
+	IsHidden     bool      `json:"isHidden"`
+}
+
+type UnassignFieldFromCategoryRequest struct {
+	CategoryID uuid.UUID `param:"categoryId"`
+	FieldID    uuid.UUID `param:"fieldId"`
+}
+
+// ReorderCategoryFieldsRequest lists every field assigned to the category in
+// the order they are to be displayed
+type ReorderCategoryFieldsRequest struct {
+	CategoryID uuid.UUID   `param:"categoryId"`
+	FieldIDs   []uuid.UUID `json:"fieldIds" validate:"required"`
 }
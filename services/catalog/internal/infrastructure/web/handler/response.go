@@ -29,6 +29,26 @@ func toCategoryResponse(category *catalog.Category) CategoryResponse {
 	}
 }
 
+// CategoryTreeNode Response
+type CategoryTreeNodeResponse struct {
+	CategoryResponse
+	Depth    int32                      `json:"depth"`
+	Children []CategoryTreeNodeResponse `json:"children"`
+}
+
+func toCategoryTreeNodeResponse(node *catalog.CategoryNode) CategoryTreeNodeResponse {
+	children := make([]CategoryTreeNodeResponse, 0, len(node.Children))
+	for _, child := range node.Children {
+		children = append(children, toCategoryTreeNodeResponse(child))
+	}
+
+	return CategoryTreeNodeResponse{
+		CategoryResponse: toCategoryResponse(&node.Category),
+		Depth:            node.Depth,
+		Children:         children,
+	}
+}
+
 // Field Response
 type FieldResponse struct {
 	ID          uuid.UUID `json:"id"`
@@ -95,6 +115,7 @@ type CategoryFieldResponse struct {
 	Field        FieldResponse `json:"field"`
 	IsRequired   bool          `json:"isRequired"`
 	DisplayOrder int32         `json:"displayOrder"`
+	IsHidden     bool          `json:"isHidden"`
 }
 
 func toCategoryFieldResponse(fieldInfo *catalog.CategoryFieldInfo) CategoryFieldResponse {
@@ -102,5 +123,25 @@ func toCategoryFieldResponse(fieldInfo *catalog.CategoryFieldInfo) CategoryField
 		Field:        toFieldResponse(fieldInfo.Field),
 		IsRequired:   fieldInfo.IsRequired,
 		DisplayOrder: fieldInfo.DisplayOrder,
+		IsHidden:     fieldInfo.IsHidden,
+	}
+}
+
+// EffectiveCategoryField Response
+type EffectiveCategoryFieldResponse struct {
+	CategoryFieldResponse
+	SourceCategoryID uuid.UUID `json:"sourceCategoryId"`
+	OriginCategoryID uuid.UUID `json:"originCategoryId"`
+	Inherited        bool      `json:"inherited"`
+	Overridden       bool      `json:"overridden"`
+}
+
+func toEffectiveCategoryFieldResponse(field *catalog.EffectiveCategoryField) EffectiveCategoryFieldResponse {
+	return EffectiveCategoryFieldResponse{
+		CategoryFieldResponse: toCategoryFieldResponse(&field.CategoryFieldInfo),
+		SourceCategoryID:      field.SourceCategoryID,
+		OriginCategoryID:      field.OriginCategoryID,
+		Inherited:             field.Inherited,
+		Overridden:            field.Overridden,
 	}
 }
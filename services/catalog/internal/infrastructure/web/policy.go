@@ -0,0 +1,21 @@
+package web
+
+import (
+	"localloop/libs/pkg/web/middleware"
+
+	"github.com/gorilla/mux"
+)
+
+// Roles issued by the user service that the catalog cares about
+const (
+	roleAdmin = "admin"
+)
+
+// schemaAdminRouter returns a subrouter for the routes that mutate the
+// catalog schema: categories, fields, field types and discriminators. They
+// are restricted to admins, while reads stay public.
+func (s *CatalogManagementServer) schemaAdminRouter(router *mux.Router) *mux.Router {
+	admin := router.Methods("POST", "PUT", "PATCH", "DELETE").Subrouter()
+	admin.Use(s.authenticator.Authenticate, middleware.RequireRole(roleAdmin))
+	return admin
+}
@@ -3,6 +3,7 @@ package web
 import (
 	"localloop/libs/pkg/web"
 	bh "localloop/libs/pkg/web/handler"
+	"localloop/libs/pkg/web/middleware"
 	catalog "localloop/services/catalog/internal/domain"
 	h "localloop/services/catalog/internal/infrastructure/web/handler"
 )
@@ -10,12 +11,14 @@ import (
 type CatalogManagementServer struct {
 	*web.Server    // Embedding the shared Server struct
 	catalogService *catalog.Service
+	authenticator  *middleware.Authenticator
 }
 
-func NewCatalogManagementServer(catalogService *catalog.Service) *CatalogManagementServer {
+func NewCatalogManagementServer(catalogService *catalog.Service, authenticator *middleware.Authenticator) *CatalogManagementServer {
 	server := &CatalogManagementServer{
 		Server:         web.NewServer(),
 		catalogService: catalogService,
+		authenticator:  authenticator,
 	}
 
 	server.setupRoutes()
@@ -26,37 +29,55 @@ func (s *CatalogManagementServer) setupRoutes() {
 	router := s.Router
 	ch := h.NewCatalogHandler(s.catalogService)
 
+	// Reads are public, schema changes are reserved to admins
+	admin := s.schemaAdminRouter(router)
+
 	// Category routes
 	router.HandleFunc("/categories", bh.HandleRequest(ch.Category.List)).Methods("GET")
-	router.HandleFunc("/categories", bh.HandleRequest(ch.Category.Create)).Methods("POST")
+	router.HandleFunc("/categories/tree", bh.HandleRequest(ch.GetCategoryTree)).Methods("GET")
 	router.HandleFunc("/categories/{id}", bh.HandleRequest(ch.Category.Get)).Methods("GET")
-	router.HandleFunc("/categories/{id}", bh.HandleRequest(ch.Category.Update)).Methods("PUT")
-	router.HandleFunc("/categories/{id}", bh.HandleRequest(ch.Category.Delete)).Methods("DELETE")
+	router.HandleFunc("/categories/{id}/subtree", bh.HandleRequest(ch.GetCategorySubtree)).Methods("GET")
+	router.HandleFunc("/categories/{id}/ancestors", bh.HandleRequest(ch.GetCategoryAncestors)).Methods("GET")
+	admin.HandleFunc("/categories", bh.HandleRequest(ch.Category.Create)).Methods("POST")
+	admin.HandleFunc("/categories/{id}", bh.HandleRequest(ch.Category.Update)).Methods("PUT")
+	admin.HandleFunc("/categories/{id}", bh.HandleRequest(ch.CategoryPatch.Patch)).Methods("PATCH")
+	admin.HandleFunc("/categories/{id}", bh.HandleRequest(ch.DeleteCategory)).Methods("DELETE")
+	admin.HandleFunc("/categories/{id}/parent", bh.HandleRequest(ch.MoveCategory)).Methods("PUT")
 
 	// Field routes
 	router.HandleFunc("/fields", bh.HandleRequest(ch.Field.List)).Methods("GET")
-	router.HandleFunc("/fields", bh.HandleRequest(ch.Field.Create)).Methods("POST")
 	router.HandleFunc("/fields/{id}", bh.HandleRequest(ch.Field.Get)).Methods("GET")
-	router.HandleFunc("/fields/{id}", bh.HandleRequest(ch.Field.Update)).Methods("PUT")
-	router.HandleFunc("/fields/{id}", bh.HandleRequest(ch.Field.Delete)).Methods("DELETE")
+	admin.HandleFunc("/fields", bh.HandleRequest(ch.Field.Create)).Methods("POST")
+	admin.HandleFunc("/fields/{id}", bh.HandleRequest(ch.Field.Update)).Methods("PUT")
+	admin.HandleFunc("/fields/{id}", bh.HandleRequest(ch.FieldPatch.Patch)).Methods("PATCH")
+	admin.HandleFunc("/fields/{id}", bh.HandleRequest(ch.DeleteField)).Methods("DELETE")
 
 	// Category-Field assignment
 	router.HandleFunc("/categories/{categoryId}/fields",
 		bh.HandleRequest(ch.GetCategoryFields)).Methods("GET")
-	router.HandleFunc("/categories/{categoryId}/fields/{fieldId}",
+	router.HandleFunc("/categories/{categoryId}/fields/effective",
+		bh.HandleRequest(ch.GetEffectiveCategoryFields)).Methods("GET")
+	admin.HandleFunc("/categories/{categoryId}/fields/order",
+		bh.HandleRequest(ch.ReorderCategoryFields)).Methods("PUT")
+	admin.HandleFunc("/categories/{categoryId}/fields/{fieldId}",
 		bh.HandleRequest(ch.AssignFieldToCategory)).Methods("POST")
+	admin.HandleFunc("/categories/{categoryId}/fields/{fieldId}",
+		bh.HandleRequest(ch.UpdateCategoryField)).Methods("PUT")
+	admin.HandleFunc("/categories/{categoryId}/fields/{fieldId}",
+		bh.HandleRequest(ch.UnassignFieldFromCategory)).Methods("DELETE")
 
 	// Field Type routes
 	router.HandleFunc("/field-types", bh.HandleRequest(ch.FieldType.List)).Methods("GET")
-	router.HandleFunc("/field-types", bh.HandleRequest(ch.FieldType.Create)).Methods("POST")
 	router.HandleFunc("/field-types/{id}", bh.HandleRequest(ch.FieldType.Get)).Methods("GET")
-	router.HandleFunc("/field-types/{id}", bh.HandleRequest(ch.FieldType.Update)).Methods("PUT")
-	router.HandleFunc("/field-types/{id}", bh.HandleRequest(ch.FieldType.Delete)).Methods("DELETE")
+	admin.HandleFunc("/field-types", bh.HandleRequest(ch.FieldType.Create)).Methods("POST")
+	admin.HandleFunc("/field-types/{id}", bh.HandleRequest(ch.FieldType.Update)).Methods("PUT")
+	admin.HandleFunc("/field-types/{id}", bh.HandleRequest(ch.FieldTypePatch.Patch)).Methods("PATCH")
+	admin.HandleFunc("/field-types/{id}", bh.HandleRequest(ch.DeleteFieldType)).Methods("DELETE")
 
 	// Field Type Discriminator routes
 	router.HandleFunc("/field-type-discriminators", bh.HandleRequest(ch.Discriminator.List)).Methods("GET")
-	router.HandleFunc("/field-type-discriminators", bh.HandleRequest(ch.Discriminator.Create)).Methods("POST")
 	router.HandleFunc("/field-type-discriminators/{id}", bh.HandleRequest(ch.Discriminator.Get)).Methods("GET")
-	router.HandleFunc("/field-type-discriminators/{id}", bh.HandleRequest(ch.Discriminator.Update)).Methods("PUT")
-	router.HandleFunc("/field-type-discriminators/{id}", bh.HandleRequest(ch.Discriminator.Delete)).Methods("DELETE")
+	admin.HandleFunc("/field-type-discriminators", bh.HandleRequest(ch.Discriminator.Create)).Methods("POST")
+	admin.HandleFunc("/field-type-discriminators/{id}", bh.HandleRequest(ch.Discriminator.Update)).Methods("PUT")
+	admin.HandleFunc("/field-type-discriminators/{id}", bh.HandleRequest(ch.Discriminator.Delete)).Methods("DELETE")
 }
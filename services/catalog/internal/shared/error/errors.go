@@ -10,17 +10,26 @@ var (
 	ErrFieldNotFound         = errorbuilder.NewError("field not found", errorbuilder.ErrNotFound)
 	ErrFieldTypeNotFound     = errorbuilder.NewError("field type not found", errorbuilder.ErrNotFound)
 	ErrDiscriminatorNotFound = errorbuilder.NewError("field type discriminator not found", errorbuilder.ErrNotFound)
+	ErrFieldNotAssigned      = errorbuilder.NewError("field is not assigned to category", errorbuilder.ErrNotFound)
 
 	// Validation errors
 	ErrInvalidCategoryName = errorbuilder.NewError("invalid category name", errorbuilder.ErrValidation)
+	ErrInvalidParent       = errorbuilder.NewError("invalid parent category", errorbuilder.ErrValidation)
 	ErrInvalidFieldName    = errorbuilder.NewError("invalid field name", errorbuilder.ErrValidation)
 	ErrInvalidFieldType    = errorbuilder.NewError("invalid field type", errorbuilder.ErrValidation)
 	ErrInvalidProperties   = errorbuilder.NewError("invalid properties", errorbuilder.ErrValidation)
+	ErrInvalidSchema       = errorbuilder.NewError("invalid validation schema", errorbuilder.ErrValidation)
+	ErrInvalidStrategy     = errorbuilder.NewError("invalid delete strategy", errorbuilder.ErrValidation)
+	ErrInvalidFieldOrder   = errorbuilder.NewError("invalid field order", errorbuilder.ErrValidation)
 
 	// Conflict errors
 	ErrCategoryExists  = errorbuilder.NewError("category already exists", errorbuilder.ErrConflict)
 	ErrFieldExists     = errorbuilder.NewError("field already exists", errorbuilder.ErrConflict)
 	ErrFieldTypeExists = errorbuilder.NewError("field type already exists", errorbuilder.ErrConflict)
+	ErrFieldAssigned   = errorbuilder.NewError("field is already assigned to category", errorbuilder.ErrConflict)
+	ErrCategoryInUse   = errorbuilder.NewError("category has dependents", errorbuilder.ErrConflict)
+	ErrFieldInUse      = errorbuilder.NewError("field is assigned to categories", errorbuilder.ErrConflict)
+	ErrFieldTypeInUse  = errorbuilder.NewError("field type has fields", errorbuilder.ErrConflict)
 
 	// Internal errors
 	ErrDatabaseOperation = errorbuilder.NewError("database operation failed", errorbuilder.ErrInternal)
@@ -45,3 +54,18 @@ func WithValidation(field, reason string) errorbuilder.ErrorOption {
 		"reason": reason,
 	})
 }
+
+func WithViolations(violations any) errorbuilder.ErrorOption {
+	return errorbuilder.WithContext(map[string]any{
+		"violations": violations,
+	})
+}
+
+// WithDependents lists the entities that keep an entity from being deleted,
+// by kind, and the strategies that would delete it anyway
+func WithDependents(dependents map[string][]string, strategies ...string) errorbuilder.ErrorOption {
+	return errorbuilder.WithContext(map[string]any{
+		"dependents": dependents,
+		"strategies": strategies,
+	})
+}
@@ -2,6 +2,7 @@ package main
 
 import (
 	"log"
+	"os"
 
 	app "localloop/services/catalog/internal"
 	"localloop/services/catalog/internal/config"
@@ -10,11 +11,20 @@ import (
 func main() {
 	cfg := config.Load()
 
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		if err := app.Migrate(cfg, os.Args[2:]); err != nil {
+			log.Fatal("Migration failed:", err)
+		}
+		return
+	}
+
 	catalogApp, err := app.NewApp(
 		cfg,
 		app.WithPostgresDatabase(),
+		app.WithMigrations(),
 		app.WithPostgresCatalogRepository(),
 		app.WithCatalogService(),
+		app.WithAuthenticator(),
 		app.WithWebServer(),
 	)
 
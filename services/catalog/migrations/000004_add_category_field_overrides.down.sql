@@ -0,0 +1 @@
+ALTER TABLE category_fields DROP COLUMN IF EXISTS is_hidden;
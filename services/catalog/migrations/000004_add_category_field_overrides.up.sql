@@ -0,0 +1,3 @@
+-- A category_fields row on a descendant category overrides the assignment
+-- inherited from its ancestors; is_hidden removes the field altogether.
+ALTER TABLE category_fields ADD COLUMN is_hidden BOOLEAN NOT NULL DEFAULT false;
@@ -1,3 +1,5 @@
 module localloop/services/listing
 
-go 1.23.2 
\ No newline at end of file
+go 1.23.2
+
+require github.com/google/uuid v1.6.0
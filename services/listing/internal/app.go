@@ -0,0 +1,289 @@
+package app
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"localloop/libs/pkg/db"
+	"localloop/libs/pkg/jwks"
+	"localloop/libs/pkg/scheduler"
+	"localloop/libs/pkg/web/middleware"
+	"localloop/services/listing/internal/config"
+	listing "localloop/services/listing/internal/domain"
+	"localloop/services/listing/internal/infrastructure/catalog"
+	"localloop/services/listing/internal/infrastructure/repository/postgresql"
+	"localloop/services/listing/internal/infrastructure/storage"
+	"localloop/services/listing/internal/infrastructure/web"
+	"localloop/services/listing/migrations"
+	"localloop/services/listing/seeds"
+)
+
+type App struct {
+	config         *config.Config
+	PostgresDB     *sql.DB
+	ListingRepo    listing.Repository
+	CatalogClient  listing.CatalogClient
+	MediaStorage   listing.MediaStorage
+	ListingService *listing.Service
+	Authenticator  *middleware.Authenticator
+	Server         *web.ListingManagementServer
+	Scheduler      *scheduler.Scheduler
+}
+
+type Option func(*App) error
+
+func NewApp(cfg *config.Config, opts ...Option) (*App, error) {
+	app := &App{
+		config: cfg,
+	}
+
+	for _, opt := range opts {
+		if err := opt(app); err != nil {
+			return nil, err
+		}
+	}
+
+	return app, nil
+}
+
+func WithPostgresDatabase() Option {
+	return func(app *App) error {
+		log.Println("Connecting to PostgreSQL:", app.config.PostgresURI)
+		db, err := db.ConnectPostgreSQL(app.config.PostgresURI)
+		if err != nil {
+			log.Fatal("Failed to connect to PostgreSQL:", err)
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		err = db.PingContext(ctx)
+		if err != nil {
+			log.Fatal("Failed to verify PostgreSQL connection:", err)
+		}
+
+		app.PostgresDB = db
+
+		log.Println("Connected to PostgreSQL successfully")
+		return nil
+	}
+}
+
+// WithMigrations applies the pending schema migrations when the config asks
+// for it, see Migrate for running them by hand
+func WithMigrations() Option {
+	return func(app *App) error {
+		if !app.config.MigrateOnStart {
+			return nil
+		}
+		if app.PostgresDB == nil {
+			return errors.New("PostgresDB is not initialized. Make sure to call WithPostgresDatabase first")
+		}
+
+		migrator, err := db.NewMigrator(app.PostgresDB, migrations.FS)
+		if err != nil {
+			return err
+		}
+
+		log.Println("Applying schema migrations")
+		return migrator.Up(context.Background())
+	}
+}
+
+// Migrate runs the arguments of the migrate subcommand, see
+// db.RunMigrateCommand
+func Migrate(cfg *config.Config, args []string) error {
+	app, err := NewApp(cfg, WithPostgresDatabase())
+	if err != nil {
+		return err
+	}
+	defer app.PostgresDB.Close()
+
+	migrator, err := db.NewMigrator(app.PostgresDB, migrations.FS)
+	if err != nil {
+		return err
+	}
+	return db.RunMigrateCommand(context.Background(), migrator, args, os.Stdout)
+}
+
+func WithPostgresListingRepository() Option {
+	return func(app *App) error {
+		if app.PostgresDB == nil {
+			return errors.New("PostgresDB is not initialized. Make sure to call WithPostgresDatabase first")
+		}
+
+		app.ListingRepo = postgresql.NewListingRepository(app.PostgresDB)
+		return nil
+	}
+}
+
+func WithCatalogClient() Option {
+	return func(app *App) error {
+		app.CatalogClient = catalog.NewClient(app.config.CatalogServiceURL)
+		return nil
+	}
+}
+
+// WithMediaStorage sets up the storage backend of listing media, selected by
+// the config
+func WithMediaStorage() Option {
+	return func(app *App) error {
+		switch app.config.MediaStorage {
+		case "fs":
+			fs, err := storage.NewFileSystem(app.config.MediaDir, app.config.MediaBaseURL)
+			if err != nil {
+				return err
+			}
+			app.MediaStorage = fs
+		case "s3":
+			app.MediaStorage = storage.NewS3(storage.S3Config{
+				Endpoint:  app.config.S3Endpoint,
+				Region:    app.config.S3Region,
+				Bucket:    app.config.S3Bucket,
+				AccessKey: app.config.S3AccessKey,
+				SecretKey: app.config.S3SecretKey,
+				PublicURL: app.config.S3PublicURL,
+			})
+		default:
+			return fmt.Errorf("unknown media storage %q, expected fs or s3", app.config.MediaStorage)
+		}
+		return nil
+	}
+}
+
+func WithListingService() Option {
+	return func(app *App) error {
+		if app.ListingRepo == nil {
+			return errors.New("ListingRepo is not initialized. Make sure to call WithPostgresListingRepository first")
+		}
+		if app.CatalogClient == nil {
+			return errors.New("CatalogClient is not initialized. Make sure to call WithCatalogClient first")
+		}
+		if app.MediaStorage == nil {
+			return errors.New("MediaStorage is not initialized. Make sure to call WithMediaStorage first")
+		}
+
+		app.ListingService = listing.NewService(app.ListingRepo, app.CatalogClient, app.MediaStorage, listing.ServiceConfig{
+			ListingTTL:         app.config.ListingTTL,
+			MaxMediaSize:       app.config.MaxMediaSize,
+			MaxMediaPerListing: app.config.MaxMediaPerListing,
+		})
+		return nil
+	}
+}
+
+// WithReferenceData seeds the reference tables when the config asks for it,
+// see Seed for seeding them by hand
+func WithReferenceData() Option {
+	return func(app *App) error {
+		if !app.config.SeedOnStart {
+			return nil
+		}
+		if app.ListingService == nil {
+			return errors.New("ListingService is not initialized. Make sure to call WithListingService first")
+		}
+
+		log.Println("Seeding reference data")
+		return seedReferenceData(app.ListingService)
+	}
+}
+
+// Seed upserts the reference data fixtures, see seeds.ReferenceData
+func Seed(cfg *config.Config) error {
+	app, err := NewApp(
+		cfg,
+		WithPostgresDatabase(),
+		WithPostgresListingRepository(),
+		WithCatalogClient(),
+		WithMediaStorage(),
+		WithListingService(),
+	)
+	if err != nil {
+		return err
+	}
+	defer app.PostgresDB.Close()
+
+	return seedReferenceData(app.ListingService)
+}
+
+func seedReferenceData(service *listing.Service) error {
+	items, err := seeds.ReferenceData()
+	if err != nil {
+		return err
+	}
+	return service.SeedReferenceData(context.Background(), items)
+}
+
+// WithAuthenticator sets up bearer token verification, either against the
+// keys published by the user service or a shared secret
+func WithAuthenticator() Option {
+	return func(app *App) error {
+		if app.config.AuthJWTSecret != "" {
+			app.Authenticator = middleware.NewSecretAuthenticator([]byte(app.config.AuthJWTSecret))
+			return nil
+		}
+
+		app.Authenticator = middleware.NewJWKSAuthenticator(jwks.NewClient(app.config.AuthJWKSURL))
+		return nil
+	}
+}
+
+func WithWebServer() Option {
+	return func(app *App) error {
+		if app.ListingService == nil {
+			return errors.New("ListingService is not initialized. Make sure to call WithListingService first")
+		}
+
+		if app.Authenticator == nil {
+			return errors.New("Authenticator is not initialized. Make sure to call WithAuthenticator first")
+		}
+
+		app.Server = web.NewListingManagementServer(app.ListingService, app.Authenticator)
+		return nil
+	}
+}
+
+// WithScheduler starts the background jobs of the service and stops them
+// when the server shuts down. Every run of a job holds an advisory lock, so
+// with several replicas only one of them runs it at a time.
+func WithScheduler() Option {
+	return func(app *App) error {
+		if !app.config.SchedulerEnabled {
+			return nil
+		}
+		if app.ListingService == nil {
+			return errors.New("ListingService is not initialized. Make sure to call WithListingService first")
+		}
+		if app.Server == nil {
+			return errors.New("Server is not initialized. Make sure to call WithWebServer first")
+		}
+
+		jobs := []scheduler.Job{
+			{
+				Name:     "expire-listings",
+				Interval: app.config.ExpiryInterval,
+				Run: func(ctx context.Context) error {
+					expired, err := app.ListingService.ExpireListings(ctx)
+					if expired > 0 {
+						log.Printf("Expired %d listings\n", expired)
+					}
+					return err
+				},
+			},
+		}
+
+		app.Scheduler = scheduler.New(jobs, scheduler.WithLock(
+			func(ctx context.Context, name string, fn func(ctx context.Context) error) (bool, error) {
+				return db.TryAdvisoryLock(ctx, app.PostgresDB, name, fn)
+			},
+		))
+		app.Scheduler.Start(context.Background())
+		app.Server.OnShutdown(app.Scheduler.Stop)
+		return nil
+	}
+}
@@ -0,0 +1,18 @@
+package listing
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// CatalogClient gives the listing domain read access to the catalog service.
+type CatalogClient interface {
+	// GetCategoryFieldDefinitions returns the field schema of a category.
+	// It returns apperror.ErrCategoryNotFound if the category does not exist.
+	GetCategoryFieldDefinitions(ctx context.Context, categoryID uuid.UUID) ([]*CategoryFieldDefinition, error)
+
+	// GetDescendantCategoryIDs returns the IDs of all categories below the
+	// given one, excluding the category itself.
+	GetDescendantCategoryIDs(ctx context.Context, categoryID uuid.UUID) ([]uuid.UUID, error)
+}
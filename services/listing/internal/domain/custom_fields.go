@@ -0,0 +1,208 @@
+package listing
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"localloop/libs/pkg/jsonschema"
+)
+
+// valueDefinition is the name of the subschema of a discriminator's
+// validation schema that describes field values, next to the field type
+// properties the schema itself describes
+const valueDefinition = "value"
+
+// fieldValueTypes are the value schemas of the known discriminators, by
+// lowercase name
+var fieldValueTypes = map[string]map[string]interface{}{
+	"text":        {"type": "string"},
+	"string":      {"type": "string"},
+	"textarea":    {"type": "string"},
+	"number":      {"type": "number"},
+	"decimal":     {"type": "number"},
+	"float":       {"type": "number"},
+	"integer":     {"type": "integer"},
+	"int":         {"type": "integer"},
+	"boolean":     {"type": "boolean"},
+	"bool":        {"type": "boolean"},
+	"checkbox":    {"type": "boolean"},
+	"date":        {"type": "string"},
+	"datetime":    {"type": "string"},
+	"select":      {"type": []interface{}{"string", "number", "boolean"}},
+	"enum":        {"type": []interface{}{"string", "number", "boolean"}},
+	"dropdown":    {"type": []interface{}{"string", "number", "boolean"}},
+	"multiselect": {"type": "array", "items": map[string]interface{}{"type": []interface{}{"string", "number", "boolean"}}},
+}
+
+// valueConstraints are the field type properties that constrain values and
+// the JSON Schema keyword of each. min and max are older names of minimum and
+// maximum, which take precedence.
+var valueConstraints = [][2]string{
+	{"minLength", "minLength"},
+	{"maxLength", "maxLength"},
+	{"pattern", "pattern"},
+	{"min", "minimum"},
+	{"max", "maximum"},
+	{"minimum", "minimum"},
+	{"maximum", "maximum"},
+	{"exclusiveMinimum", "exclusiveMinimum"},
+	{"exclusiveMaximum", "exclusiveMaximum"},
+	{"multipleOf", "multipleOf"},
+	{"minItems", "minItems"},
+	{"maxItems", "maxItems"},
+	{"uniqueItems", "uniqueItems"},
+}
+
+// arrayKeywords constrain multiselect values as a whole, other constraints
+// apply to each selected option
+var arrayKeywords = map[string]bool{
+	"minItems": true, "maxItems": true, "uniqueItems": true,
+}
+
+// timeLayouts are the formats of the date discriminators
+var timeLayouts = map[string]string{
+	"date":     time.DateOnly,
+	"datetime": time.RFC3339,
+}
+
+// validateCustomFields checks listing custom field values against the field
+// definitions of the listing's category. Values are keyed by field ID. The
+// returned map holds one reason per offending key and is empty when all
+// values are valid.
+//
+// Values are validated against a JSON Schema built from the field type: the
+// value type of its discriminator, the constraints of its properties (e.g.
+// maxLength, maximum or options) and the "value" definition of the
+// discriminator's validation schema, if it has one. Fields whose
+// discriminator is neither known nor defines its values are rejected.
+func validateCustomFields(definitions []*CategoryFieldDefinition, values map[string]interface{}) map[string]string {
+	errs := make(map[string]string)
+
+	known := make(map[string]bool, len(definitions))
+	for _, def := range definitions {
+		key := def.FieldID.String()
+		known[key] = true
+
+		value, present := values[key]
+		if !present || value == nil {
+			if def.IsRequired {
+				errs[key] = fmt.Sprintf("%s is required", def.Name)
+			}
+			continue
+		}
+
+		if reason := validateFieldValue(def, value); reason != "" {
+			errs[key] = fmt.Sprintf("%s %s", def.Name, reason)
+		}
+	}
+
+	for key := range values {
+		if !known[key] {
+			errs[key] = "field is not defined for this category"
+		}
+	}
+
+	return errs
+}
+
+func validateFieldValue(def *CategoryFieldDefinition, value interface{}) string {
+	typeName := strings.ToLower(def.TypeName)
+
+	schema, ok := valueSchema(def)
+	if !ok {
+		return fmt.Sprintf("has an unsupported field type %q", def.TypeName)
+	}
+
+	violations, err := jsonschema.Validate(schema, value)
+	if err != nil {
+		return "cannot be validated, its field type is misconfigured"
+	}
+	if len(violations) > 0 {
+		v := violations[0]
+		if v.Path != "/" {
+			return fmt.Sprintf("is invalid at %s: %s", v.Path, v.Message)
+		}
+		return v.Message
+	}
+
+	if layout, ok := timeLayouts[typeName]; ok {
+		if _, err := time.Parse(layout, value.(string)); err != nil {
+			return fmt.Sprintf("must be formatted as %s", layout)
+		}
+	}
+	return ""
+}
+
+// valueSchema builds the JSON Schema values of a field have to conform to
+func valueSchema(def *CategoryFieldDefinition) (map[string]interface{}, bool) {
+	base, known := fieldValueTypes[strings.ToLower(def.TypeName)]
+
+	defs, _ := def.ValidationSchema["$defs"].(map[string]interface{})
+	_, defined := defs[valueDefinition]
+	if !known && !defined {
+		return nil, false
+	}
+
+	schema := copySchema(base)
+	// Options and their constraints apply to the items of multiselect values
+	target := schema
+	if items, ok := schema["items"].(map[string]interface{}); ok {
+		target = copySchema(items)
+		schema["items"] = target
+	}
+
+	for _, constraint := range valueConstraints {
+		property, keyword := constraint[0], constraint[1]
+		value, ok := def.Properties[property]
+		if !ok {
+			continue
+		}
+		if arrayKeywords[keyword] {
+			schema[keyword] = value
+		} else {
+			target[keyword] = value
+		}
+	}
+
+	if options := optionValues(def.Properties); options != nil {
+		target["enum"] = options
+	}
+
+	// The value definition is resolved within the discriminator's schema, so
+	// its references to other definitions keep working
+	if defined {
+		schema["$defs"] = defs
+		schema["allOf"] = []interface{}{
+			map[string]interface{}{"$ref": "#/$defs/" + valueDefinition},
+		}
+	}
+
+	return schema, true
+}
+
+// optionValues returns the allowed values of a select field. Options are
+// either plain values or {"value": ..., "label": ...} objects.
+func optionValues(props map[string]interface{}) []interface{} {
+	options, ok := props["options"].([]interface{})
+	if !ok {
+		return nil
+	}
+
+	values := make([]interface{}, len(options))
+	for i, option := range options {
+		if obj, ok := option.(map[string]interface{}); ok {
+			option = obj["value"]
+		}
+		values[i] = option
+	}
+	return values
+}
+
+func copySchema(schema map[string]interface{}) map[string]interface{} {
+	out := make(map[string]interface{}, len(schema)+2)
+	for keyword, value := range schema {
+		out[keyword] = value
+	}
+	return out
+}
@@ -0,0 +1,112 @@
+package listing
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestValidateCustomFields(t *testing.T) {
+	field := func(typeName string, props map[string]interface{}) *CategoryFieldDefinition {
+		return &CategoryFieldDefinition{
+			FieldID:    uuid.New(),
+			Name:       "Field",
+			TypeName:   typeName,
+			Properties: props,
+		}
+	}
+
+	tests := []struct {
+		name    string
+		def     *CategoryFieldDefinition
+		value   interface{}
+		wantErr string
+	}{
+		{name: "text", def: field("text", nil), value: "hello"},
+		{name: "text of the wrong type", def: field("text", nil), value: 3.0, wantErr: "expected string"},
+		{name: "text too long", def: field("text", map[string]interface{}{"maxLength": 3.0}), value: "hello", wantErr: "at most 3 characters"},
+		{name: "text not matching", def: field("text", map[string]interface{}{"pattern": "^[a-z]+$"}), value: "Hello", wantErr: "must match pattern"},
+		{name: "integer", def: field("integer", nil), value: 4.0},
+		{name: "integer with a fraction", def: field("integer", nil), value: 4.5, wantErr: "expected integer"},
+		{name: "number under min", def: field("number", map[string]interface{}{"min": 10.0}), value: 4.0, wantErr: "must be >= 10"},
+		{name: "minimum over min", def: field("number", map[string]interface{}{"min": 1.0, "minimum": 10.0}), value: 4.0, wantErr: "must be >= 10"},
+		{name: "boolean", def: field("checkbox", nil), value: true},
+		{name: "date", def: field("date", nil), value: "2024-02-29"},
+		{name: "invalid date", def: field("date", nil), value: "2023-02-29", wantErr: "formatted as"},
+		{name: "option", def: field("select", map[string]interface{}{"options": []interface{}{"red", map[string]interface{}{"value": "blue", "label": "Blue"}}}), value: "blue"},
+		{name: "unknown option", def: field("select", map[string]interface{}{"options": []interface{}{"red"}}), value: "green", wantErr: "not one of the allowed values"},
+		{name: "multiselect", def: field("multiselect", map[string]interface{}{"options": []interface{}{"a", "b"}, "maxItems": 2.0}), value: []interface{}{"a", "b"}},
+		{name: "multiselect option", def: field("multiselect", map[string]interface{}{"options": []interface{}{"a", "b"}}), value: []interface{}{"a", "c"}, wantErr: "/1"},
+		{name: "multiselect too many", def: field("multiselect", map[string]interface{}{"maxItems": 1.0}), value: []interface{}{"a", "b"}, wantErr: "at most 1 items"},
+		{name: "unknown discriminator", def: field("color", nil), value: "red", wantErr: "unsupported field type"},
+		{
+			name: "discriminator defining its values",
+			def: &CategoryFieldDefinition{
+				FieldID:  uuid.New(),
+				Name:     "Field",
+				TypeName: "color",
+				ValidationSchema: map[string]interface{}{
+					"$defs": map[string]interface{}{
+						"hex":   map[string]interface{}{"type": "string", "pattern": "^#[0-9a-f]{6}$"},
+						"value": map[string]interface{}{"$ref": "#/$defs/hex"},
+					},
+				},
+			},
+			value:   "red",
+			wantErr: "must match pattern",
+		},
+		{
+			name: "value definition adds to a known discriminator",
+			def: &CategoryFieldDefinition{
+				FieldID:  uuid.New(),
+				Name:     "Field",
+				TypeName: "number",
+				ValidationSchema: map[string]interface{}{
+					"$defs": map[string]interface{}{
+						"value": map[string]interface{}{"multipleOf": 5.0},
+					},
+				},
+			},
+			value:   7.0,
+			wantErr: "multiple of 5",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key := tt.def.FieldID.String()
+			errs := validateCustomFields([]*CategoryFieldDefinition{tt.def}, map[string]interface{}{key: tt.value})
+
+			if tt.wantErr == "" {
+				if len(errs) > 0 {
+					t.Fatalf("validateCustomFields() = %v, want no errors", errs)
+				}
+				return
+			}
+			if !strings.Contains(errs[key], tt.wantErr) {
+				t.Fatalf("validateCustomFields() = %v, want an error containing %q", errs, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateCustomFieldsPresence(t *testing.T) {
+	required := &CategoryFieldDefinition{FieldID: uuid.New(), Name: "Size", TypeName: "text", IsRequired: true}
+	optional := &CategoryFieldDefinition{FieldID: uuid.New(), Name: "Color", TypeName: "text"}
+	unknown := uuid.New().String()
+
+	errs := validateCustomFields([]*CategoryFieldDefinition{required, optional}, map[string]interface{}{
+		unknown: "x",
+	})
+
+	if errs[required.FieldID.String()] != "Size is required" {
+		t.Errorf("required field error = %q", errs[required.FieldID.String()])
+	}
+	if _, ok := errs[optional.FieldID.String()]; ok {
+		t.Errorf("optional field reported: %q", errs[optional.FieldID.String()])
+	}
+	if errs[unknown] != "field is not defined for this category" {
+		t.Errorf("unknown field error = %q", errs[unknown])
+	}
+}
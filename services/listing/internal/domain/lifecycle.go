@@ -0,0 +1,309 @@
+package listing
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"localloop/libs/pkg/errorbuilder"
+	apperror "localloop/services/listing/internal/shared/error"
+
+	"github.com/google/uuid"
+)
+
+// ListingStatus is the name of a listing status, the natural key of the
+// listing_statuses reference table
+type ListingStatus string
+
+const (
+	StatusDraft     ListingStatus = "draft"
+	StatusPublished ListingStatus = "published"
+	StatusPaused    ListingStatus = "paused"
+	StatusReserved  ListingStatus = "reserved"
+	StatusSold      ListingStatus = "sold"
+	StatusExpired   ListingStatus = "expired"
+	StatusArchived  ListingStatus = "archived"
+)
+
+// ListingAction moves a listing from one status to another
+type ListingAction string
+
+const (
+	// ActionCreate records the initial status of a listing, it cannot be
+	// applied to an existing one
+	ActionCreate  ListingAction = "create"
+	ActionPublish ListingAction = "publish"
+	// ActionRenew restarts the expiry period of a listing, putting it back
+	// on the market if it expired
+	ActionRenew   ListingAction = "renew"
+	ActionPause   ListingAction = "pause"
+	ActionReserve ListingAction = "reserve"
+	ActionRelease ListingAction = "release"
+	ActionSell    ListingAction = "sell"
+	// ActionExpire is applied by the expiry job, not by users
+	ActionExpire  ListingAction = "expire"
+	ActionArchive ListingAction = "archive"
+)
+
+type transition struct {
+	from []ListingStatus
+	to   ListingStatus
+}
+
+// transitions is the lifecycle of a listing. Publishing a paused or expired
+// listing puts it back on the market, sold and archived are final.
+var transitions = map[ListingAction]transition{
+	ActionPublish: {from: []ListingStatus{StatusDraft, StatusPaused, StatusExpired}, to: StatusPublished},
+	ActionRenew:   {from: []ListingStatus{StatusPublished, StatusExpired}, to: StatusPublished},
+	ActionPause:   {from: []ListingStatus{StatusPublished}, to: StatusPaused},
+	ActionReserve: {from: []ListingStatus{StatusPublished}, to: StatusReserved},
+	ActionRelease: {from: []ListingStatus{StatusReserved}, to: StatusPublished},
+	ActionSell:    {from: []ListingStatus{StatusPublished, StatusReserved}, to: StatusSold},
+	ActionExpire:  {from: []ListingStatus{StatusPublished, StatusPaused}, to: StatusExpired},
+	ActionArchive: {from: []ListingStatus{StatusDraft, StatusPublished, StatusPaused, StatusReserved, StatusSold, StatusExpired}, to: StatusArchived},
+}
+
+// ListingActions lists the actions that can be applied to existing listings
+var ListingActions = []ListingAction{
+	ActionPublish, ActionRenew, ActionPause, ActionReserve, ActionRelease, ActionSell, ActionExpire, ActionArchive,
+}
+
+// Next returns the status action moves a listing in status s to
+func (s ListingStatus) Next(action ListingAction) (ListingStatus, bool) {
+	t, ok := transitions[action]
+	if !ok {
+		return "", false
+	}
+	for _, from := range t.from {
+		if from == s {
+			return t.to, true
+		}
+	}
+	return "", false
+}
+
+// Actions returns the actions that can be applied to a listing in status s
+func (s ListingStatus) Actions() []ListingAction {
+	actions := []ListingAction{}
+	for _, action := range ListingActions {
+		if _, ok := s.Next(action); ok {
+			actions = append(actions, action)
+		}
+	}
+	return actions
+}
+
+// ListingTransition is a status change in the history of a listing. From is
+// nil for the transition that created it.
+type ListingTransition struct {
+	ID        uuid.UUID
+	ListingID uuid.UUID
+	From      *ListingStatus
+	To        ListingStatus
+	Action    ListingAction
+	Reason    string
+	ChangedBy *uuid.UUID
+	CreatedAt time.Time
+}
+
+// TransitionListingParams applies an action to a listing. Users can only
+// apply actions to the listings they own.
+type TransitionListingParams struct {
+	ID     uuid.UUID     `validate:"required"`
+	Action ListingAction `validate:"required"`
+	Reason string
+	// ChangedBy is the user applying the action, nil for the system
+	ChangedBy *uuid.UUID
+}
+
+// SetListingStatusParams moves a listing to a status. Publish also stamps its
+// publication time, and a positive ExpiresIn sets it to expire that long
+// from now.
+type SetListingStatusParams struct {
+	ID        uuid.UUID
+	StatusID  uuid.UUID
+	Publish   bool
+	ExpiresIn time.Duration
+}
+
+// expireBatchSize is the number of expired listings ExpireListings loads at
+// a time
+const expireBatchSize = 100
+
+// TransitionListing applies an action to a listing and records it in the
+// listing's history. The listing is locked while the transition is checked,
+// so concurrent actions are applied one after the other.
+func (s *Service) TransitionListing(ctx context.Context, params TransitionListingParams) (*Listing, error) {
+	if !isListingAction(params.Action) {
+		return nil, apperror.ErrInvalidTransition(
+			apperror.WithValidation("action", "unknown action "+string(params.Action)),
+		)
+	}
+	if params.Action == ActionExpire && params.ChangedBy != nil {
+		return nil, apperror.ErrInvalidTransition(
+			apperror.WithValidation("action", "listings expire on their own"),
+		)
+	}
+
+	var listing *Listing
+	err := s.withTx(ctx, func(tx *Service) error {
+		from, err := tx.repo.GetListingStatus(ctx, params.ID)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return apperror.ErrListingNotFound(
+					apperror.WithListing(params.ID.String()),
+				)
+			}
+			return err
+		}
+
+		// Users can only move their own listings, the system moves any
+		if params.ChangedBy != nil {
+			current, err := tx.repo.GetListing(ctx, params.ID)
+			if err != nil {
+				return err
+			}
+			if current.CreatedBy != *params.ChangedBy {
+				return apperror.ErrNotListingOwner(
+					apperror.WithListing(params.ID.String()),
+				)
+			}
+		}
+
+		to, ok := from.Next(params.Action)
+		if !ok {
+			return apperror.ErrIllegalTransition(
+				apperror.WithListing(params.ID.String()),
+				errorbuilder.WithContext(map[string]any{
+					"status":         string(from),
+					"action":         string(params.Action),
+					"allowedActions": from.Actions(),
+				}),
+			)
+		}
+
+		// The expiry job loads listings before locking them, so one that was
+		// renewed in between is still published but no longer expired
+		if params.Action == ActionExpire {
+			expired, err := tx.repo.IsListingExpired(ctx, params.ID)
+			if err != nil {
+				return err
+			}
+			if !expired {
+				return apperror.ErrListingNotExpired(
+					apperror.WithListing(params.ID.String()),
+				)
+			}
+		}
+
+		statusID, err := tx.statusID(ctx, to)
+		if err != nil {
+			return err
+		}
+
+		update := SetListingStatusParams{
+			ID:       params.ID,
+			StatusID: statusID,
+			Publish:  params.Action == ActionPublish,
+		}
+		if params.Action == ActionPublish || params.Action == ActionRenew {
+			update.ExpiresIn = tx.cfg.ListingTTL
+		}
+
+		listing, err = tx.repo.SetListingStatus(ctx, update)
+		if err != nil {
+			return err
+		}
+
+		return tx.repo.CreateListingTransition(ctx, &ListingTransition{
+			ID:        uuid.New(),
+			ListingID: params.ID,
+			From:      &from,
+			To:        to,
+			Action:    params.Action,
+			Reason:    params.Reason,
+			ChangedBy: params.ChangedBy,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return listing, nil
+}
+
+// ExpireListings expires the listings whose expiry time has passed and
+// returns how many it expired. Listings that changed status or were renewed
+// since they were loaded are left alone.
+func (s *Service) ExpireListings(ctx context.Context) (int, error) {
+	expired := 0
+	for {
+		ids, err := s.repo.ListExpiredListingIDs(ctx, expireBatchSize)
+		if err != nil {
+			return expired, apperror.ErrDatabaseOperation(
+				errorbuilder.WithOriginal(err),
+			)
+		}
+
+		skipped := 0
+		for _, id := range ids {
+			_, err := s.TransitionListing(ctx, TransitionListingParams{
+				ID:     id,
+				Action: ActionExpire,
+				Reason: "listing expired",
+			})
+			if err != nil {
+				var appErr *errorbuilder.CustomError
+				if errors.As(err, &appErr) && appErr.Code == errorbuilder.ErrConflict {
+					skipped++
+					continue
+				}
+				return expired, err
+			}
+			expired++
+		}
+
+		// A batch of listings that all moved on would be loaded again
+		if len(ids) < expireBatchSize || skipped == len(ids) {
+			return expired, nil
+		}
+	}
+}
+
+// ListListingTransitions returns the history of a listing, oldest first
+func (s *Service) ListListingTransitions(ctx context.Context, id uuid.UUID) ([]*ListingTransition, error) {
+	if _, err := s.GetListing(ctx, id); err != nil {
+		return nil, err
+	}
+
+	history, err := s.repo.ListListingTransitions(ctx, id)
+	if err != nil {
+		return nil, apperror.ErrDatabaseOperation(
+			errorbuilder.WithOriginal(err),
+		)
+	}
+	return history, nil
+}
+
+// statusID resolves a status to the ID of its reference row, which has to be
+// seeded before listings can enter the status
+func (s *Service) statusID(ctx context.Context, status ListingStatus) (uuid.UUID, error) {
+	id, err := s.repo.GetListingStatusID(ctx, status)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return uuid.Nil, apperror.ErrMissingReferenceData(
+				errorbuilder.WithContext(map[string]any{
+					"kind": string(ReferenceStatuses),
+					"key":  string(status),
+				}),
+			)
+		}
+		return uuid.Nil, err
+	}
+	return id, nil
+}
+
+func isListingAction(action ListingAction) bool {
+	_, ok := transitions[action]
+	return ok
+}
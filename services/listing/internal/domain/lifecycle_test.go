@@ -0,0 +1,251 @@
+package listing
+
+import (
+	"context"
+	"database/sql"
+	"reflect"
+	"testing"
+	"time"
+
+	"localloop/libs/pkg/errorbuilder"
+
+	"github.com/google/uuid"
+)
+
+// lifecycleRepository keeps listings and their status history in memory.
+// Methods the tests do not use panic through the embedded nil Repository.
+type lifecycleRepository struct {
+	Repository
+	listings    map[uuid.UUID]*Listing
+	statuses    map[uuid.UUID]ListingStatus
+	expired     map[uuid.UUID]bool
+	updates     []SetListingStatusParams
+	transitions []*ListingTransition
+}
+
+func newLifecycleRepository() *lifecycleRepository {
+	return &lifecycleRepository{
+		listings: make(map[uuid.UUID]*Listing),
+		statuses: make(map[uuid.UUID]ListingStatus),
+		expired:  make(map[uuid.UUID]bool),
+	}
+}
+
+func (r *lifecycleRepository) add(status ListingStatus, owner uuid.UUID) uuid.UUID {
+	id := uuid.New()
+	r.listings[id] = &Listing{ID: id, CreatedBy: owner}
+	r.statuses[id] = status
+	return id
+}
+
+func (r *lifecycleRepository) WithTx(_ context.Context, fn func(repo Repository) error) error {
+	return fn(r)
+}
+
+func (r *lifecycleRepository) GetListing(_ context.Context, id uuid.UUID) (*Listing, error) {
+	listing, ok := r.listings[id]
+	if !ok {
+		return nil, sql.ErrNoRows
+	}
+	return listing, nil
+}
+
+func (r *lifecycleRepository) GetListingStatus(_ context.Context, id uuid.UUID) (ListingStatus, error) {
+	status, ok := r.statuses[id]
+	if !ok {
+		return "", sql.ErrNoRows
+	}
+	return status, nil
+}
+
+// Status IDs are derived from their names
+func (r *lifecycleRepository) GetListingStatusID(_ context.Context, status ListingStatus) (uuid.UUID, error) {
+	return uuid.NewSHA1(uuid.Nil, []byte(status)), nil
+}
+
+func (r *lifecycleRepository) SetListingStatus(_ context.Context, params SetListingStatusParams) (*Listing, error) {
+	r.updates = append(r.updates, params)
+	for _, status := range []ListingStatus{StatusPublished, StatusPaused, StatusReserved, StatusSold, StatusExpired, StatusArchived} {
+		if id, _ := r.GetListingStatusID(context.Background(), status); id == params.StatusID {
+			r.statuses[params.ID] = status
+		}
+	}
+	delete(r.expired, params.ID)
+	return r.listings[params.ID], nil
+}
+
+func (r *lifecycleRepository) CreateListingTransition(_ context.Context, transition *ListingTransition) error {
+	r.transitions = append(r.transitions, transition)
+	return nil
+}
+
+func (r *lifecycleRepository) IsListingExpired(_ context.Context, id uuid.UUID) (bool, error) {
+	return r.expired[id], nil
+}
+
+func (r *lifecycleRepository) ListExpiredListingIDs(_ context.Context, limit int) ([]uuid.UUID, error) {
+	var ids []uuid.UUID
+	for id := range r.expired {
+		if len(ids) == limit {
+			break
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+func TestListingStatusNext(t *testing.T) {
+	tests := []struct {
+		from   ListingStatus
+		action ListingAction
+		want   ListingStatus
+		ok     bool
+	}{
+		{from: StatusDraft, action: ActionPublish, want: StatusPublished, ok: true},
+		{from: StatusExpired, action: ActionRenew, want: StatusPublished, ok: true},
+		{from: StatusPublished, action: ActionReserve, want: StatusReserved, ok: true},
+		{from: StatusReserved, action: ActionRelease, want: StatusPublished, ok: true},
+		{from: StatusReserved, action: ActionSell, want: StatusSold, ok: true},
+		{from: StatusPaused, action: ActionExpire, want: StatusExpired, ok: true},
+		{from: StatusDraft, action: ActionSell},
+		{from: StatusDraft, action: ActionExpire},
+		{from: StatusSold, action: ActionPublish},
+		{from: StatusArchived, action: ActionArchive},
+		{from: StatusPublished, action: ActionCreate},
+	}
+
+	for _, tt := range tests {
+		got, ok := tt.from.Next(tt.action)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("%s.Next(%s) = %q, %v, want %q, %v", tt.from, tt.action, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestListingStatusActions(t *testing.T) {
+	if got := StatusArchived.Actions(); len(got) != 0 {
+		t.Errorf("archived listings allow %v, want no actions", got)
+	}
+
+	want := []ListingAction{ActionPublish, ActionArchive}
+	if got := StatusDraft.Actions(); !reflect.DeepEqual(got, want) {
+		t.Errorf("draft listings allow %v, want %v", got, want)
+	}
+}
+
+func TestTransitionListing(t *testing.T) {
+	owner, other := uuid.New(), uuid.New()
+	ctx := context.Background()
+
+	tests := []struct {
+		name      string
+		status    ListingStatus
+		action    ListingAction
+		changedBy *uuid.UUID
+		expired   bool
+		wantCode  errorbuilder.ErrorCode
+		want      ListingStatus
+	}{
+		{name: "owner publishes", status: StatusDraft, action: ActionPublish, changedBy: &owner, want: StatusPublished},
+		{name: "owner sells", status: StatusReserved, action: ActionSell, changedBy: &owner, want: StatusSold},
+		{name: "owner renews", status: StatusExpired, action: ActionRenew, changedBy: &owner, want: StatusPublished},
+		{name: "someone else", status: StatusDraft, action: ActionPublish, changedBy: &other, wantCode: errorbuilder.ErrForbidden},
+		{name: "illegal transition", status: StatusSold, action: ActionPublish, changedBy: &owner, wantCode: errorbuilder.ErrConflict},
+		{name: "unknown action", status: StatusDraft, action: "teleport", changedBy: &owner, wantCode: errorbuilder.ErrValidation},
+		{name: "users cannot expire listings", status: StatusPublished, action: ActionExpire, changedBy: &owner, wantCode: errorbuilder.ErrValidation},
+		{name: "system expires", status: StatusPublished, action: ActionExpire, expired: true, want: StatusExpired},
+		{name: "system skips renewed listings", status: StatusPublished, action: ActionExpire, wantCode: errorbuilder.ErrConflict},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newLifecycleRepository()
+			id := repo.add(tt.status, owner)
+			repo.expired[id] = tt.expired
+			s := NewService(repo, nil, nil, ServiceConfig{})
+
+			_, err := s.TransitionListing(ctx, TransitionListingParams{ID: id, Action: tt.action, ChangedBy: tt.changedBy})
+			if code := errorCode(err); code != tt.wantCode {
+				t.Fatalf("TransitionListing() error = %v, want code %d", err, tt.wantCode)
+			}
+			if tt.wantCode != 0 {
+				if len(repo.transitions) != 0 || repo.statuses[id] != tt.status {
+					t.Errorf("a failed transition moved the listing to %s", repo.statuses[id])
+				}
+				return
+			}
+
+			if repo.statuses[id] != tt.want {
+				t.Errorf("status = %s, want %s", repo.statuses[id], tt.want)
+			}
+			if len(repo.transitions) != 1 {
+				t.Fatalf("recorded %d transitions, want 1", len(repo.transitions))
+			}
+			recorded := repo.transitions[0]
+			if *recorded.From != tt.status || recorded.To != tt.want || recorded.Action != tt.action || recorded.ChangedBy != tt.changedBy {
+				t.Errorf("recorded %+v", recorded)
+			}
+		})
+	}
+}
+
+func TestTransitionListingSetsExpiry(t *testing.T) {
+	owner := uuid.New()
+	ttl := 48 * time.Hour
+
+	tests := []struct {
+		status      ListingStatus
+		action      ListingAction
+		wantPublish bool
+		wantExpiry  time.Duration
+	}{
+		{status: StatusDraft, action: ActionPublish, wantPublish: true, wantExpiry: ttl},
+		{status: StatusExpired, action: ActionRenew, wantExpiry: ttl},
+		{status: StatusPublished, action: ActionPause},
+	}
+
+	for _, tt := range tests {
+		repo := newLifecycleRepository()
+		id := repo.add(tt.status, owner)
+		s := NewService(repo, nil, nil, ServiceConfig{ListingTTL: ttl})
+
+		if _, err := s.TransitionListing(context.Background(), TransitionListingParams{ID: id, Action: tt.action, ChangedBy: &owner}); err != nil {
+			t.Fatalf("%s error = %v", tt.action, err)
+		}
+		update := repo.updates[0]
+		if update.Publish != tt.wantPublish || update.ExpiresIn != tt.wantExpiry {
+			t.Errorf("%s set publish %v and expiry %v, want %v and %v", tt.action, update.Publish, update.ExpiresIn, tt.wantPublish, tt.wantExpiry)
+		}
+	}
+}
+
+func TestTransitionMissingListing(t *testing.T) {
+	s := NewService(newLifecycleRepository(), nil, nil, ServiceConfig{})
+	_, err := s.TransitionListing(context.Background(), TransitionListingParams{ID: uuid.New(), Action: ActionPublish})
+	if errorCode(err) != errorbuilder.ErrNotFound {
+		t.Errorf("TransitionListing() error = %v, want not found", err)
+	}
+}
+
+func TestExpireListings(t *testing.T) {
+	repo := newLifecycleRepository()
+	for i := 0; i < expireBatchSize+5; i++ {
+		repo.expired[repo.add(StatusPublished, uuid.New())] = true
+	}
+	// Sold since it was found expired
+	repo.expired[repo.add(StatusSold, uuid.New())] = true
+
+	s := NewService(repo, nil, nil, ServiceConfig{})
+	expired, err := s.ExpireListings(context.Background())
+	if err != nil {
+		t.Fatalf("ExpireListings() error = %v", err)
+	}
+	if expired != expireBatchSize+5 {
+		t.Errorf("expired %d listings, want %d", expired, expireBatchSize+5)
+	}
+	for _, transition := range repo.transitions {
+		if transition.Action != ActionExpire || transition.ChangedBy != nil {
+			t.Errorf("recorded %+v, want a system expiry", transition)
+		}
+	}
+}
@@ -0,0 +1,17 @@
+package listing
+
+import "localloop/libs/pkg/pagination"
+
+// Sort fields supported by ListListings
+const (
+	SortByCreatedAt = "createdAt"
+	SortByTitle     = "title"
+)
+
+// ListingListSpec lists the newest listings first. All filters match IDs
+// exactly.
+var ListingListSpec = pagination.Spec{
+	Sorts:      []string{SortByCreatedAt, SortByTitle},
+	Descending: true,
+	Filters:    []string{"categoryId", "statusId", "createdBy"},
+}
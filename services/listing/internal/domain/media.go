@@ -0,0 +1,386 @@
+package listing
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"time"
+
+	"localloop/libs/pkg/errorbuilder"
+	apperror "localloop/services/listing/internal/shared/error"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// DefaultMaxMediaSize is the largest image that can be uploaded, in bytes
+	DefaultMaxMediaSize = 10 << 20
+	// DefaultMaxMediaPerListing is the number of images a listing can have
+	DefaultMaxMediaPerListing = 10
+)
+
+// mediaExtensions are the image types listings accept, by sniffed content
+// type, and the extension they are stored with
+var mediaExtensions = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/gif":  ".gif",
+}
+
+// ErrObjectNotFound is returned by a MediaStorage for keys it does not hold
+var ErrObjectNotFound = errors.New("object not found")
+
+// MediaStorage stores the files of listing media by key
+type MediaStorage interface {
+	Put(ctx context.Context, key, contentType string, data []byte) error
+	Get(ctx context.Context, key string) (io.ReadCloser, string, error)
+	Delete(ctx context.Context, key string) error
+	// URL returns the address clients download the object from
+	URL(key string) string
+}
+
+// ListingMedia is an image of a listing. Images are shown by ascending
+// Position, the first one being the cover.
+type ListingMedia struct {
+	ID           uuid.UUID
+	ListingID    uuid.UUID
+	Position     int32
+	StorageKey   string
+	ThumbnailKey string
+	ContentType  string
+	SizeBytes    int64
+	Width        int32
+	Height       int32
+	CreatedAt    time.Time
+}
+
+// MediaFile is an uploaded file, its content type is sniffed from the data
+type MediaFile struct {
+	Filename string
+	Data     []byte
+}
+
+type AddListingMediaParams struct {
+	ListingID  uuid.UUID `validate:"required"`
+	UploadedBy uuid.UUID `validate:"required"`
+	Files      []MediaFile
+}
+
+type DeleteListingMediaParams struct {
+	ListingID uuid.UUID `validate:"required"`
+	MediaID   uuid.UUID `validate:"required"`
+	DeletedBy uuid.UUID `validate:"required"`
+}
+
+// ReorderListingMediaParams lists every image of the listing in the order
+// they are to be shown
+type ReorderListingMediaParams struct {
+	ListingID   uuid.UUID `validate:"required"`
+	MediaIDs    []uuid.UUID
+	ReorderedBy uuid.UUID `validate:"required"`
+}
+
+// AddListingMedia validates and stores uploaded images together with their
+// thumbnails, and appends them to the images of the listing. Either every
+// file is added or none is.
+func (s *Service) AddListingMedia(ctx context.Context, params AddListingMediaParams) ([]*ListingMedia, error) {
+	if len(params.Files) == 0 {
+		return nil, apperror.ErrInvalidMedia(
+			apperror.WithValidation("files", "at least one file is required"),
+		)
+	}
+
+	if _, err := s.ownedListing(ctx, params.ListingID, params.UploadedBy); err != nil {
+		return nil, err
+	}
+
+	// Fail before storing any file, the count is checked again once the
+	// listing is locked
+	existing, err := s.repo.ListListingMedia(ctx, params.ListingID)
+	if err != nil {
+		return nil, apperror.ErrDatabaseOperation(
+			errorbuilder.WithOriginal(err),
+		)
+	}
+	if err := s.checkMediaCount(existing, len(params.Files)); err != nil {
+		return nil, err
+	}
+
+	var added []*ListingMedia
+	var stored []string
+	cleanup := func() {
+		for _, key := range stored {
+			if err := s.storage.Delete(context.WithoutCancel(ctx), key); err != nil {
+				log.Printf("Failed to delete media object %s: %v\n", key, err)
+			}
+		}
+	}
+
+	for _, file := range params.Files {
+		media, thumbnail, err := s.prepareMedia(params.ListingID, file)
+		if err != nil {
+			cleanup()
+			return nil, err
+		}
+		if err := s.storage.Put(ctx, media.StorageKey, media.ContentType, file.Data); err != nil {
+			cleanup()
+			return nil, apperror.ErrMediaStorage(
+				errorbuilder.WithOriginal(err),
+			)
+		}
+		stored = append(stored, media.StorageKey)
+
+		if err := s.storage.Put(ctx, media.ThumbnailKey, thumbnailContentType, thumbnail); err != nil {
+			cleanup()
+			return nil, apperror.ErrMediaStorage(
+				errorbuilder.WithOriginal(err),
+			)
+		}
+		stored = append(stored, media.ThumbnailKey)
+
+		added = append(added, media)
+	}
+
+	err = s.withTx(ctx, func(tx *Service) error {
+		if err := tx.repo.LockListingForMedia(ctx, params.ListingID); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return apperror.ErrListingNotFound(
+					apperror.WithListing(params.ListingID.String()),
+				)
+			}
+			return err
+		}
+
+		existing, err := tx.repo.ListListingMedia(ctx, params.ListingID)
+		if err != nil {
+			return err
+		}
+		if err := tx.checkMediaCount(existing, len(added)); err != nil {
+			return err
+		}
+
+		position := int32(0)
+		for _, media := range existing {
+			position = max(position, media.Position+1)
+		}
+
+		for _, media := range added {
+			media.Position = position
+			position++
+			if err := tx.repo.CreateListingMedia(ctx, media); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		cleanup()
+		return nil, err
+	}
+
+	return added, nil
+}
+
+// ListListingMedia returns the images of a listing in display order
+func (s *Service) ListListingMedia(ctx context.Context, listingID uuid.UUID) ([]*ListingMedia, error) {
+	if _, err := s.GetListing(ctx, listingID); err != nil {
+		return nil, err
+	}
+
+	media, err := s.repo.ListListingMedia(ctx, listingID)
+	if err != nil {
+		return nil, apperror.ErrDatabaseOperation(
+			errorbuilder.WithOriginal(err),
+		)
+	}
+	return media, nil
+}
+
+// DeleteListingMedia removes an image from a listing and deletes its files
+func (s *Service) DeleteListingMedia(ctx context.Context, params DeleteListingMediaParams) error {
+	if _, err := s.ownedListing(ctx, params.ListingID, params.DeletedBy); err != nil {
+		return err
+	}
+
+	media, err := s.repo.DeleteListingMedia(ctx, params.ListingID, params.MediaID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return apperror.ErrMediaNotFound(
+				apperror.WithListing(params.ListingID.String()),
+				errorbuilder.WithContext(map[string]any{
+					"mediaId": params.MediaID.String(),
+				}),
+			)
+		}
+		return apperror.ErrDatabaseOperation(
+			errorbuilder.WithOriginal(err),
+		)
+	}
+
+	s.deleteMediaObjects(ctx, media)
+	return nil
+}
+
+// ReorderListingMedia sets the display order of the images of a listing
+func (s *Service) ReorderListingMedia(ctx context.Context, params ReorderListingMediaParams) ([]*ListingMedia, error) {
+	if _, err := s.ownedListing(ctx, params.ListingID, params.ReorderedBy); err != nil {
+		return nil, err
+	}
+
+	var reordered []*ListingMedia
+	err := s.withTx(ctx, func(tx *Service) error {
+		media, err := tx.repo.ListListingMedia(ctx, params.ListingID)
+		if err != nil {
+			return err
+		}
+		if err := checkMediaOrder(media, params.MediaIDs); err != nil {
+			return err
+		}
+
+		for i, id := range params.MediaIDs {
+			if err := tx.repo.SetListingMediaPosition(ctx, params.ListingID, id, int32(i)); err != nil {
+				return err
+			}
+		}
+
+		reordered, err = tx.repo.ListListingMedia(ctx, params.ListingID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return reordered, nil
+}
+
+// GetMediaObject opens a stored media file for downloading
+func (s *Service) GetMediaObject(ctx context.Context, key string) (io.ReadCloser, string, error) {
+	body, contentType, err := s.storage.Get(ctx, key)
+	if err != nil {
+		if errors.Is(err, ErrObjectNotFound) {
+			return nil, "", apperror.ErrMediaNotFound(
+				errorbuilder.WithContext(map[string]any{
+					"key": key,
+				}),
+			)
+		}
+		return nil, "", apperror.ErrMediaStorage(
+			errorbuilder.WithOriginal(err),
+		)
+	}
+	return body, contentType, nil
+}
+
+// MediaLimits returns the largest image that can be uploaded and the number
+// of images a listing can have
+func (s *Service) MediaLimits() (int64, int) {
+	return s.cfg.MaxMediaSize, s.cfg.MaxMediaPerListing
+}
+
+// MediaURL returns the address clients download a media file from
+func (s *Service) MediaURL(key string) string {
+	return s.storage.URL(key)
+}
+
+// prepareMedia checks an uploaded file and renders its thumbnail
+func (s *Service) prepareMedia(listingID uuid.UUID, file MediaFile) (*ListingMedia, []byte, error) {
+	if int64(len(file.Data)) > s.cfg.MaxMediaSize {
+		return nil, nil, apperror.ErrInvalidMedia(
+			apperror.WithValidation(file.Filename, fmt.Sprintf("file cannot be larger than %d bytes", s.cfg.MaxMediaSize)),
+		)
+	}
+
+	contentType := http.DetectContentType(file.Data)
+	ext, ok := mediaExtensions[contentType]
+	if !ok {
+		return nil, nil, apperror.ErrInvalidMedia(
+			apperror.WithValidation(file.Filename, "file must be a JPEG, PNG or GIF image, got "+contentType),
+		)
+	}
+
+	thumbnail, width, height, err := renderThumbnail(file.Data)
+	if err != nil {
+		return nil, nil, apperror.ErrInvalidMedia(
+			apperror.WithValidation(file.Filename, fmt.Sprintf("file must be a readable image of at most %d pixels", maxImagePixels)),
+			errorbuilder.WithOriginal(err),
+		)
+	}
+
+	id := uuid.New()
+	prefix := fmt.Sprintf("listings/%s/%s", listingID, id)
+	return &ListingMedia{
+		ID:           id,
+		ListingID:    listingID,
+		StorageKey:   prefix + ext,
+		ThumbnailKey: prefix + "_thumb.jpg",
+		ContentType:  contentType,
+		SizeBytes:    int64(len(file.Data)),
+		Width:        int32(width),
+		Height:       int32(height),
+	}, thumbnail, nil
+}
+
+// checkMediaCount checks that a listing with the existing images has room
+// for count more
+func (s *Service) checkMediaCount(existing []*ListingMedia, count int) error {
+	if len(existing)+count > s.cfg.MaxMediaPerListing {
+		return apperror.ErrInvalidMedia(
+			apperror.WithValidation("files", fmt.Sprintf("a listing cannot have more than %d images", s.cfg.MaxMediaPerListing)),
+		)
+	}
+	return nil
+}
+
+// ownedListing returns a listing that userID owns, and so may change
+func (s *Service) ownedListing(ctx context.Context, listingID, userID uuid.UUID) (*Listing, error) {
+	listing, err := s.GetListing(ctx, listingID)
+	if err != nil {
+		return nil, err
+	}
+	if listing.CreatedBy != userID {
+		return nil, apperror.ErrNotListingOwner(
+			apperror.WithListing(listingID.String()),
+		)
+	}
+	return listing, nil
+}
+
+// deleteMediaObjects removes the files of deleted media. Failures leave
+// orphaned objects behind and are only logged, since the media is gone.
+func (s *Service) deleteMediaObjects(ctx context.Context, media ...*ListingMedia) {
+	ctx = context.WithoutCancel(ctx)
+	for _, m := range media {
+		for _, key := range []string{m.StorageKey, m.ThumbnailKey} {
+			if err := s.storage.Delete(ctx, key); err != nil && !errors.Is(err, ErrObjectNotFound) {
+				log.Printf("Failed to delete media object %s: %v\n", key, err)
+			}
+		}
+	}
+}
+
+func checkMediaOrder(media []*ListingMedia, order []uuid.UUID) error {
+	current := make(map[uuid.UUID]bool, len(media))
+	for _, m := range media {
+		current[m.ID] = true
+	}
+
+	seen := make(map[uuid.UUID]bool, len(order))
+	for _, id := range order {
+		if !current[id] || seen[id] {
+			return apperror.ErrInvalidMediaOrder(
+				apperror.WithValidation("mediaIds", "must list every image of the listing exactly once"),
+			)
+		}
+		seen[id] = true
+	}
+
+	if len(seen) != len(current) {
+		return apperror.ErrInvalidMediaOrder(
+			apperror.WithValidation("mediaIds", "must list every image of the listing exactly once"),
+		)
+	}
+	return nil
+}
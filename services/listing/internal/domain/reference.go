@@ -0,0 +1,154 @@
+package listing
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"time"
+
+	"localloop/libs/pkg/errorbuilder"
+	apperror "localloop/services/listing/internal/shared/error"
+
+	"github.com/google/uuid"
+)
+
+// ReferenceKind names a reference table listings point into
+type ReferenceKind string
+
+const (
+	ReferenceConditions ReferenceKind = "conditions"
+	ReferenceCurrencies ReferenceKind = "currencies"
+	ReferenceStatuses   ReferenceKind = "statuses"
+)
+
+// ReferenceKinds lists every reference table
+var ReferenceKinds = []ReferenceKind{ReferenceConditions, ReferenceCurrencies, ReferenceStatuses}
+
+var currencyCode = regexp.MustCompile(`^[A-Z]{3}$`)
+
+// ReferenceItem is a row of a reference table. Key is its natural key: the
+// name of a condition or listing status and the ISO 4217 code of a currency.
+// DisplayName is the name of a currency.
+type ReferenceItem struct {
+	ID           uuid.UUID
+	Kind         ReferenceKind
+	Key          string
+	DisplayName  string
+	DisplayOrder int32
+	IsActive     bool
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+// UpsertReferenceItemParams creates the item with the given key, or replaces
+// its attributes if it exists
+type UpsertReferenceItemParams struct {
+	Kind         ReferenceKind `validate:"required"`
+	Key          string        `validate:"required"`
+	DisplayName  string        `validate:"required"`
+	DisplayOrder int32         `validate:"gte=0"`
+	IsActive     bool
+}
+
+func (s *Service) ListReferenceItems(ctx context.Context, kind ReferenceKind, includeInactive bool) ([]*ReferenceItem, error) {
+	if err := checkReferenceKind(kind); err != nil {
+		return nil, err
+	}
+
+	items, err := s.repo.ListReferenceItems(ctx, kind, includeInactive)
+	if err != nil {
+		return nil, apperror.ErrDatabaseOperation(
+			errorbuilder.WithOriginal(err),
+		)
+	}
+	return items, nil
+}
+
+// UpsertReferenceItem creates or updates a reference item by its key. Items
+// are deactivated rather than deleted, since listings keep referring to them.
+func (s *Service) UpsertReferenceItem(ctx context.Context, params UpsertReferenceItemParams) (*ReferenceItem, error) {
+	if err := validateReferenceItem(params); err != nil {
+		return nil, err
+	}
+
+	item, err := s.repo.UpsertReferenceItem(ctx, params)
+	if err != nil {
+		return nil, apperror.ErrDatabaseOperation(
+			errorbuilder.WithOriginal(err),
+		)
+	}
+	return item, nil
+}
+
+// SeedReferenceData upserts every item of a fixture set in one transaction.
+// Seeding is idempotent: items are matched by key, so running it again only
+// restores the attributes the fixtures declare.
+func (s *Service) SeedReferenceData(ctx context.Context, items []UpsertReferenceItemParams) error {
+	for _, params := range items {
+		if err := validateReferenceItem(params); err != nil {
+			return err
+		}
+	}
+
+	err := s.repo.WithTx(ctx, func(repo Repository) error {
+		for _, params := range items {
+			if _, err := repo.UpsertReferenceItem(ctx, params); err != nil {
+				return fmt.Errorf("failed to seed %s %s: %w", params.Kind, params.Key, err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return apperror.ErrDatabaseOperation(
+			errorbuilder.WithOriginal(err),
+		)
+	}
+	return nil
+}
+
+func validateReferenceItem(params UpsertReferenceItemParams) error {
+	if err := checkReferenceKind(params.Kind); err != nil {
+		return err
+	}
+
+	if params.Key == "" {
+		return apperror.ErrInvalidReferenceItem(
+			apperror.WithValidation("key", "key cannot be empty"),
+		)
+	}
+	if params.Kind == ReferenceCurrencies && !currencyCode.MatchString(params.Key) {
+		return apperror.ErrInvalidReferenceItem(
+			apperror.WithValidation("key", "currency code must be three upper case letters"),
+		)
+	}
+	if params.Kind != ReferenceCurrencies && len(params.Key) > 50 {
+		return apperror.ErrInvalidReferenceItem(
+			apperror.WithValidation("key", "key cannot be longer than 50 characters"),
+		)
+	}
+
+	if params.DisplayName == "" || len(params.DisplayName) > 50 {
+		return apperror.ErrInvalidReferenceItem(
+			apperror.WithValidation("displayName", "display name must have between 1 and 50 characters"),
+		)
+	}
+	if params.DisplayOrder < 0 {
+		return apperror.ErrInvalidReferenceItem(
+			apperror.WithValidation("displayOrder", "display order cannot be negative"),
+		)
+	}
+	return nil
+}
+
+func checkReferenceKind(kind ReferenceKind) error {
+	for _, known := range ReferenceKinds {
+		if kind == known {
+			return nil
+		}
+	}
+	return apperror.ErrReferenceKindNotFound(
+		errorbuilder.WithContext(map[string]any{
+			"kind": string(kind),
+		}),
+	)
+}
@@ -0,0 +1,94 @@
+package listing
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"localloop/libs/pkg/errorbuilder"
+
+	"github.com/google/uuid"
+)
+
+// referenceRepository keeps reference items by kind and key
+type referenceRepository struct {
+	Repository
+	items map[ReferenceKind]map[string]*ReferenceItem
+}
+
+func (r *referenceRepository) WithTx(_ context.Context, fn func(repo Repository) error) error {
+	return fn(r)
+}
+
+func (r *referenceRepository) UpsertReferenceItem(_ context.Context, params UpsertReferenceItemParams) (*ReferenceItem, error) {
+	if r.items[params.Kind] == nil {
+		r.items[params.Kind] = make(map[string]*ReferenceItem)
+	}
+	item, ok := r.items[params.Kind][params.Key]
+	if !ok {
+		item = &ReferenceItem{ID: uuid.New(), Kind: params.Kind, Key: params.Key}
+		r.items[params.Kind][params.Key] = item
+	}
+	item.DisplayName = params.DisplayName
+	item.DisplayOrder = params.DisplayOrder
+	item.IsActive = params.IsActive
+	return item, nil
+}
+
+func errorCode(err error) errorbuilder.ErrorCode {
+	var appErr *errorbuilder.CustomError
+	if errors.As(err, &appErr) {
+		return appErr.Code
+	}
+	return 0
+}
+
+func TestSeedReferenceData(t *testing.T) {
+	repo := &referenceRepository{items: make(map[ReferenceKind]map[string]*ReferenceItem)}
+	s := NewService(repo, nil, nil, ServiceConfig{})
+	ctx := context.Background()
+
+	items := []UpsertReferenceItemParams{
+		{Kind: ReferenceCurrencies, Key: "EUR", DisplayName: "Euro", IsActive: true},
+		{Kind: ReferenceConditions, Key: "new", DisplayName: "New", DisplayOrder: 1, IsActive: true},
+	}
+	for i := 0; i < 2; i++ {
+		if err := s.SeedReferenceData(ctx, items); err != nil {
+			t.Fatalf("SeedReferenceData() error = %v", err)
+		}
+	}
+	if len(repo.items[ReferenceCurrencies]) != 1 || len(repo.items[ReferenceConditions]) != 1 {
+		t.Errorf("seeding twice stored %v", repo.items)
+	}
+
+	invalid := []UpsertReferenceItemParams{
+		{Kind: ReferenceCurrencies, Key: "EUR", DisplayName: "Changed", IsActive: true},
+		{Kind: ReferenceCurrencies, Key: "euro", DisplayName: "Euro"},
+	}
+	if err := s.SeedReferenceData(ctx, invalid); errorCode(err) != errorbuilder.ErrValidation {
+		t.Fatalf("SeedReferenceData() with an invalid currency error = %v, want a validation error", err)
+	}
+	if repo.items[ReferenceCurrencies]["EUR"].DisplayName != "Euro" {
+		t.Error("an invalid fixture set was partially seeded")
+	}
+}
+
+func TestValidateReferenceItem(t *testing.T) {
+	tests := []struct {
+		params UpsertReferenceItemParams
+		want   errorbuilder.ErrorCode
+	}{
+		{params: UpsertReferenceItemParams{Kind: ReferenceStatuses, Key: "draft", DisplayName: "Draft"}},
+		{params: UpsertReferenceItemParams{Kind: "colours", Key: "red", DisplayName: "Red"}, want: errorbuilder.ErrNotFound},
+		{params: UpsertReferenceItemParams{Kind: ReferenceConditions, DisplayName: "New"}, want: errorbuilder.ErrValidation},
+		{params: UpsertReferenceItemParams{Kind: ReferenceCurrencies, Key: "usd", DisplayName: "US Dollar"}, want: errorbuilder.ErrValidation},
+		{params: UpsertReferenceItemParams{Kind: ReferenceConditions, Key: "new"}, want: errorbuilder.ErrValidation},
+		{params: UpsertReferenceItemParams{Kind: ReferenceConditions, Key: "new", DisplayName: "New", DisplayOrder: -1}, want: errorbuilder.ErrValidation},
+	}
+
+	for _, tt := range tests {
+		if got := errorCode(validateReferenceItem(tt.params)); got != tt.want {
+			t.Errorf("validateReferenceItem(%+v) code = %d, want %d", tt.params, got, tt.want)
+		}
+	}
+}
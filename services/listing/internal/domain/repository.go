@@ -0,0 +1,48 @@
+package listing
+
+import (
+	"context"
+
+	"localloop/libs/pkg/pagination"
+
+	"github.com/google/uuid"
+)
+
+type Repository interface {
+	// Listing operations
+	CreateListing(ctx context.Context, listing *Listing) error
+	GetListing(ctx context.Context, id uuid.UUID) (*Listing, error)
+	UpdateListing(ctx context.Context, listing *Listing) error
+	DeleteListing(ctx context.Context, id uuid.UUID) error
+	ListListings(ctx context.Context, query pagination.Query) (pagination.Page[*Listing], error)
+
+	// Lifecycle operations, GetListingStatus locks the listing until the
+	// transaction ends
+	GetListingStatus(ctx context.Context, id uuid.UUID) (ListingStatus, error)
+	GetListingStatusID(ctx context.Context, status ListingStatus) (uuid.UUID, error)
+	SetListingStatus(ctx context.Context, params SetListingStatusParams) (*Listing, error)
+	CreateListingTransition(ctx context.Context, transition *ListingTransition) error
+	ListListingTransitions(ctx context.Context, listingID uuid.UUID) ([]*ListingTransition, error)
+	IsListingExpired(ctx context.Context, id uuid.UUID) (bool, error)
+	ListExpiredListingIDs(ctx context.Context, limit int) ([]uuid.UUID, error)
+
+	// Media operations, DeleteListingMedia returns the deleted media.
+	// LockListingForMedia locks the listing until the transaction ends, so
+	// its images are added one upload after the other.
+	ListListingMedia(ctx context.Context, listingID uuid.UUID) ([]*ListingMedia, error)
+	LockListingForMedia(ctx context.Context, listingID uuid.UUID) error
+	CreateListingMedia(ctx context.Context, media *ListingMedia) error
+	DeleteListingMedia(ctx context.Context, listingID, mediaID uuid.UUID) (*ListingMedia, error)
+	SetListingMediaPosition(ctx context.Context, listingID, mediaID uuid.UUID, position int32) error
+
+	// Search operations
+	SearchListings(ctx context.Context, criteria SearchCriteria) ([]*Listing, error)
+	CountListingFacets(ctx context.Context, criteria SearchCriteria) (*SearchFacets, error)
+
+	// Reference data operations
+	ListReferenceItems(ctx context.Context, kind ReferenceKind, includeInactive bool) ([]*ReferenceItem, error)
+	UpsertReferenceItem(ctx context.Context, params UpsertReferenceItemParams) (*ReferenceItem, error)
+
+	// Transaction support
+	WithTx(ctx context.Context, fn func(repo Repository) error) error
+}
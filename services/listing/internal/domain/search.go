@@ -0,0 +1,106 @@
+package listing
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	DefaultSearchLimit = 20
+	MaxSearchLimit     = 100
+)
+
+// Custom field predicate operators
+const (
+	OpEq  = "eq"
+	OpGt  = "gt"
+	OpGte = "gte"
+	OpLt  = "lt"
+	OpLte = "lte"
+)
+
+// CustomFieldPredicate filters listings on a single custom field value.
+// Eq matches the JSON value exactly, the other operators compare numerically.
+type CustomFieldPredicate struct {
+	Key      string
+	Operator string
+	Value    interface{}
+}
+
+type SearchParams struct {
+	CategoryID         *uuid.UUID
+	IncludeDescendants bool
+	MinPrice           *float64
+	MaxPrice           *float64
+	ConditionIDs       []uuid.UUID
+	CurrencyIDs        []uuid.UUID
+	StatusIDs          []uuid.UUID
+	CustomFields       []CustomFieldPredicate
+	FacetFields        []string
+	Cursor             string
+	Limit              int
+}
+
+// SearchCriteria is the resolved form of SearchParams handed to the repository
+type SearchCriteria struct {
+	CategoryIDs  []uuid.UUID
+	MinPrice     *float64
+	MaxPrice     *float64
+	ConditionIDs []uuid.UUID
+	CurrencyIDs  []uuid.UUID
+	StatusIDs    []uuid.UUID
+	// StatusDefaulted tells that StatusIDs were not asked for. The default
+	// statuses then narrow the status facet as well.
+	StatusDefaulted bool
+	CustomFields    []CustomFieldPredicate
+	FacetFields     []string
+	After           *SearchCursor
+	Limit           int
+}
+
+type FacetCount struct {
+	Value string
+	Count int64
+}
+
+type SearchFacets struct {
+	Categories   []FacetCount
+	Conditions   []FacetCount
+	Currencies   []FacetCount
+	Statuses     []FacetCount
+	CustomFields map[string][]FacetCount
+}
+
+type SearchResult struct {
+	Listings   []*Listing
+	Facets     *SearchFacets
+	NextCursor string
+}
+
+// SearchCursor marks the position of the last listing of a page in the
+// (created_at DESC, id DESC) ordering used by search.
+type SearchCursor struct {
+	CreatedAt time.Time `json:"t"`
+	ID        uuid.UUID `json:"id"`
+}
+
+func encodeSearchCursor(listing *Listing) string {
+	data, _ := json.Marshal(SearchCursor{CreatedAt: listing.CreatedAt, ID: listing.ID})
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeSearchCursor(cursor string) (*SearchCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	var c SearchCursor
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
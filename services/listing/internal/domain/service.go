@@ -0,0 +1,380 @@
+package listing
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"localloop/libs/pkg/errorbuilder"
+	"localloop/libs/pkg/pagination"
+	apperror "localloop/services/listing/internal/shared/error"
+
+	"github.com/google/uuid"
+)
+
+// DefaultListingTTL is how long published listings stay on the market
+// before they expire
+const DefaultListingTTL = 30 * 24 * time.Hour
+
+type ServiceConfig struct {
+	// ListingTTL is how long a listing stays published after it was
+	// published or renewed, DefaultListingTTL when zero
+	ListingTTL time.Duration
+	// MaxMediaSize and MaxMediaPerListing limit uploaded images, they
+	// default to DefaultMaxMediaSize and DefaultMaxMediaPerListing
+	MaxMediaSize       int64
+	MaxMediaPerListing int
+}
+
+type Service struct {
+	repo    Repository
+	catalog CatalogClient
+	storage MediaStorage
+	cfg     ServiceConfig
+}
+
+func NewService(repo Repository, catalog CatalogClient, storage MediaStorage, cfg ServiceConfig) *Service {
+	if cfg.ListingTTL <= 0 {
+		cfg.ListingTTL = DefaultListingTTL
+	}
+	if cfg.MaxMediaSize <= 0 {
+		cfg.MaxMediaSize = DefaultMaxMediaSize
+	}
+	if cfg.MaxMediaPerListing <= 0 {
+		cfg.MaxMediaPerListing = DefaultMaxMediaPerListing
+	}
+
+	return &Service{
+		repo:    repo,
+		catalog: catalog,
+		storage: storage,
+		cfg:     cfg,
+	}
+}
+
+// withTx runs fn with a service bound to a new transaction. Errors that are
+// not application errors are reported as failed database operations.
+func (s *Service) withTx(ctx context.Context, fn func(tx *Service) error) error {
+	err := s.repo.WithTx(ctx, func(repo Repository) error {
+		return fn(&Service{repo: repo, catalog: s.catalog, storage: s.storage, cfg: s.cfg})
+	})
+
+	var appErr *errorbuilder.CustomError
+	if err != nil && !errors.As(err, &appErr) {
+		return apperror.ErrDatabaseOperation(
+			errorbuilder.WithOriginal(err),
+		)
+	}
+	return err
+}
+
+// Listing operations
+func (s *Service) CreateListing(ctx context.Context, params CreateListingParams) (*Listing, error) {
+	if err := validateListing(params.Title, params.CategoryID, params.Price); err != nil {
+		return nil, err
+	}
+
+	if params.CreatedBy == uuid.Nil {
+		return nil, apperror.ErrInvalidOwner(
+			apperror.WithValidation("createdBy", "owner cannot be empty"),
+		)
+	}
+
+	listing := &Listing{
+		ID:           uuid.New(),
+		Title:        params.Title,
+		Description:  params.Description,
+		CategoryID:   params.CategoryID,
+		Price:        params.Price,
+		CurrencyID:   params.CurrencyID,
+		ConditionID:  params.ConditionID,
+		MediaURL:     params.MediaURL,
+		CustomFields: params.CustomFields,
+		CreatedBy:    params.CreatedBy,
+	}
+
+	if listing.CustomFields == nil {
+		listing.CustomFields = map[string]interface{}{}
+	}
+
+	if err := s.validateCustomFields(ctx, listing.CategoryID, listing.CustomFields); err != nil {
+		return nil, err
+	}
+
+	err := s.withTx(ctx, func(tx *Service) error {
+		statusID, err := tx.statusID(ctx, StatusDraft)
+		if err != nil {
+			return err
+		}
+		listing.StatusID = statusID
+
+		if err := tx.repo.CreateListing(ctx, listing); err != nil {
+			return err
+		}
+
+		return tx.repo.CreateListingTransition(ctx, &ListingTransition{
+			ID:        uuid.New(),
+			ListingID: listing.ID,
+			To:        StatusDraft,
+			Action:    ActionCreate,
+			ChangedBy: &listing.CreatedBy,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return listing, nil
+}
+
+func (s *Service) GetListing(ctx context.Context, id uuid.UUID) (*Listing, error) {
+	listing, err := s.repo.GetListing(ctx, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, apperror.ErrListingNotFound(
+				apperror.WithListing(id.String()),
+			)
+		}
+		return nil, apperror.ErrDatabaseOperation(
+			errorbuilder.WithOriginal(err),
+		)
+	}
+	return listing, nil
+}
+
+// UpdateListing replaces the attributes of a listing, its status is kept
+func (s *Service) UpdateListing(ctx context.Context, params UpdateListingParams) (*Listing, error) {
+	if err := validateListing(params.Title, params.CategoryID, params.Price); err != nil {
+		return nil, err
+	}
+
+	existing, err := s.ownedListing(ctx, params.ID, params.UpdatedBy)
+	if err != nil {
+		return nil, err
+	}
+
+	listing := &Listing{
+		ID:           params.ID,
+		Title:        params.Title,
+		Description:  params.Description,
+		CategoryID:   params.CategoryID,
+		Price:        params.Price,
+		CurrencyID:   params.CurrencyID,
+		ConditionID:  params.ConditionID,
+		StatusID:     existing.StatusID,
+		MediaURL:     params.MediaURL,
+		CustomFields: params.CustomFields,
+		CreatedBy:    existing.CreatedBy,
+		CreatedAt:    existing.CreatedAt,
+		PublishedAt:  existing.PublishedAt,
+		ExpiresAt:    existing.ExpiresAt,
+	}
+
+	if listing.CustomFields == nil {
+		listing.CustomFields = map[string]interface{}{}
+	}
+
+	if err := s.validateCustomFields(ctx, listing.CategoryID, listing.CustomFields); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.UpdateListing(ctx, listing); err != nil {
+		return nil, apperror.ErrDatabaseOperation(
+			errorbuilder.WithOriginal(err),
+		)
+	}
+
+	return listing, nil
+}
+
+// DeleteListing deletes a listing together with the files of its media
+func (s *Service) DeleteListing(ctx context.Context, params DeleteListingParams) error {
+	if _, err := s.ownedListing(ctx, params.ID, params.DeletedBy); err != nil {
+		return err
+	}
+
+	media, err := s.repo.ListListingMedia(ctx, params.ID)
+	if err != nil {
+		return apperror.ErrDatabaseOperation(
+			errorbuilder.WithOriginal(err),
+		)
+	}
+
+	if err := s.repo.DeleteListing(ctx, params.ID); err != nil {
+		return apperror.ErrDatabaseOperation(
+			errorbuilder.WithOriginal(err),
+		)
+	}
+
+	s.deleteMediaObjects(ctx, media...)
+	return nil
+}
+
+func (s *Service) ListListings(ctx context.Context, query pagination.Query) (pagination.Page[*Listing], error) {
+	page, err := s.repo.ListListings(ctx, query)
+	if err != nil {
+		var customErr *errorbuilder.CustomError
+		if errors.As(err, &customErr) {
+			return pagination.Page[*Listing]{}, err
+		}
+		return pagination.Page[*Listing]{}, apperror.ErrDatabaseOperation(
+			errorbuilder.WithOriginal(err),
+		)
+	}
+	return page, nil
+}
+
+// Search operations
+func (s *Service) SearchListings(ctx context.Context, params SearchParams) (*SearchResult, error) {
+	criteria := SearchCriteria{
+		MinPrice:     params.MinPrice,
+		MaxPrice:     params.MaxPrice,
+		ConditionIDs: params.ConditionIDs,
+		CurrencyIDs:  params.CurrencyIDs,
+		StatusIDs:    params.StatusIDs,
+		CustomFields: params.CustomFields,
+		FacetFields:  params.FacetFields,
+		Limit:        params.Limit,
+	}
+
+	if criteria.Limit <= 0 {
+		criteria.Limit = DefaultSearchLimit
+	}
+	if criteria.Limit > MaxSearchLimit {
+		return nil, apperror.ErrInvalidSearch(
+			apperror.WithValidation("limit", fmt.Sprintf("limit cannot exceed %d", MaxSearchLimit)),
+		)
+	}
+
+	if params.MinPrice != nil && params.MaxPrice != nil && *params.MinPrice > *params.MaxPrice {
+		return nil, apperror.ErrInvalidSearch(
+			apperror.WithValidation("minPrice", "minPrice cannot be greater than maxPrice"),
+		)
+	}
+
+	for _, predicate := range params.CustomFields {
+		if predicate.Operator == OpEq {
+			continue
+		}
+		if _, ok := predicate.Value.(float64); !ok {
+			return nil, apperror.ErrInvalidSearch(
+				apperror.WithValidation(predicate.Key, fmt.Sprintf("operator %s requires a numeric value", predicate.Operator)),
+			)
+		}
+	}
+
+	if params.Cursor != "" {
+		cursor, err := decodeSearchCursor(params.Cursor)
+		if err != nil {
+			return nil, apperror.ErrInvalidCursor(
+				errorbuilder.WithOriginal(err),
+			)
+		}
+		criteria.After = cursor
+	}
+
+	// Only published listings are searched unless statuses are asked for
+	if len(criteria.StatusIDs) == 0 {
+		statusID, err := s.statusID(ctx, StatusPublished)
+		if err != nil {
+			var appErr *errorbuilder.CustomError
+			if !errors.As(err, &appErr) {
+				err = apperror.ErrDatabaseOperation(
+					errorbuilder.WithOriginal(err),
+				)
+			}
+			return nil, err
+		}
+		criteria.StatusIDs = []uuid.UUID{statusID}
+		criteria.StatusDefaulted = true
+	}
+
+	if params.CategoryID != nil {
+		criteria.CategoryIDs = []uuid.UUID{*params.CategoryID}
+		if params.IncludeDescendants {
+			descendants, err := s.catalog.GetDescendantCategoryIDs(ctx, *params.CategoryID)
+			if err != nil {
+				return nil, err
+			}
+			criteria.CategoryIDs = append(criteria.CategoryIDs, descendants...)
+		}
+	}
+
+	// Fetch one extra row to find out whether there is a next page
+	pageCriteria := criteria
+	pageCriteria.Limit = criteria.Limit + 1
+
+	listings, err := s.repo.SearchListings(ctx, pageCriteria)
+	if err != nil {
+		return nil, apperror.ErrDatabaseOperation(
+			errorbuilder.WithOriginal(err),
+		)
+	}
+
+	facets, err := s.repo.CountListingFacets(ctx, criteria)
+	if err != nil {
+		return nil, apperror.ErrDatabaseOperation(
+			errorbuilder.WithOriginal(err),
+		)
+	}
+
+	result := &SearchResult{
+		Listings: listings,
+		Facets:   facets,
+	}
+
+	if len(listings) > criteria.Limit {
+		result.Listings = listings[:criteria.Limit]
+		result.NextCursor = encodeSearchCursor(result.Listings[criteria.Limit-1])
+	}
+
+	return result, nil
+}
+
+// validateCustomFields fetches the category's field schema from the catalog
+// service and rejects custom field values that do not conform to it.
+func (s *Service) validateCustomFields(ctx context.Context, categoryID uuid.UUID, values map[string]interface{}) error {
+	definitions, err := s.catalog.GetCategoryFieldDefinitions(ctx, categoryID)
+	if err != nil {
+		var customErr *errorbuilder.CustomError
+		if errors.As(err, &customErr) && customErr.Code == errorbuilder.ErrNotFound {
+			return apperror.ErrInvalidCategory(
+				apperror.WithValidation("categoryId", "category does not exist"),
+				errorbuilder.WithOriginal(err),
+			)
+		}
+		return err
+	}
+
+	if fieldErrs := validateCustomFields(definitions, values); len(fieldErrs) > 0 {
+		return apperror.ErrInvalidFields(
+			apperror.WithFieldErrors(fieldErrs),
+		)
+	}
+
+	return nil
+}
+
+func validateListing(title string, categoryID uuid.UUID, price *float64) error {
+	if title == "" {
+		return apperror.ErrInvalidTitle(
+			apperror.WithValidation("title", "title cannot be empty"),
+		)
+	}
+
+	if categoryID == uuid.Nil {
+		return apperror.ErrInvalidCategory(
+			apperror.WithValidation("categoryId", "category cannot be empty"),
+		)
+	}
+
+	if price != nil && *price < 0 {
+		return apperror.ErrInvalidPrice(
+			apperror.WithValidation("price", "price cannot be negative"),
+		)
+	}
+
+	return nil
+}
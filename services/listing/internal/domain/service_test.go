@@ -0,0 +1,191 @@
+package listing
+
+import (
+	"context"
+	"testing"
+
+	"localloop/libs/pkg/errorbuilder"
+	apperror "localloop/services/listing/internal/shared/error"
+
+	"github.com/google/uuid"
+)
+
+// listingsRepository creates, updates and deletes listings, which have no
+// media
+type listingsRepository struct {
+	*lifecycleRepository
+	deleted []uuid.UUID
+}
+
+func newListingsRepository() *listingsRepository {
+	return &listingsRepository{lifecycleRepository: newLifecycleRepository()}
+}
+
+func (r *listingsRepository) WithTx(_ context.Context, fn func(repo Repository) error) error {
+	return fn(r)
+}
+
+func (r *listingsRepository) CreateListing(_ context.Context, listing *Listing) error {
+	r.listings[listing.ID] = listing
+	return nil
+}
+
+func (r *listingsRepository) UpdateListing(_ context.Context, listing *Listing) error {
+	r.listings[listing.ID] = listing
+	return nil
+}
+
+func (r *listingsRepository) ListListingMedia(context.Context, uuid.UUID) ([]*ListingMedia, error) {
+	return nil, nil
+}
+
+func (r *listingsRepository) DeleteListing(_ context.Context, id uuid.UUID) error {
+	r.deleted = append(r.deleted, id)
+	delete(r.listings, id)
+	return nil
+}
+
+// categoriesCatalog knows categories without custom fields
+type categoriesCatalog struct {
+	CatalogClient
+	categories map[uuid.UUID]bool
+}
+
+func (c *categoriesCatalog) GetCategoryFieldDefinitions(_ context.Context, id uuid.UUID) ([]*CategoryFieldDefinition, error) {
+	if !c.categories[id] {
+		return nil, apperror.ErrCategoryNotFound()
+	}
+	return nil, nil
+}
+
+func TestCreateListing(t *testing.T) {
+	owner, category := uuid.New(), uuid.New()
+	price, negative := 10.0, -1.0
+	catalog := &categoriesCatalog{categories: map[uuid.UUID]bool{category: true}}
+
+	tests := []struct {
+		name     string
+		params   CreateListingParams
+		wantCode errorbuilder.ErrorCode
+	}{
+		{name: "draft", params: CreateListingParams{Title: "Bike", CategoryID: category, Price: &price, CreatedBy: owner}},
+		{name: "without a title", params: CreateListingParams{CategoryID: category, CreatedBy: owner}, wantCode: errorbuilder.ErrValidation},
+		{name: "without a category", params: CreateListingParams{Title: "Bike", CreatedBy: owner}, wantCode: errorbuilder.ErrValidation},
+		{name: "unknown category", params: CreateListingParams{Title: "Bike", CategoryID: uuid.New(), CreatedBy: owner}, wantCode: errorbuilder.ErrValidation},
+		{name: "negative price", params: CreateListingParams{Title: "Bike", CategoryID: category, Price: &negative, CreatedBy: owner}, wantCode: errorbuilder.ErrValidation},
+		{name: "without an owner", params: CreateListingParams{Title: "Bike", CategoryID: category}, wantCode: errorbuilder.ErrValidation},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newListingsRepository()
+			s := NewService(repo, catalog, nil, ServiceConfig{})
+			ctx := context.Background()
+
+			created, err := s.CreateListing(ctx, tt.params)
+			if code := errorCode(err); code != tt.wantCode {
+				t.Fatalf("CreateListing() error = %v, want code %d", err, tt.wantCode)
+			}
+			if tt.wantCode != 0 {
+				if len(repo.listings) != 0 {
+					t.Error("a rejected listing was created")
+				}
+				return
+			}
+
+			draft, _ := repo.GetListingStatusID(ctx, StatusDraft)
+			if created.StatusID != draft || created.CreatedBy != owner || created.CustomFields == nil {
+				t.Errorf("CreateListing() = %+v, want a draft of %v", created, owner)
+			}
+			if len(repo.transitions) != 1 || repo.transitions[0].Action != ActionCreate {
+				t.Errorf("recorded %v, want the creation", repo.transitions)
+			}
+			if got, err := s.GetListing(ctx, created.ID); err != nil || got != created {
+				t.Errorf("GetListing() = %v, %v, want the created listing", got, err)
+			}
+		})
+	}
+}
+
+func TestGetMissingListing(t *testing.T) {
+	s := NewService(newListingsRepository(), nil, nil, ServiceConfig{})
+	if _, err := s.GetListing(context.Background(), uuid.New()); errorCode(err) != errorbuilder.ErrNotFound {
+		t.Errorf("GetListing() error = %v, want not found", err)
+	}
+}
+
+func TestUpdateListing(t *testing.T) {
+	owner, other, category := uuid.New(), uuid.New(), uuid.New()
+	catalog := &categoriesCatalog{categories: map[uuid.UUID]bool{category: true}}
+
+	tests := []struct {
+		name     string
+		missing  bool
+		params   UpdateListingParams
+		wantCode errorbuilder.ErrorCode
+	}{
+		{name: "owner", params: UpdateListingParams{Title: "Road bike", CategoryID: category, UpdatedBy: owner}},
+		{name: "someone else", params: UpdateListingParams{Title: "Road bike", CategoryID: category, UpdatedBy: other}, wantCode: errorbuilder.ErrForbidden},
+		{name: "without a title", params: UpdateListingParams{CategoryID: category, UpdatedBy: owner}, wantCode: errorbuilder.ErrValidation},
+		{name: "unknown category", params: UpdateListingParams{Title: "Road bike", CategoryID: uuid.New(), UpdatedBy: owner}, wantCode: errorbuilder.ErrValidation},
+		{name: "missing listing", missing: true, params: UpdateListingParams{Title: "Road bike", CategoryID: category, UpdatedBy: owner}, wantCode: errorbuilder.ErrNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newListingsRepository()
+			id := repo.add(StatusPublished, owner)
+			existing := repo.listings[id]
+			existing.Title, existing.StatusID = "Bike", uuid.New()
+			if tt.missing {
+				delete(repo.listings, id)
+			}
+			s := NewService(repo, catalog, nil, ServiceConfig{})
+
+			tt.params.ID = id
+			updated, err := s.UpdateListing(context.Background(), tt.params)
+			if code := errorCode(err); code != tt.wantCode {
+				t.Fatalf("UpdateListing() error = %v, want code %d", err, tt.wantCode)
+			}
+			if tt.wantCode != 0 {
+				if !tt.missing && repo.listings[id].Title != "Bike" {
+					t.Error("a rejected update changed the listing")
+				}
+				return
+			}
+
+			if updated.Title != "Road bike" || updated.StatusID != existing.StatusID || updated.CreatedBy != owner {
+				t.Errorf("UpdateListing() = %+v, want the new title with the status and owner kept", updated)
+			}
+			if repo.listings[id] != updated {
+				t.Error("UpdateListing() did not store the listing")
+			}
+		})
+	}
+}
+
+func TestDeleteListingRequiresOwner(t *testing.T) {
+	owner, other := uuid.New(), uuid.New()
+	repo := newListingsRepository()
+	id := repo.add(StatusPublished, owner)
+	s := NewService(repo, nil, nil, ServiceConfig{})
+	ctx := context.Background()
+
+	if err := s.DeleteListing(ctx, DeleteListingParams{ID: id, DeletedBy: other}); errorCode(err) != errorbuilder.ErrForbidden {
+		t.Fatalf("DeleteListing() by someone else error = %v, want forbidden", err)
+	}
+	if len(repo.deleted) != 0 {
+		t.Fatal("someone else deleted the listing")
+	}
+
+	if err := s.DeleteListing(ctx, DeleteListingParams{ID: id, DeletedBy: owner}); err != nil {
+		t.Fatalf("DeleteListing() by the owner error = %v", err)
+	}
+	if len(repo.deleted) != 1 || repo.deleted[0] != id {
+		t.Errorf("deleted %v, want %v", repo.deleted, id)
+	}
+
+	if err := s.DeleteListing(ctx, DeleteListingParams{ID: id, DeletedBy: owner}); errorCode(err) != errorbuilder.ErrNotFound {
+		t.Errorf("DeleteListing() of a deleted listing error = %v, want not found", err)
+	}
+}
@@ -0,0 +1,102 @@
+package listing
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/jpeg"
+
+	// Decoders of the accepted image types
+	_ "image/gif"
+	_ "image/png"
+)
+
+const (
+	// thumbnailSize bounds the longer side of thumbnails, in pixels
+	thumbnailSize        = 320
+	thumbnailContentType = "image/jpeg"
+	thumbnailQuality     = 80
+
+	// maxImagePixels bounds the decoded size of images. Compressed files can
+	// declare far larger images than their size suggests, and decoding
+	// allocates for every pixel.
+	maxImagePixels = 25_000_000
+)
+
+// renderThumbnail decodes an image and encodes a JPEG thumbnail of it, which
+// is never larger than the image. It also returns the size of the image.
+// Images of more than maxImagePixels are rejected before they are decoded.
+func renderThumbnail(data []byte) ([]byte, int, int, error) {
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, 0, 0, err
+	}
+	if cfg.Width <= 0 || cfg.Height <= 0 || int64(cfg.Width)*int64(cfg.Height) > maxImagePixels {
+		return nil, 0, 0, fmt.Errorf("image of %dx%d pixels is too large", cfg.Width, cfg.Height)
+	}
+
+	src, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, 0, 0, err
+	}
+
+	bounds := src.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+
+	thumbWidth, thumbHeight := width, height
+	if width > thumbnailSize || height > thumbnailSize {
+		if width >= height {
+			thumbWidth, thumbHeight = thumbnailSize, max(1, height*thumbnailSize/width)
+		} else {
+			thumbWidth, thumbHeight = max(1, width*thumbnailSize/height), thumbnailSize
+		}
+	}
+
+	var buf bytes.Buffer
+	thumb := downscale(src, thumbWidth, thumbHeight)
+	if err := jpeg.Encode(&buf, thumb, &jpeg.Options{Quality: thumbnailQuality}); err != nil {
+		return nil, 0, 0, err
+	}
+	return buf.Bytes(), width, height, nil
+}
+
+// downscale resizes src by averaging the source pixels each destination
+// pixel covers. Transparent areas are flattened onto white, since JPEG has
+// no alpha channel.
+func downscale(src image.Image, width, height int) *image.RGBA {
+	bounds := src.Bounds()
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+
+	for y := 0; y < height; y++ {
+		y0 := bounds.Min.Y + y*bounds.Dy()/height
+		y1 := max(y0+1, bounds.Min.Y+(y+1)*bounds.Dy()/height)
+
+		for x := 0; x < width; x++ {
+			x0 := bounds.Min.X + x*bounds.Dx()/width
+			x1 := max(x0+1, bounds.Min.X+(x+1)*bounds.Dx()/width)
+
+			var r, g, b, n uint64
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					cr, cg, cb, ca := src.At(sx, sy).RGBA()
+					// Colors are alpha premultiplied, add the white background
+					white := 0xffff - uint64(ca)
+					r += uint64(cr) + white
+					g += uint64(cg) + white
+					b += uint64(cb) + white
+					n++
+				}
+			}
+
+			dst.SetRGBA(x, y, color.RGBA{
+				R: uint8(r / n >> 8),
+				G: uint8(g / n >> 8),
+				B: uint8(b / n >> 8),
+				A: 0xff,
+			})
+		}
+	}
+
+	return dst
+}
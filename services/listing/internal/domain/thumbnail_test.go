@@ -0,0 +1,103 @@
+package listing
+
+import (
+	"bytes"
+	"encoding/binary"
+	"hash/crc32"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"testing"
+)
+
+func encodePNG(t *testing.T, width, height int) []byte {
+	t.Helper()
+
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	for x := 0; x < width; x++ {
+		for y := 0; y < height; y++ {
+			img.Set(x, y, color.NRGBA{R: uint8(x), G: uint8(y), A: 0xff})
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+// withPNGSize rewrites the dimensions declared in the IHDR chunk of a PNG,
+// leaving the pixel data as it is
+func withPNGSize(data []byte, width, height uint32) []byte {
+	out := bytes.Clone(data)
+	// Signature (8), chunk length (4) and type (4) precede the IHDR data
+	binary.BigEndian.PutUint32(out[16:], width)
+	binary.BigEndian.PutUint32(out[20:], height)
+	binary.BigEndian.PutUint32(out[29:], crc32.ChecksumIEEE(out[12:29]))
+	return out
+}
+
+func TestRenderThumbnail(t *testing.T) {
+	tests := []struct {
+		name                    string
+		width, height           int
+		thumbWidth, thumbHeight int
+	}{
+		{name: "landscape", width: 1000, height: 500, thumbWidth: 320, thumbHeight: 160},
+		{name: "portrait", width: 400, height: 800, thumbWidth: 160, thumbHeight: 320},
+		{name: "small images are not enlarged", width: 100, height: 50, thumbWidth: 100, thumbHeight: 50},
+		{name: "thin images keep a pixel", width: 2000, height: 1, thumbWidth: 320, thumbHeight: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			thumb, width, height, err := renderThumbnail(encodePNG(t, tt.width, tt.height))
+			if err != nil {
+				t.Fatalf("renderThumbnail() error = %v", err)
+			}
+			if width != tt.width || height != tt.height {
+				t.Errorf("image size = %dx%d, want %dx%d", width, height, tt.width, tt.height)
+			}
+
+			cfg, err := jpeg.DecodeConfig(bytes.NewReader(thumb))
+			if err != nil {
+				t.Fatalf("thumbnail is not a JPEG: %v", err)
+			}
+			if cfg.Width != tt.thumbWidth || cfg.Height != tt.thumbHeight {
+				t.Errorf("thumbnail size = %dx%d, want %dx%d", cfg.Width, cfg.Height, tt.thumbWidth, tt.thumbHeight)
+			}
+		})
+	}
+}
+
+func TestRenderThumbnailRejectsInvalidImages(t *testing.T) {
+	small := encodePNG(t, 4, 4)
+
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{name: "not an image", data: []byte("hello")},
+		{name: "declared size over the limit", data: withPNGSize(small, 50000, 50000)},
+		{name: "declared size just over the limit", data: withPNGSize(small, maxImagePixels/1000+1, 1000)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, _, _, err := renderThumbnail(tt.data); err == nil {
+				t.Fatal("renderThumbnail() error = nil, want an error")
+			}
+		})
+	}
+}
+
+func TestDownscaleFlattensTransparencyOntoWhite(t *testing.T) {
+	src := image.NewNRGBA(image.Rect(0, 0, 2, 2))
+	dst := downscale(src, 1, 1)
+
+	if got := dst.RGBAAt(0, 0); got != (color.RGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff}) {
+		t.Errorf("transparent pixel = %v, want white", got)
+	}
+}
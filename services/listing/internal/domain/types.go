@@ -0,0 +1,71 @@
+package listing
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Listing struct {
+	ID           uuid.UUID
+	Title        string
+	Description  string
+	CategoryID   uuid.UUID
+	Price        *float64
+	CurrencyID   *uuid.UUID
+	ConditionID  *uuid.UUID
+	StatusID     uuid.UUID
+	MediaURL     string
+	CustomFields map[string]interface{}
+	CreatedBy    uuid.UUID
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+	PublishedAt  *time.Time
+	// ExpiresAt is set when the listing is published or renewed
+	ExpiresAt *time.Time
+}
+
+// CreateListingParams creates a draft listing, its status is changed with
+// Service.TransitionListing
+type CreateListingParams struct {
+	Title        string `validate:"required"`
+	Description  string
+	CategoryID   uuid.UUID `validate:"required"`
+	Price        *float64
+	CurrencyID   *uuid.UUID
+	ConditionID  *uuid.UUID
+	MediaURL     string
+	CustomFields map[string]interface{}
+	CreatedBy    uuid.UUID `validate:"required"`
+}
+
+type UpdateListingParams struct {
+	ID           uuid.UUID `validate:"required"`
+	Title        string    `validate:"required"`
+	Description  string
+	CategoryID   uuid.UUID `validate:"required"`
+	Price        *float64
+	CurrencyID   *uuid.UUID
+	ConditionID  *uuid.UUID
+	MediaURL     string
+	CustomFields map[string]interface{}
+	// UpdatedBy is the user making the change, who has to own the listing
+	UpdatedBy uuid.UUID `validate:"required"`
+}
+
+// DeleteListingParams deletes a listing, DeletedBy has to own it
+type DeleteListingParams struct {
+	ID        uuid.UUID `validate:"required"`
+	DeletedBy uuid.UUID `validate:"required"`
+}
+
+// CategoryFieldDefinition describes a catalog field assigned to a category,
+// resolved together with its field type and discriminator.
+type CategoryFieldDefinition struct {
+	FieldID          uuid.UUID
+	Name             string
+	IsRequired       bool
+	TypeName         string
+	Properties       map[string]interface{}
+	ValidationSchema map[string]interface{}
+}
@@ -0,0 +1,244 @@
+package catalog
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"localloop/libs/pkg/errorbuilder"
+	listing "localloop/services/listing/internal/domain"
+	apperror "localloop/services/listing/internal/shared/error"
+
+	"github.com/google/uuid"
+)
+
+var errNotFound = errors.New("resource not found")
+
+type Client struct {
+	baseURL string
+	client  *http.Client
+}
+
+func NewClient(baseURL string) *Client {
+	return &Client{
+		baseURL: baseURL,
+		client:  &http.Client{Timeout: 5 * time.Second},
+	}
+}
+
+type apiResponse struct {
+	Message string          `json:"message,omitempty"`
+	Data    json.RawMessage `json:"data,omitempty"`
+	Meta    listMeta        `json:"meta"`
+}
+
+type listMeta struct {
+	NextCursor string `json:"nextCursor,omitempty"`
+}
+
+// listPageSize is the largest page the catalog lists
+const listPageSize = 100
+
+type categoryNodeResponse struct {
+	ID       uuid.UUID              `json:"id"`
+	Children []categoryNodeResponse `json:"children"`
+}
+
+type fieldResponse struct {
+	ID          uuid.UUID `json:"id"`
+	Name        string    `json:"name"`
+	FieldTypeID uuid.UUID `json:"fieldTypeId"`
+}
+
+type categoryFieldResponse struct {
+	Field      fieldResponse `json:"field"`
+	IsRequired bool          `json:"isRequired"`
+}
+
+type fieldTypeResponse struct {
+	ID                  uuid.UUID              `json:"id"`
+	TypeDiscriminatorID uuid.UUID              `json:"typeDiscriminatorId"`
+	Properties          map[string]interface{} `json:"properties"`
+}
+
+type discriminatorResponse struct {
+	ID               uuid.UUID              `json:"id"`
+	Name             string                 `json:"name"`
+	ValidationSchema map[string]interface{} `json:"validationSchema"`
+}
+
+// GetCategoryFieldDefinitions resolves the effective fields of a category,
+// including those inherited from its ancestors, together with their field
+// types and discriminators.
+func (c *Client) GetCategoryFieldDefinitions(ctx context.Context, categoryID uuid.UUID) ([]*listing.CategoryFieldDefinition, error) {
+	var categoryFields []categoryFieldResponse
+	if err := c.get(ctx, fmt.Sprintf("/categories/%s/fields/effective", categoryID), &categoryFields); err != nil {
+		if errors.Is(err, errNotFound) {
+			return nil, apperror.ErrCategoryNotFound(
+				errorbuilder.WithContext(map[string]any{
+					"categoryId": categoryID.String(),
+				}),
+			)
+		}
+		return nil, err
+	}
+
+	if len(categoryFields) == 0 {
+		return []*listing.CategoryFieldDefinition{}, nil
+	}
+
+	discriminators, err := c.listDiscriminators(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	fieldTypes := make(map[uuid.UUID]*fieldTypeResponse)
+
+	definitions := make([]*listing.CategoryFieldDefinition, 0, len(categoryFields))
+	for _, cf := range categoryFields {
+		fieldType, ok := fieldTypes[cf.Field.FieldTypeID]
+		if !ok {
+			fieldType = &fieldTypeResponse{}
+			if err := c.get(ctx, fmt.Sprintf("/field-types/%s", cf.Field.FieldTypeID), fieldType); err != nil {
+				return nil, err
+			}
+			fieldTypes[cf.Field.FieldTypeID] = fieldType
+		}
+
+		// Field types cannot refer to missing discriminators, one created
+		// since the list was fetched has no name or schema to validate by
+		discriminator, ok := discriminators[fieldType.TypeDiscriminatorID]
+		if !ok {
+			discriminator = &discriminatorResponse{ID: fieldType.TypeDiscriminatorID}
+		}
+
+		definitions = append(definitions, &listing.CategoryFieldDefinition{
+			FieldID:          cf.Field.ID,
+			Name:             cf.Field.Name,
+			IsRequired:       cf.IsRequired,
+			TypeName:         discriminator.Name,
+			Properties:       fieldType.Properties,
+			ValidationSchema: discriminator.ValidationSchema,
+		})
+	}
+
+	return definitions, nil
+}
+
+// listDiscriminators fetches every field type discriminator by ID. There are
+// few of them, so they usually fit on a single page.
+func (c *Client) listDiscriminators(ctx context.Context) (map[uuid.UUID]*discriminatorResponse, error) {
+	discriminators := make(map[uuid.UUID]*discriminatorResponse)
+
+	query := url.Values{"limit": []string{strconv.Itoa(listPageSize)}}
+	for {
+		var page []*discriminatorResponse
+		meta, err := c.getPage(ctx, "/field-type-discriminators?"+query.Encode(), &page)
+		if err != nil {
+			return nil, err
+		}
+		for _, discriminator := range page {
+			discriminators[discriminator.ID] = discriminator
+		}
+
+		if meta.NextCursor == "" {
+			return discriminators, nil
+		}
+		query.Set("cursor", meta.NextCursor)
+	}
+}
+
+// GetDescendantCategoryIDs returns every category below categoryID using the
+// catalog's subtree endpoint.
+func (c *Client) GetDescendantCategoryIDs(ctx context.Context, categoryID uuid.UUID) ([]uuid.UUID, error) {
+	var root categoryNodeResponse
+	if err := c.get(ctx, fmt.Sprintf("/categories/%s/subtree", categoryID), &root); err != nil {
+		if errors.Is(err, errNotFound) {
+			return nil, apperror.ErrCategoryNotFound(
+				errorbuilder.WithContext(map[string]any{
+					"categoryId": categoryID.String(),
+				}),
+			)
+		}
+		return nil, err
+	}
+
+	var descendants []uuid.UUID
+	queue := root.Children
+	for len(queue) > 0 {
+		node := queue[0]
+		queue = append(queue[1:], node.Children...)
+		descendants = append(descendants, node.ID)
+	}
+
+	return descendants, nil
+}
+
+// get performs a GET request against the catalog service and decodes the
+// data envelope into out. A nil out discards the payload.
+func (c *Client) get(ctx context.Context, path string, out any) error {
+	_, err := c.getPage(ctx, path, out)
+	return err
+}
+
+// getPage is get for list endpoints, it also returns the list metadata
+func (c *Client) getPage(ctx context.Context, path string, out any) (listMeta, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+path, nil)
+	if err != nil {
+		return listMeta{}, apperror.ErrCatalogService(
+			apperror.WithService("catalog"),
+			errorbuilder.WithOriginal(err),
+		)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return listMeta{}, apperror.ErrCatalogService(
+			apperror.WithService("catalog"),
+			errorbuilder.WithOriginal(err),
+		)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		problem := errorbuilder.FromResponse(resp)
+		var orig error = problem
+		if problem.Code == errorbuilder.ErrNotFound {
+			orig = errNotFound
+		}
+		return listMeta{}, apperror.ErrCatalogService(
+			errorbuilder.WithContext(map[string]any{
+				"service": "catalog",
+				"path":    path,
+				"status":  resp.StatusCode,
+				"error":   problem.Message,
+				"detail":  problem.Detail,
+			}),
+			errorbuilder.WithOriginal(orig),
+		)
+	}
+
+	var apiResp apiResponse
+	if err := json.NewDecoder(resp.Body).Decode(&apiResp); err != nil {
+		return listMeta{}, apperror.ErrInvalidJSON(
+			errorbuilder.WithOriginal(err),
+		)
+	}
+
+	if out == nil || len(apiResp.Data) == 0 {
+		return apiResp.Meta, nil
+	}
+
+	if err := json.Unmarshal(apiResp.Data, out); err != nil {
+		return listMeta{}, apperror.ErrInvalidJSON(
+			errorbuilder.WithOriginal(err),
+		)
+	}
+
+	return apiResp.Meta, nil
+}
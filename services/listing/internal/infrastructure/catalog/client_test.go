@@ -0,0 +1,76 @@
+package catalog
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestGetCategoryFieldDefinitions(t *testing.T) {
+	categoryID := uuid.New()
+	textType, colorType := uuid.New(), uuid.New()
+	textID, colorID := uuid.New(), uuid.New()
+	fields := []uuid.UUID{uuid.New(), uuid.New(), uuid.New()}
+
+	respond := func(w http.ResponseWriter, data any, meta any) {
+		json.NewEncoder(w).Encode(map[string]any{"data": data, "meta": meta})
+	}
+
+	requests := map[string]int{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests[r.URL.Path]++
+
+		switch {
+		case r.URL.Path == "/categories/"+categoryID.String()+"/fields/effective":
+			respond(w, []map[string]any{
+				{"field": map[string]any{"id": fields[0], "name": "Size", "fieldTypeId": textType}, "isRequired": true},
+				{"field": map[string]any{"id": fields[1], "name": "Brand", "fieldTypeId": textType}},
+				{"field": map[string]any{"id": fields[2], "name": "Color", "fieldTypeId": colorType}},
+			}, nil)
+		case r.URL.Path == "/field-types/"+textType.String():
+			respond(w, map[string]any{"id": textType, "typeDiscriminatorId": textID, "properties": map[string]any{"maxLength": 20}}, nil)
+		case r.URL.Path == "/field-types/"+colorType.String():
+			respond(w, map[string]any{"id": colorType, "typeDiscriminatorId": colorID}, nil)
+		case r.URL.Path == "/field-type-discriminators" && r.URL.Query().Get("cursor") == "":
+			respond(w, []map[string]any{{"id": textID, "name": "text"}}, map[string]any{"nextCursor": "page2"})
+		case r.URL.Path == "/field-type-discriminators":
+			respond(w, []map[string]any{{"id": colorID, "name": "color", "validationSchema": map[string]any{"type": "object"}}}, nil)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	definitions, err := NewClient(server.URL).GetCategoryFieldDefinitions(context.Background(), categoryID)
+	if err != nil {
+		t.Fatalf("GetCategoryFieldDefinitions() error = %v", err)
+	}
+
+	var got []string
+	for _, def := range definitions {
+		got = append(got, def.Name+":"+def.TypeName)
+	}
+	if want := "Size:text Brand:text Color:color"; strings.Join(got, " ") != want {
+		t.Errorf("definitions = %v, want %s", got, want)
+	}
+	if !definitions[0].IsRequired || definitions[0].Properties["maxLength"] != 20.0 {
+		t.Errorf("first definition = %+v", definitions[0])
+	}
+	if definitions[2].ValidationSchema["type"] != "object" {
+		t.Errorf("color schema = %v", definitions[2].ValidationSchema)
+	}
+
+	if requests["/field-type-discriminators"] != 2 || requests["/field-types/"+textType.String()] != 1 {
+		t.Errorf("requests = %v, want one per discriminator page and field type", requests)
+	}
+	for path := range requests {
+		if strings.HasPrefix(path, "/field-type-discriminators/") {
+			t.Errorf("discriminator fetched one by one: %s", path)
+		}
+	}
+}
@@ -0,0 +1,93 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+
+	listing "localloop/services/listing/internal/domain"
+	"localloop/services/listing/internal/infrastructure/repository/postgresql/sqlc"
+
+	"github.com/google/uuid"
+)
+
+func (r *ListingRepository) GetListingStatus(ctx context.Context, id uuid.UUID) (listing.ListingStatus, error) {
+	status, err := r.q.GetListingStatusForUpdate(ctx, id)
+	if err != nil {
+		return "", err
+	}
+	return listing.ListingStatus(status), nil
+}
+
+func (r *ListingRepository) GetListingStatusID(ctx context.Context, status listing.ListingStatus) (uuid.UUID, error) {
+	return r.q.GetListingStatusID(ctx, string(status))
+}
+
+func (r *ListingRepository) SetListingStatus(ctx context.Context, params listing.SetListingStatusParams) (*listing.Listing, error) {
+	result, err := r.q.SetListingStatus(ctx, sqlc.SetListingStatusParams{
+		ID:               params.ID,
+		StatusID:         params.StatusID,
+		Publish:          params.Publish,
+		ExpiresInSeconds: params.ExpiresIn.Seconds(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toListing(result)
+}
+
+func (r *ListingRepository) CreateListingTransition(ctx context.Context, t *listing.ListingTransition) error {
+	params := sqlc.CreateListingTransitionParams{
+		ID:        t.ID,
+		ListingID: t.ListingID,
+		ToStatus:  string(t.To),
+		Action:    string(t.Action),
+		Reason:    t.Reason,
+		ChangedBy: toNullUUID(t.ChangedBy),
+	}
+	if t.From != nil {
+		params.FromStatus = sql.NullString{String: string(*t.From), Valid: true}
+	}
+
+	createdAt, err := r.q.CreateListingTransition(ctx, params)
+	if err != nil {
+		return err
+	}
+	t.CreatedAt = createdAt
+	return nil
+}
+
+func (r *ListingRepository) ListListingTransitions(ctx context.Context, listingID uuid.UUID) ([]*listing.ListingTransition, error) {
+	results, err := r.q.ListListingTransitions(ctx, listingID)
+	if err != nil {
+		return nil, err
+	}
+
+	history := make([]*listing.ListingTransition, len(results))
+	for i, result := range results {
+		var from *listing.ListingStatus
+		if result.FromStatus.Valid {
+			status := listing.ListingStatus(result.FromStatus.String)
+			from = &status
+		}
+
+		history[i] = &listing.ListingTransition{
+			ID:        result.ID,
+			ListingID: result.ListingID,
+			From:      from,
+			To:        listing.ListingStatus(result.ToStatus),
+			Action:    listing.ListingAction(result.Action),
+			Reason:    result.Reason,
+			ChangedBy: fromNullUUID(result.ChangedBy),
+			CreatedAt: result.CreatedAt,
+		}
+	}
+	return history, nil
+}
+
+func (r *ListingRepository) IsListingExpired(ctx context.Context, id uuid.UUID) (bool, error) {
+	return r.q.IsListingExpired(ctx, id)
+}
+
+func (r *ListingRepository) ListExpiredListingIDs(ctx context.Context, limit int) ([]uuid.UUID, error) {
+	return r.q.ListExpiredListingIDs(ctx, int32(limit))
+}
@@ -0,0 +1,89 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+
+	listing "localloop/services/listing/internal/domain"
+	"localloop/services/listing/internal/infrastructure/repository/postgresql/sqlc"
+
+	"github.com/google/uuid"
+)
+
+func toListingMedia(result sqlc.ListingMedia) *listing.ListingMedia {
+	return &listing.ListingMedia{
+		ID:           result.ID,
+		ListingID:    result.ListingID,
+		Position:     result.Position,
+		StorageKey:   result.StorageKey,
+		ThumbnailKey: result.ThumbnailKey,
+		ContentType:  result.ContentType,
+		SizeBytes:    result.SizeBytes,
+		Width:        result.Width,
+		Height:       result.Height,
+		CreatedAt:    result.CreatedAt,
+	}
+}
+
+func (r *ListingRepository) ListListingMedia(ctx context.Context, listingID uuid.UUID) ([]*listing.ListingMedia, error) {
+	results, err := r.q.ListListingMedia(ctx, listingID)
+	if err != nil {
+		return nil, err
+	}
+
+	media := make([]*listing.ListingMedia, len(results))
+	for i, result := range results {
+		media[i] = toListingMedia(result)
+	}
+	return media, nil
+}
+
+func (r *ListingRepository) LockListingForMedia(ctx context.Context, listingID uuid.UUID) error {
+	_, err := r.q.LockListingForMedia(ctx, listingID)
+	return err
+}
+
+func (r *ListingRepository) CreateListingMedia(ctx context.Context, media *listing.ListingMedia) error {
+	createdAt, err := r.q.CreateListingMedia(ctx, sqlc.CreateListingMediaParams{
+		ID:           media.ID,
+		ListingID:    media.ListingID,
+		Position:     media.Position,
+		StorageKey:   media.StorageKey,
+		ThumbnailKey: media.ThumbnailKey,
+		ContentType:  media.ContentType,
+		SizeBytes:    media.SizeBytes,
+		Width:        media.Width,
+		Height:       media.Height,
+	})
+	if err != nil {
+		return err
+	}
+	media.CreatedAt = createdAt
+	return nil
+}
+
+func (r *ListingRepository) DeleteListingMedia(ctx context.Context, listingID, mediaID uuid.UUID) (*listing.ListingMedia, error) {
+	result, err := r.q.DeleteListingMedia(ctx, sqlc.DeleteListingMediaParams{
+		ListingID: listingID,
+		ID:        mediaID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toListingMedia(result), nil
+}
+
+func (r *ListingRepository) SetListingMediaPosition(ctx context.Context, listingID, mediaID uuid.UUID, position int32) error {
+	rows, err := r.q.SetListingMediaPosition(ctx, sqlc.SetListingMediaPositionParams{
+		ListingID: listingID,
+		ID:        mediaID,
+		Position:  position,
+	})
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+-- name: GetListingStatusForUpdate :one
+SELECT s.name
+FROM listings l
+JOIN listing_statuses s ON s.id = l.status_id
+WHERE l.id = $1
+FOR UPDATE OF l;
+
+-- name: GetListingStatusID :one
+SELECT id FROM listing_statuses
+WHERE name = $1;
+
+-- name: SetListingStatus :one
+UPDATE listings
+SET status_id = @status_id,
+    published_at = CASE WHEN @publish::bool THEN NOW() ELSE published_at END,
+    expires_at = CASE
+        WHEN @expires_in_seconds::float8 > 0 THEN NOW() + make_interval(secs => @expires_in_seconds::float8)
+        ELSE expires_at
+    END,
+    updated_at = NOW()
+WHERE id = @id
+RETURNING *;
+
+-- name: CreateListingTransition :one
+INSERT INTO listing_status_transitions (
+    id, listing_id, from_status_id, to_status_id, action, reason, changed_by
+) VALUES (
+    @id,
+    @listing_id,
+    (SELECT id FROM listing_statuses WHERE name = sqlc.narg('from_status')::text),
+    (SELECT id FROM listing_statuses WHERE name = @to_status::text),
+    @action,
+    @reason,
+    sqlc.narg('changed_by')
+)
+RETURNING created_at;
+
+-- name: ListListingTransitions :many
+SELECT t.id, t.listing_id, f.name AS from_status, s.name AS to_status,
+       t.action, t.reason, t.changed_by, t.created_at
+FROM listing_status_transitions t
+LEFT JOIN listing_statuses f ON f.id = t.from_status_id
+JOIN listing_statuses s ON s.id = t.to_status_id
+WHERE t.listing_id = $1
+ORDER BY t.created_at, t.id;
+
+-- name: ListExpiredListingIDs :many
+-- Listings can expire from the statuses the expire action applies to
+SELECT l.id
+FROM listings l
+JOIN listing_statuses s ON s.id = l.status_id
+WHERE s.name IN ('published', 'paused')
+  AND l.expires_at <= NOW()
+ORDER BY l.expires_at, l.id
+LIMIT @batch_size;
+
+-- name: IsListingExpired :one
+SELECT COALESCE(expires_at <= NOW(), false)::bool AS expired
+FROM listings
+WHERE id = $1;
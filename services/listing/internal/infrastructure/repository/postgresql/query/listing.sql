@@ -0,0 +1,55 @@
+-- name: CreateListing :one
+INSERT INTO listings (
+    id, title, description, category_id, price, currency_id,
+    condition_id, status_id, media_url, custom_fields, created_by
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11
+)
+RETURNING *;
+
+-- name: GetListing :one
+SELECT * FROM listings
+WHERE id = $1;
+
+-- name: ListListings :many
+SELECT * FROM listings
+WHERE (sqlc.narg('category_id')::uuid IS NULL OR category_id = sqlc.narg('category_id')::uuid)
+  AND (sqlc.narg('status_id')::uuid IS NULL OR status_id = sqlc.narg('status_id')::uuid)
+  AND (sqlc.narg('created_by')::uuid IS NULL OR created_by = sqlc.narg('created_by')::uuid)
+  AND (
+    sqlc.narg('after_id')::uuid IS NULL
+    OR (@sort_by::text = 'createdAt' AND NOT @descending::bool
+        AND (created_at, id) > (sqlc.narg('after_created_at')::timestamp, sqlc.narg('after_id')::uuid))
+    OR (@sort_by::text = 'createdAt' AND @descending::bool
+        AND (created_at, id) < (sqlc.narg('after_created_at')::timestamp, sqlc.narg('after_id')::uuid))
+    OR (@sort_by::text = 'title' AND NOT @descending::bool
+        AND (title, id) > (sqlc.narg('after_title')::text, sqlc.narg('after_id')::uuid))
+    OR (@sort_by::text = 'title' AND @descending::bool
+        AND (title, id) < (sqlc.narg('after_title')::text, sqlc.narg('after_id')::uuid))
+  )
+ORDER BY
+    CASE WHEN @sort_by::text = 'createdAt' AND NOT @descending::bool THEN created_at END ASC,
+    CASE WHEN @sort_by::text = 'createdAt' AND @descending::bool THEN created_at END DESC,
+    CASE WHEN @sort_by::text = 'title' AND NOT @descending::bool THEN title END ASC,
+    CASE WHEN @sort_by::text = 'title' AND @descending::bool THEN title END DESC,
+    CASE WHEN NOT @descending::bool THEN id END ASC,
+    CASE WHEN @descending::bool THEN id END DESC
+LIMIT @page_limit;
+
+-- name: UpdateListing :one
+UPDATE listings
+SET title = $2,
+    description = $3,
+    category_id = $4,
+    price = $5,
+    currency_id = $6,
+    condition_id = $7,
+    media_url = $8,
+    custom_fields = $9,
+    updated_at = NOW()
+WHERE id = $1
+RETURNING *;
+
+-- name: DeleteListing :exec
+DELETE FROM listings
+WHERE id = $1;
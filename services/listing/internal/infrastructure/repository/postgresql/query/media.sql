@@ -0,0 +1,28 @@
+-- name: ListListingMedia :many
+SELECT * FROM listing_media
+WHERE listing_id = $1
+ORDER BY position, created_at, id;
+
+-- name: CreateListingMedia :one
+INSERT INTO listing_media (
+    id, listing_id, position, storage_key, thumbnail_key,
+    content_type, size_bytes, width, height
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9
+)
+RETURNING created_at;
+
+-- name: DeleteListingMedia :one
+DELETE FROM listing_media
+WHERE listing_id = @listing_id AND id = @id
+RETURNING *;
+
+-- name: SetListingMediaPosition :execrows
+UPDATE listing_media
+SET position = @position
+WHERE listing_id = @listing_id AND id = @id;
+
+-- name: LockListingForMedia :one
+SELECT id FROM listings
+WHERE id = $1
+FOR UPDATE;
@@ -0,0 +1,50 @@
+-- Reference tables, keyed by the name of conditions and listing statuses and
+-- the code of currencies
+
+-- name: ListConditions :many
+SELECT id, name AS key, display_name, display_order, is_active, created_at, updated_at
+FROM conditions
+WHERE @include_inactive::bool OR is_active
+ORDER BY display_order, name;
+
+-- name: UpsertCondition :one
+INSERT INTO conditions (id, name, display_name, display_order, is_active)
+VALUES (@id, @key, @display_name, @display_order, @is_active)
+ON CONFLICT (name) DO UPDATE
+SET display_name = EXCLUDED.display_name,
+    display_order = EXCLUDED.display_order,
+    is_active = EXCLUDED.is_active,
+    updated_at = NOW()
+RETURNING id, name AS key, display_name, display_order, is_active, created_at, updated_at;
+
+-- name: ListCurrencies :many
+SELECT id, code AS key, name AS display_name, display_order, is_active, created_at, updated_at
+FROM currencies
+WHERE @include_inactive::bool OR is_active
+ORDER BY display_order, code;
+
+-- name: UpsertCurrency :one
+INSERT INTO currencies (id, code, name, display_order, is_active)
+VALUES (@id, @key, @display_name, @display_order, @is_active)
+ON CONFLICT (code) DO UPDATE
+SET name = EXCLUDED.name,
+    display_order = EXCLUDED.display_order,
+    is_active = EXCLUDED.is_active,
+    updated_at = NOW()
+RETURNING id, code AS key, name AS display_name, display_order, is_active, created_at, updated_at;
+
+-- name: ListListingStatuses :many
+SELECT id, name AS key, display_name, display_order, is_active, created_at, updated_at
+FROM listing_statuses
+WHERE @include_inactive::bool OR is_active
+ORDER BY display_order, name;
+
+-- name: UpsertListingStatus :one
+INSERT INTO listing_statuses (id, name, display_name, display_order, is_active)
+VALUES (@id, @key, @display_name, @display_order, @is_active)
+ON CONFLICT (name) DO UPDATE
+SET display_name = EXCLUDED.display_name,
+    display_order = EXCLUDED.display_order,
+    is_active = EXCLUDED.is_active,
+    updated_at = NOW()
+RETURNING id, name AS key, display_name, display_order, is_active, created_at, updated_at;
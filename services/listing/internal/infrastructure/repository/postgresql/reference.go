@@ -0,0 +1,112 @@
+package postgresql
+
+import (
+	"context"
+	"fmt"
+
+	listing "localloop/services/listing/internal/domain"
+	"localloop/services/listing/internal/infrastructure/repository/postgresql/sqlc"
+
+	"github.com/google/uuid"
+)
+
+// referenceRow is the shape every reference query returns, with the natural
+// key and display name of each table aliased to key and display_name
+type referenceRow = sqlc.ListConditionsRow
+
+func (r *ListingRepository) ListReferenceItems(ctx context.Context, kind listing.ReferenceKind, includeInactive bool) ([]*listing.ReferenceItem, error) {
+	var rows []referenceRow
+	switch kind {
+	case listing.ReferenceConditions:
+		results, err := r.q.ListConditions(ctx, includeInactive)
+		if err != nil {
+			return nil, err
+		}
+		rows = results
+	case listing.ReferenceCurrencies:
+		results, err := r.q.ListCurrencies(ctx, includeInactive)
+		if err != nil {
+			return nil, err
+		}
+		for _, result := range results {
+			rows = append(rows, referenceRow(result))
+		}
+	case listing.ReferenceStatuses:
+		results, err := r.q.ListListingStatuses(ctx, includeInactive)
+		if err != nil {
+			return nil, err
+		}
+		for _, result := range results {
+			rows = append(rows, referenceRow(result))
+		}
+	default:
+		return nil, fmt.Errorf("unknown reference kind %q", kind)
+	}
+
+	items := make([]*listing.ReferenceItem, len(rows))
+	for i, row := range rows {
+		items[i] = toReferenceItem(kind, row)
+	}
+	return items, nil
+}
+
+// UpsertReferenceItem inserts an item with a new ID, or updates the item
+// with the same key
+func (r *ListingRepository) UpsertReferenceItem(ctx context.Context, params listing.UpsertReferenceItemParams) (*listing.ReferenceItem, error) {
+	var row referenceRow
+	switch params.Kind {
+	case listing.ReferenceConditions:
+		result, err := r.q.UpsertCondition(ctx, sqlc.UpsertConditionParams{
+			ID:           uuid.New(),
+			Key:          params.Key,
+			DisplayName:  params.DisplayName,
+			DisplayOrder: params.DisplayOrder,
+			IsActive:     params.IsActive,
+		})
+		if err != nil {
+			return nil, err
+		}
+		row = referenceRow(result)
+	case listing.ReferenceCurrencies:
+		result, err := r.q.UpsertCurrency(ctx, sqlc.UpsertCurrencyParams{
+			ID:           uuid.New(),
+			Key:          params.Key,
+			DisplayName:  params.DisplayName,
+			DisplayOrder: params.DisplayOrder,
+			IsActive:     params.IsActive,
+		})
+		if err != nil {
+			return nil, err
+		}
+		row = referenceRow(result)
+	case listing.ReferenceStatuses:
+		result, err := r.q.UpsertListingStatus(ctx, sqlc.UpsertListingStatusParams{
+			ID:           uuid.New(),
+			Key:          params.Key,
+			DisplayName:  params.DisplayName,
+			DisplayOrder: params.DisplayOrder,
+			IsActive:     params.IsActive,
+		})
+		if err != nil {
+			return nil, err
+		}
+		row = referenceRow(result)
+	default:
+		return nil, fmt.Errorf("unknown reference kind %q", params.Kind)
+	}
+
+	return toReferenceItem(params.Kind, row), nil
+}
+
+func toReferenceItem(kind listing.ReferenceKind, row referenceRow) *listing.ReferenceItem {
+	return &listing.ReferenceItem{
+		ID:           row.ID,
+		Kind:         kind,
+		Key:          row.Key,
+		DisplayName:  row.DisplayName,
+		DisplayOrder: row.DisplayOrder,
+		IsActive:     row.IsActive,
+		CreatedAt:    row.CreatedAt,
+		UpdatedAt:    row.UpdatedAt,
+	}
+}
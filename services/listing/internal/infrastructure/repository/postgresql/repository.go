@@ -0,0 +1,268 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"localloop/libs/pkg/pagination"
+	listing "localloop/services/listing/internal/domain"
+	"localloop/services/listing/internal/infrastructure/repository/postgresql/sqlc"
+
+	"github.com/google/uuid"
+)
+
+type ListingRepository struct {
+	db *sql.DB
+	q  *sqlc.Queries
+}
+
+func NewListingRepository(db *sql.DB) *ListingRepository {
+	return &ListingRepository{
+		db: db,
+		q:  sqlc.New(db),
+	}
+}
+
+// WithTx runs fn with a repository bound to a new transaction, which is
+// committed when fn succeeds and rolled back otherwise. Calls made on a
+// repository that is already bound to a transaction join it.
+func (r *ListingRepository) WithTx(ctx context.Context, fn func(repo listing.Repository) error) error {
+	if r.db == nil {
+		return fn(r)
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			_ = tx.Rollback()
+			panic(p)
+		}
+	}()
+
+	if err := fn(&ListingRepository{q: r.q.WithTx(tx)}); err != nil {
+		if rbErr := tx.Rollback(); rbErr != nil {
+			return fmt.Errorf("failed to roll back transaction: %v (original error: %w)", rbErr, err)
+		}
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+func unmarshalJSON[T any](data json.RawMessage) (T, error) {
+	var result T
+	if err := json.Unmarshal(data, &result); err != nil {
+		return result, fmt.Errorf("failed to unmarshal JSON: %w", err)
+	}
+	return result, nil
+}
+
+func toNullUUID(id *uuid.UUID) uuid.NullUUID {
+	if id == nil {
+		return uuid.NullUUID{UUID: uuid.Nil, Valid: false}
+	}
+	return uuid.NullUUID{UUID: *id, Valid: true}
+}
+
+func fromNullUUID(id uuid.NullUUID) *uuid.UUID {
+	if !id.Valid {
+		return nil
+	}
+	value := id.UUID
+	return &value
+}
+
+func toNullDecimal(value *float64) sql.NullString {
+	if value == nil {
+		return sql.NullString{}
+	}
+	return sql.NullString{String: strconv.FormatFloat(*value, 'f', -1, 64), Valid: true}
+}
+
+func fromNullDecimal(value sql.NullString) (*float64, error) {
+	if !value.Valid {
+		return nil, nil
+	}
+	parsed, err := strconv.ParseFloat(value.String, 64)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse decimal: %w", err)
+	}
+	return &parsed, nil
+}
+
+func toListing(result sqlc.Listing) (*listing.Listing, error) {
+	price, err := fromNullDecimal(result.Price)
+	if err != nil {
+		return nil, err
+	}
+
+	customFields, err := unmarshalJSON[map[string]interface{}](result.CustomFields)
+	if err != nil {
+		return nil, err
+	}
+
+	var publishedAt *time.Time
+	if result.PublishedAt.Valid {
+		t := result.PublishedAt.Time
+		publishedAt = &t
+	}
+
+	var expiresAt *time.Time
+	if result.ExpiresAt.Valid {
+		t := result.ExpiresAt.Time
+		expiresAt = &t
+	}
+
+	return &listing.Listing{
+		ID:           result.ID,
+		Title:        result.Title,
+		Description:  result.Description.String,
+		CategoryID:   result.CategoryID,
+		Price:        price,
+		CurrencyID:   fromNullUUID(result.CurrencyID),
+		ConditionID:  fromNullUUID(result.ConditionID),
+		StatusID:     result.StatusID,
+		MediaURL:     result.MediaUrl.String,
+		CustomFields: customFields,
+		CreatedBy:    result.CreatedBy,
+		CreatedAt:    result.CreatedAt,
+		UpdatedAt:    result.UpdatedAt,
+		PublishedAt:  publishedAt,
+		ExpiresAt:    expiresAt,
+	}, nil
+}
+
+func (r *ListingRepository) CreateListing(ctx context.Context, l *listing.Listing) error {
+	customFields, err := json.Marshal(l.CustomFields)
+	if err != nil {
+		return fmt.Errorf("failed to marshal custom fields: %w", err)
+	}
+
+	params := sqlc.CreateListingParams{
+		ID:           l.ID,
+		Title:        l.Title,
+		Description:  sql.NullString{String: l.Description, Valid: l.Description != ""},
+		CategoryID:   l.CategoryID,
+		Price:        toNullDecimal(l.Price),
+		CurrencyID:   toNullUUID(l.CurrencyID),
+		ConditionID:  toNullUUID(l.ConditionID),
+		StatusID:     l.StatusID,
+		MediaUrl:     sql.NullString{String: l.MediaURL, Valid: l.MediaURL != ""},
+		CustomFields: customFields,
+		CreatedBy:    l.CreatedBy,
+	}
+
+	result, err := r.q.CreateListing(ctx, params)
+	if err != nil {
+		return err
+	}
+
+	l.CreatedAt = result.CreatedAt
+	l.UpdatedAt = result.UpdatedAt
+	return nil
+}
+
+func (r *ListingRepository) GetListing(ctx context.Context, id uuid.UUID) (*listing.Listing, error) {
+	result, err := r.q.GetListing(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return toListing(result)
+}
+
+func (r *ListingRepository) UpdateListing(ctx context.Context, l *listing.Listing) error {
+	customFields, err := json.Marshal(l.CustomFields)
+	if err != nil {
+		return fmt.Errorf("failed to marshal custom fields: %w", err)
+	}
+
+	params := sqlc.UpdateListingParams{
+		ID:           l.ID,
+		Title:        l.Title,
+		Description:  sql.NullString{String: l.Description, Valid: l.Description != ""},
+		CategoryID:   l.CategoryID,
+		Price:        toNullDecimal(l.Price),
+		CurrencyID:   toNullUUID(l.CurrencyID),
+		ConditionID:  toNullUUID(l.ConditionID),
+		MediaUrl:     sql.NullString{String: l.MediaURL, Valid: l.MediaURL != ""},
+		CustomFields: customFields,
+	}
+
+	result, err := r.q.UpdateListing(ctx, params)
+	if err != nil {
+		return err
+	}
+
+	l.UpdatedAt = result.UpdatedAt
+	return nil
+}
+
+func (r *ListingRepository) DeleteListing(ctx context.Context, id uuid.UUID) error {
+	return r.q.DeleteListing(ctx, id)
+}
+
+func (r *ListingRepository) ListListings(ctx context.Context, query pagination.Query) (pagination.Page[*listing.Listing], error) {
+	params := sqlc.ListListingsParams{
+		SortBy:     query.Sort,
+		Descending: query.Descending,
+		PageLimit:  int32(query.FetchLimit()),
+	}
+
+	var err error
+	if params.CategoryID, err = query.UUIDFilter("categoryId"); err != nil {
+		return pagination.Page[*listing.Listing]{}, err
+	}
+	if params.StatusID, err = query.UUIDFilter("statusId"); err != nil {
+		return pagination.Page[*listing.Listing]{}, err
+	}
+	if params.CreatedBy, err = query.UUIDFilter("createdBy"); err != nil {
+		return pagination.Page[*listing.Listing]{}, err
+	}
+
+	if after := query.After; after != nil {
+		params.AfterID = uuid.NullUUID{UUID: after.ID, Valid: true}
+		switch query.Sort {
+		case listing.SortByTitle:
+			params.AfterTitle = sql.NullString{String: after.Key, Valid: true}
+		default:
+			createdAt, err := after.Time()
+			if err != nil {
+				return pagination.Page[*listing.Listing]{}, err
+			}
+			params.AfterCreatedAt = sql.NullTime{Time: createdAt, Valid: true}
+		}
+	}
+
+	results, err := r.q.ListListings(ctx, params)
+	if err != nil {
+		return pagination.Page[*listing.Listing]{}, err
+	}
+
+	listings := make([]*listing.Listing, len(results))
+	for i, result := range results {
+		l, err := toListing(result)
+		if err != nil {
+			return pagination.Page[*listing.Listing]{}, err
+		}
+		listings[i] = l
+	}
+
+	return pagination.NewPage(listings, query, func(l *listing.Listing) (string, uuid.UUID) {
+		if query.Sort == listing.SortByTitle {
+			return l.Title, l.ID
+		}
+		return pagination.TimeKey(l.CreatedAt), l.ID
+	}), nil
+}
@@ -0,0 +1,338 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	listing "localloop/services/listing/internal/domain"
+	"localloop/services/listing/internal/infrastructure/repository/postgresql/sqlc"
+
+	"github.com/google/uuid"
+)
+
+// Search queries are assembled dynamically since sqlc cannot express an
+// arbitrary number of optional filters. Keep listingColumns in sync with
+// the listings table and sqlc.Listing.
+const listingColumns = `id, title, description, category_id, price, currency_id,
+    condition_id, status_id, media_url, custom_fields, created_by, created_at,
+    updated_at, published_at, expires_at`
+
+// Facet dimensions. A filter tagged with a dimension is left out when
+// counting that dimension's facet, so every value of the facet reports how
+// many listings selecting it would yield.
+const (
+	facetCategory  = "category"
+	facetCondition = "condition"
+	facetCurrency  = "currency"
+	facetStatus    = "status"
+	facetPrice     = "price"
+)
+
+type sqlArgs struct {
+	values []any
+}
+
+func (a *sqlArgs) add(value any) string {
+	a.values = append(a.values, value)
+	return "$" + strconv.Itoa(len(a.values))
+}
+
+type searchFilter struct {
+	facet string
+	build func(a *sqlArgs) string
+}
+
+func customFieldFacet(key string) string {
+	return "cf:" + key
+}
+
+func inUUIDs(column string, ids []uuid.UUID) func(a *sqlArgs) string {
+	return func(a *sqlArgs) string {
+		placeholders := make([]string, len(ids))
+		for i, id := range ids {
+			placeholders[i] = a.add(id)
+		}
+		return fmt.Sprintf("%s IN (%s)", column, strings.Join(placeholders, ", "))
+	}
+}
+
+func buildSearchFilters(criteria listing.SearchCriteria) ([]searchFilter, error) {
+	var filters []searchFilter
+
+	if len(criteria.CategoryIDs) > 0 {
+		filters = append(filters, searchFilter{facetCategory, inUUIDs("category_id", criteria.CategoryIDs)})
+	}
+	if len(criteria.ConditionIDs) > 0 {
+		filters = append(filters, searchFilter{facetCondition, inUUIDs("condition_id", criteria.ConditionIDs)})
+	}
+	if len(criteria.CurrencyIDs) > 0 {
+		filters = append(filters, searchFilter{facetCurrency, inUUIDs("currency_id", criteria.CurrencyIDs)})
+	}
+	if len(criteria.StatusIDs) > 0 {
+		// Default statuses are not a choice the status facet offers to undo
+		facet := facetStatus
+		if criteria.StatusDefaulted {
+			facet = ""
+		}
+		filters = append(filters, searchFilter{facet, inUUIDs("status_id", criteria.StatusIDs)})
+	}
+	if criteria.MinPrice != nil {
+		min := *criteria.MinPrice
+		filters = append(filters, searchFilter{facetPrice, func(a *sqlArgs) string {
+			return "price >= " + a.add(min)
+		}})
+	}
+	if criteria.MaxPrice != nil {
+		max := *criteria.MaxPrice
+		filters = append(filters, searchFilter{facetPrice, func(a *sqlArgs) string {
+			return "price <= " + a.add(max)
+		}})
+	}
+
+	for _, predicate := range criteria.CustomFields {
+		filter, err := customFieldFilter(predicate)
+		if err != nil {
+			return nil, err
+		}
+		filters = append(filters, filter)
+	}
+
+	return filters, nil
+}
+
+func customFieldFilter(predicate listing.CustomFieldPredicate) (searchFilter, error) {
+	key := predicate.Key
+	facet := customFieldFacet(key)
+
+	if predicate.Operator == listing.OpEq {
+		// Containment lets Postgres use the GIN index on custom_fields
+		typed, err := json.Marshal(map[string]interface{}{key: predicate.Value})
+		if err != nil {
+			return searchFilter{}, fmt.Errorf("failed to marshal custom field predicate: %w", err)
+		}
+
+		if _, isString := predicate.Value.(string); isString {
+			return searchFilter{facet, func(a *sqlArgs) string {
+				return fmt.Sprintf("custom_fields @> %s::jsonb", a.add(string(typed)))
+			}}, nil
+		}
+
+		// Also match values that were stored as strings, e.g. "42" for 42
+		untyped, err := json.Marshal(map[string]interface{}{key: fmt.Sprint(predicate.Value)})
+		if err != nil {
+			return searchFilter{}, fmt.Errorf("failed to marshal custom field predicate: %w", err)
+		}
+		return searchFilter{facet, func(a *sqlArgs) string {
+			return fmt.Sprintf("(custom_fields @> %s::jsonb OR custom_fields @> %s::jsonb)",
+				a.add(string(typed)), a.add(string(untyped)))
+		}}, nil
+	}
+
+	operators := map[string]string{
+		listing.OpGt:  ">",
+		listing.OpGte: ">=",
+		listing.OpLt:  "<",
+		listing.OpLte: "<=",
+	}
+	op, ok := operators[predicate.Operator]
+	if !ok {
+		return searchFilter{}, fmt.Errorf("unsupported custom field operator %q", predicate.Operator)
+	}
+
+	return searchFilter{facet, func(a *sqlArgs) string {
+		k := a.add(key) + "::text"
+		// The CASE guards the cast against non-numeric values
+		return fmt.Sprintf("CASE WHEN jsonb_typeof(custom_fields -> %s) = 'number' THEN (custom_fields ->> %s)::numeric END %s %s",
+			k, k, op, a.add(predicate.Value))
+	}}, nil
+}
+
+func whereClause(filters []searchFilter, a *sqlArgs, extra ...string) string {
+	var conditions []string
+	for _, filter := range filters {
+		conditions = append(conditions, filter.build(a))
+	}
+	conditions = append(conditions, extra...)
+
+	if len(conditions) == 0 {
+		return ""
+	}
+	return "WHERE " + strings.Join(conditions, "\n  AND ")
+}
+
+func (r *ListingRepository) SearchListings(ctx context.Context, criteria listing.SearchCriteria) ([]*listing.Listing, error) {
+	filters, err := buildSearchFilters(criteria)
+	if err != nil {
+		return nil, err
+	}
+
+	args := &sqlArgs{}
+	var extra []string
+	if criteria.After != nil {
+		extra = append(extra, fmt.Sprintf("(created_at, id) < (%s, %s)",
+			args.add(criteria.After.CreatedAt), args.add(criteria.After.ID)))
+	}
+
+	query := fmt.Sprintf("SELECT %s FROM listings\n%s\nORDER BY created_at DESC, id DESC\nLIMIT %s",
+		listingColumns, whereClause(filters, args, extra...), args.add(criteria.Limit))
+
+	rows, err := r.db.QueryContext(ctx, query, args.values...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	listings := []*listing.Listing{}
+	for rows.Next() {
+		var i sqlc.Listing
+		if err := rows.Scan(
+			&i.ID,
+			&i.Title,
+			&i.Description,
+			&i.CategoryID,
+			&i.Price,
+			&i.CurrencyID,
+			&i.ConditionID,
+			&i.StatusID,
+			&i.MediaUrl,
+			&i.CustomFields,
+			&i.CreatedBy,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.PublishedAt,
+			&i.ExpiresAt,
+		); err != nil {
+			return nil, err
+		}
+
+		l, err := toListing(i)
+		if err != nil {
+			return nil, err
+		}
+		listings = append(listings, l)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return listings, nil
+}
+
+// facetDimension is a facet being counted, value is the SQL expression of
+// the values it counts
+type facetDimension struct {
+	facet  string
+	value  string
+	counts []listing.FacetCount
+}
+
+// CountListingFacets counts every facet in a single query. The listings
+// matching the filters no facet leaves out are read once, each row carrying
+// the value of every dimension and whether it matches the filters of each
+// dimension. Every facet then counts the rows matching the filters of the
+// other dimensions.
+func (r *ListingRepository) CountListingFacets(ctx context.Context, criteria listing.SearchCriteria) (*listing.SearchFacets, error) {
+	filters, err := buildSearchFilters(criteria)
+	if err != nil {
+		return nil, err
+	}
+
+	args := &sqlArgs{}
+	dimensions := []*facetDimension{
+		{facet: facetCategory, value: "category_id::text"},
+		{facet: facetCondition, value: "condition_id::text"},
+		{facet: facetCurrency, value: "currency_id::text"},
+		{facet: facetStatus, value: "status_id::text"},
+	}
+	index := make(map[string]int, len(dimensions)+len(criteria.FacetFields))
+	for i, d := range dimensions {
+		index[d.facet] = i
+	}
+	for _, key := range criteria.FacetFields {
+		if _, ok := index[customFieldFacet(key)]; ok {
+			continue
+		}
+		index[customFieldFacet(key)] = len(dimensions)
+		dimensions = append(dimensions, &facetDimension{
+			facet: customFieldFacet(key),
+			value: "custom_fields ->> " + args.add(key) + "::text",
+		})
+	}
+
+	for _, d := range dimensions {
+		d.counts = []listing.FacetCount{}
+	}
+
+	// Filters of a dimension become a match column, the others narrow the
+	// rows that are read
+	var shared []searchFilter
+	matches := make([][]string, len(dimensions))
+	for _, filter := range filters {
+		i, ok := index[filter.facet]
+		if !ok {
+			shared = append(shared, filter)
+			continue
+		}
+		matches[i] = append(matches[i], filter.build(args))
+	}
+
+	columns := make([]string, 0, 2*len(dimensions))
+	for i, d := range dimensions {
+		columns = append(columns, fmt.Sprintf("%s AS v%d", d.value, i))
+		if len(matches[i]) > 0 {
+			columns = append(columns, fmt.Sprintf("(%s) AS m%d", strings.Join(matches[i], " AND "), i))
+		}
+	}
+
+	counts := make([]string, len(dimensions))
+	for i := range dimensions {
+		conditions := []string{fmt.Sprintf("v%d IS NOT NULL", i)}
+		for j := range dimensions {
+			if j != i && len(matches[j]) > 0 {
+				conditions = append(conditions, fmt.Sprintf("m%d", j))
+			}
+		}
+		counts[i] = fmt.Sprintf("SELECT %d, v%d, COUNT(*) FROM matched WHERE %s GROUP BY v%d",
+			i, i, strings.Join(conditions, " AND "), i)
+	}
+
+	query := fmt.Sprintf("WITH matched AS (\nSELECT %s FROM listings\n%s\n)\n%s\nORDER BY 1, 3 DESC, 2",
+		strings.Join(columns, ", "), whereClause(shared, args), strings.Join(counts, "\nUNION ALL\n"))
+
+	rows, err := r.db.QueryContext(ctx, query, args.values...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var dimension int
+		var value sql.NullString
+		var count int64
+		if err := rows.Scan(&dimension, &value, &count); err != nil {
+			return nil, err
+		}
+		d := dimensions[dimension]
+		d.counts = append(d.counts, listing.FacetCount{Value: value.String, Count: count})
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	facets := &listing.SearchFacets{
+		Categories:   dimensions[index[facetCategory]].counts,
+		Conditions:   dimensions[index[facetCondition]].counts,
+		Currencies:   dimensions[index[facetCurrency]].counts,
+		Statuses:     dimensions[index[facetStatus]].counts,
+		CustomFields: make(map[string][]listing.FacetCount),
+	}
+	for _, key := range criteria.FacetFields {
+		facets.CustomFields[key] = dimensions[index[customFieldFacet(key)]].counts
+	}
+
+	return facets, nil
+}
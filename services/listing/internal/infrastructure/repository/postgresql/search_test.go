@@ -0,0 +1,82 @@
+package postgresql
+
+import (
+	"strings"
+	"testing"
+
+	listing "localloop/services/listing/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+func TestBuildSearchFilters(t *testing.T) {
+	min := 10.0
+	statusIDs := []uuid.UUID{uuid.New()}
+
+	tests := []struct {
+		name       string
+		criteria   listing.SearchCriteria
+		wantFacets []string
+		wantSQL    []string
+	}{
+		{
+			name:       "chosen statuses are a facet filter",
+			criteria:   listing.SearchCriteria{StatusIDs: statusIDs},
+			wantFacets: []string{facetStatus},
+			wantSQL:    []string{"status_id IN ($1)"},
+		},
+		{
+			name:       "default statuses narrow every facet",
+			criteria:   listing.SearchCriteria{StatusIDs: statusIDs, StatusDefaulted: true},
+			wantFacets: []string{""},
+			wantSQL:    []string{"status_id IN ($1)"},
+		},
+		{
+			name: "price and custom fields",
+			criteria: listing.SearchCriteria{
+				MinPrice: &min,
+				CustomFields: []listing.CustomFieldPredicate{
+					{Key: "size", Operator: listing.OpEq, Value: "M"},
+					{Key: "weight", Operator: listing.OpLte, Value: 2.0},
+				},
+			},
+			wantFacets: []string{facetPrice, customFieldFacet("size"), customFieldFacet("weight")},
+			wantSQL: []string{
+				"price >= $1",
+				"custom_fields @> $2::jsonb",
+				"(custom_fields ->> $3::text)::numeric END <= $4",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filters, err := buildSearchFilters(tt.criteria)
+			if err != nil {
+				t.Fatalf("buildSearchFilters() error = %v", err)
+			}
+			if len(filters) != len(tt.wantFacets) {
+				t.Fatalf("got %d filters, want %d", len(filters), len(tt.wantFacets))
+			}
+
+			args := &sqlArgs{}
+			for i, filter := range filters {
+				if filter.facet != tt.wantFacets[i] {
+					t.Errorf("filter %d facet = %q, want %q", i, filter.facet, tt.wantFacets[i])
+				}
+				if sql := filter.build(args); !strings.Contains(sql, tt.wantSQL[i]) {
+					t.Errorf("filter %d = %q, want it to contain %q", i, sql, tt.wantSQL[i])
+				}
+			}
+		})
+	}
+}
+
+func TestBuildSearchFiltersRejectsUnknownOperators(t *testing.T) {
+	_, err := buildSearchFilters(listing.SearchCriteria{
+		CustomFields: []listing.CustomFieldPredicate{{Key: "size", Operator: "like", Value: "M"}},
+	})
+	if err == nil {
+		t.Fatal("buildSearchFilters() error = nil, want an error")
+	}
+}
@@ -0,0 +1,229 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.27.0
+// source: lifecycle.sql
+
+package sqlc
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const createListingTransition = `-- name: CreateListingTransition :one
+INSERT INTO listing_status_transitions (
+    id, listing_id, from_status_id, to_status_id, action, reason, changed_by
+) VALUES (
+    $1,
+    $2,
+    (SELECT id FROM listing_statuses WHERE name = $3::text),
+    (SELECT id FROM listing_statuses WHERE name = $4::text),
+    $5,
+    $6,
+    $7
+)
+RETURNING created_at
+`
+
+type CreateListingTransitionParams struct {
+	ID         uuid.UUID      `json:"id"`
+	ListingID  uuid.UUID      `json:"listingId"`
+	FromStatus sql.NullString `json:"fromStatus"`
+	ToStatus   string         `json:"toStatus"`
+	Action     string         `json:"action"`
+	Reason     string         `json:"reason"`
+	ChangedBy  uuid.NullUUID  `json:"changedBy"`
+}
+
+func (q *Queries) CreateListingTransition(ctx context.Context, arg CreateListingTransitionParams) (time.Time, error) {
+	row := q.db.QueryRowContext(ctx, createListingTransition,
+		arg.ID,
+		arg.ListingID,
+		arg.FromStatus,
+		arg.ToStatus,
+		arg.Action,
+		arg.Reason,
+		arg.ChangedBy,
+	)
+	var created_at time.Time
+	err := row.Scan(&created_at)
+	return created_at, err
+}
+
+const getListingStatusForUpdate = `-- name: GetListingStatusForUpdate :one
+SELECT s.name
+FROM listings l
+JOIN listing_statuses s ON s.id = l.status_id
+WHERE l.id = $1
+FOR UPDATE OF l
+`
+
+func (q *Queries) GetListingStatusForUpdate(ctx context.Context, id uuid.UUID) (string, error) {
+	row := q.db.QueryRowContext(ctx, getListingStatusForUpdate, id)
+	var name string
+	err := row.Scan(&name)
+	return name, err
+}
+
+const getListingStatusID = `-- name: GetListingStatusID :one
+SELECT id FROM listing_statuses
+WHERE name = $1
+`
+
+func (q *Queries) GetListingStatusID(ctx context.Context, name string) (uuid.UUID, error) {
+	row := q.db.QueryRowContext(ctx, getListingStatusID, name)
+	var id uuid.UUID
+	err := row.Scan(&id)
+	return id, err
+}
+
+const isListingExpired = `-- name: IsListingExpired :one
+SELECT COALESCE(expires_at <= NOW(), false)::bool AS expired
+FROM listings
+WHERE id = $1
+`
+
+func (q *Queries) IsListingExpired(ctx context.Context, id uuid.UUID) (bool, error) {
+	row := q.db.QueryRowContext(ctx, isListingExpired, id)
+	var expired bool
+	err := row.Scan(&expired)
+	return expired, err
+}
+
+const listExpiredListingIDs = `-- name: ListExpiredListingIDs :many
+SELECT l.id
+FROM listings l
+JOIN listing_statuses s ON s.id = l.status_id
+WHERE s.name IN ('published', 'paused')
+  AND l.expires_at <= NOW()
+ORDER BY l.expires_at, l.id
+LIMIT $1
+`
+
+// Listings can expire from the statuses the expire action applies to
+func (q *Queries) ListExpiredListingIDs(ctx context.Context, batchSize int32) ([]uuid.UUID, error) {
+	rows, err := q.db.QueryContext(ctx, listExpiredListingIDs, batchSize)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []uuid.UUID{}
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listListingTransitions = `-- name: ListListingTransitions :many
+SELECT t.id, t.listing_id, f.name AS from_status, s.name AS to_status,
+       t.action, t.reason, t.changed_by, t.created_at
+FROM listing_status_transitions t
+LEFT JOIN listing_statuses f ON f.id = t.from_status_id
+JOIN listing_statuses s ON s.id = t.to_status_id
+WHERE t.listing_id = $1
+ORDER BY t.created_at, t.id
+`
+
+type ListListingTransitionsRow struct {
+	ID         uuid.UUID      `json:"id"`
+	ListingID  uuid.UUID      `json:"listingId"`
+	FromStatus sql.NullString `json:"fromStatus"`
+	ToStatus   string         `json:"toStatus"`
+	Action     string         `json:"action"`
+	Reason     string         `json:"reason"`
+	ChangedBy  uuid.NullUUID  `json:"changedBy"`
+	CreatedAt  time.Time      `json:"createdAt"`
+}
+
+func (q *Queries) ListListingTransitions(ctx context.Context, listingID uuid.UUID) ([]ListListingTransitionsRow, error) {
+	rows, err := q.db.QueryContext(ctx, listListingTransitions, listingID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListListingTransitionsRow{}
+	for rows.Next() {
+		var i ListListingTransitionsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.ListingID,
+			&i.FromStatus,
+			&i.ToStatus,
+			&i.Action,
+			&i.Reason,
+			&i.ChangedBy,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const setListingStatus = `-- name: SetListingStatus :one
+UPDATE listings
+SET status_id = $1,
+    published_at = CASE WHEN $2::bool THEN NOW() ELSE published_at END,
+    expires_at = CASE
+        WHEN $3::float8 > 0 THEN NOW() + make_interval(secs => $3::float8)
+        ELSE expires_at
+    END,
+    updated_at = NOW()
+WHERE id = $4
+RETURNING id, title, description, category_id, price, currency_id, condition_id, status_id, media_url, custom_fields, created_by, created_at, updated_at, published_at, expires_at
+`
+
+type SetListingStatusParams struct {
+	StatusID         uuid.UUID `json:"statusId"`
+	Publish          bool      `json:"publish"`
+	ExpiresInSeconds float64   `json:"expiresInSeconds"`
+	ID               uuid.UUID `json:"id"`
+}
+
+func (q *Queries) SetListingStatus(ctx context.Context, arg SetListingStatusParams) (Listing, error) {
+	row := q.db.QueryRowContext(ctx, setListingStatus,
+		arg.StatusID,
+		arg.Publish,
+		arg.ExpiresInSeconds,
+		arg.ID,
+	)
+	var i Listing
+	err := row.Scan(
+		&i.ID,
+		&i.Title,
+		&i.Description,
+		&i.CategoryID,
+		&i.Price,
+		&i.CurrencyID,
+		&i.ConditionID,
+		&i.StatusID,
+		&i.MediaUrl,
+		&i.CustomFields,
+		&i.CreatedBy,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.PublishedAt,
+		&i.ExpiresAt,
+	)
+	return i, err
+}
@@ -0,0 +1,258 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.27.0
+// source: listing.sql
+
+package sqlc
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+
+	"github.com/google/uuid"
+)
+
+const createListing = `-- name: CreateListing :one
+INSERT INTO listings (
+    id, title, description, category_id, price, currency_id,
+    condition_id, status_id, media_url, custom_fields, created_by
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11
+)
+RETURNING id, title, description, category_id, price, currency_id, condition_id, status_id, media_url, custom_fields, created_by, created_at, updated_at, published_at, expires_at
+`
+
+type CreateListingParams struct {
+	ID           uuid.UUID       `json:"id"`
+	Title        string          `json:"title"`
+	Description  sql.NullString  `json:"description"`
+	CategoryID   uuid.UUID       `json:"categoryId"`
+	Price        sql.NullString  `json:"price"`
+	CurrencyID   uuid.NullUUID   `json:"currencyId"`
+	ConditionID  uuid.NullUUID   `json:"conditionId"`
+	StatusID     uuid.UUID       `json:"statusId"`
+	MediaUrl     sql.NullString  `json:"mediaUrl"`
+	CustomFields json.RawMessage `json:"customFields"`
+	CreatedBy    uuid.UUID       `json:"createdBy"`
+}
+
+func (q *Queries) CreateListing(ctx context.Context, arg CreateListingParams) (Listing, error) {
+	row := q.db.QueryRowContext(ctx, createListing,
+		arg.ID,
+		arg.Title,
+		arg.Description,
+		arg.CategoryID,
+		arg.Price,
+		arg.CurrencyID,
+		arg.ConditionID,
+		arg.StatusID,
+		arg.MediaUrl,
+		arg.CustomFields,
+		arg.CreatedBy,
+	)
+	var i Listing
+	err := row.Scan(
+		&i.ID,
+		&i.Title,
+		&i.Description,
+		&i.CategoryID,
+		&i.Price,
+		&i.CurrencyID,
+		&i.ConditionID,
+		&i.StatusID,
+		&i.MediaUrl,
+		&i.CustomFields,
+		&i.CreatedBy,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.PublishedAt,
+		&i.ExpiresAt,
+	)
+	return i, err
+}
+
+const deleteListing = `-- name: DeleteListing :exec
+DELETE FROM listings
+WHERE id = $1
+`
+
+func (q *Queries) DeleteListing(ctx context.Context, id uuid.UUID) error {
+	_, err := q.db.ExecContext(ctx, deleteListing, id)
+	return err
+}
+
+const getListing = `-- name: GetListing :one
+SELECT id, title, description, category_id, price, currency_id, condition_id, status_id, media_url, custom_fields, created_by, created_at, updated_at, published_at, expires_at FROM listings
+WHERE id = $1
+`
+
+func (q *Queries) GetListing(ctx context.Context, id uuid.UUID) (Listing, error) {
+	row := q.db.QueryRowContext(ctx, getListing, id)
+	var i Listing
+	err := row.Scan(
+		&i.ID,
+		&i.Title,
+		&i.Description,
+		&i.CategoryID,
+		&i.Price,
+		&i.CurrencyID,
+		&i.ConditionID,
+		&i.StatusID,
+		&i.MediaUrl,
+		&i.CustomFields,
+		&i.CreatedBy,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.PublishedAt,
+		&i.ExpiresAt,
+	)
+	return i, err
+}
+
+const listListings = `-- name: ListListings :many
+SELECT id, title, description, category_id, price, currency_id, condition_id, status_id, media_url, custom_fields, created_by, created_at, updated_at, published_at, expires_at FROM listings
+WHERE ($1::uuid IS NULL OR category_id = $1::uuid)
+  AND ($2::uuid IS NULL OR status_id = $2::uuid)
+  AND ($3::uuid IS NULL OR created_by = $3::uuid)
+  AND (
+    $4::uuid IS NULL
+    OR ($5::text = 'createdAt' AND NOT $6::bool
+        AND (created_at, id) > ($7::timestamp, $4::uuid))
+    OR ($5::text = 'createdAt' AND $6::bool
+        AND (created_at, id) < ($7::timestamp, $4::uuid))
+    OR ($5::text = 'title' AND NOT $6::bool
+        AND (title, id) > ($8::text, $4::uuid))
+    OR ($5::text = 'title' AND $6::bool
+        AND (title, id) < ($8::text, $4::uuid))
+  )
+ORDER BY
+    CASE WHEN $5::text = 'createdAt' AND NOT $6::bool THEN created_at END ASC,
+    CASE WHEN $5::text = 'createdAt' AND $6::bool THEN created_at END DESC,
+    CASE WHEN $5::text = 'title' AND NOT $6::bool THEN title END ASC,
+    CASE WHEN $5::text = 'title' AND $6::bool THEN title END DESC,
+    CASE WHEN NOT $6::bool THEN id END ASC,
+    CASE WHEN $6::bool THEN id END DESC
+LIMIT $9
+`
+
+type ListListingsParams struct {
+	CategoryID     uuid.NullUUID  `json:"categoryId"`
+	StatusID       uuid.NullUUID  `json:"statusId"`
+	CreatedBy      uuid.NullUUID  `json:"createdBy"`
+	AfterID        uuid.NullUUID  `json:"afterId"`
+	SortBy         string         `json:"sortBy"`
+	Descending     bool           `json:"descending"`
+	AfterCreatedAt sql.NullTime   `json:"afterCreatedAt"`
+	AfterTitle     sql.NullString `json:"afterTitle"`
+	PageLimit      int32          `json:"pageLimit"`
+}
+
+func (q *Queries) ListListings(ctx context.Context, arg ListListingsParams) ([]Listing, error) {
+	rows, err := q.db.QueryContext(ctx, listListings,
+		arg.CategoryID,
+		arg.StatusID,
+		arg.CreatedBy,
+		arg.AfterID,
+		arg.SortBy,
+		arg.Descending,
+		arg.AfterCreatedAt,
+		arg.AfterTitle,
+		arg.PageLimit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Listing{}
+	for rows.Next() {
+		var i Listing
+		if err := rows.Scan(
+			&i.ID,
+			&i.Title,
+			&i.Description,
+			&i.CategoryID,
+			&i.Price,
+			&i.CurrencyID,
+			&i.ConditionID,
+			&i.StatusID,
+			&i.MediaUrl,
+			&i.CustomFields,
+			&i.CreatedBy,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.PublishedAt,
+			&i.ExpiresAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const updateListing = `-- name: UpdateListing :one
+UPDATE listings
+SET title = $2,
+    description = $3,
+    category_id = $4,
+    price = $5,
+    currency_id = $6,
+    condition_id = $7,
+    media_url = $8,
+    custom_fields = $9,
+    updated_at = NOW()
+WHERE id = $1
+RETURNING id, title, description, category_id, price, currency_id, condition_id, status_id, media_url, custom_fields, created_by, created_at, updated_at, published_at, expires_at
+`
+
+type UpdateListingParams struct {
+	ID           uuid.UUID       `json:"id"`
+	Title        string          `json:"title"`
+	Description  sql.NullString  `json:"description"`
+	CategoryID   uuid.UUID       `json:"categoryId"`
+	Price        sql.NullString  `json:"price"`
+	CurrencyID   uuid.NullUUID   `json:"currencyId"`
+	ConditionID  uuid.NullUUID   `json:"conditionId"`
+	MediaUrl     sql.NullString  `json:"mediaUrl"`
+	CustomFields json.RawMessage `json:"customFields"`
+}
+
+func (q *Queries) UpdateListing(ctx context.Context, arg UpdateListingParams) (Listing, error) {
+	row := q.db.QueryRowContext(ctx, updateListing,
+		arg.ID,
+		arg.Title,
+		arg.Description,
+		arg.CategoryID,
+		arg.Price,
+		arg.CurrencyID,
+		arg.ConditionID,
+		arg.MediaUrl,
+		arg.CustomFields,
+	)
+	var i Listing
+	err := row.Scan(
+		&i.ID,
+		&i.Title,
+		&i.Description,
+		&i.CategoryID,
+		&i.Price,
+		&i.CurrencyID,
+		&i.ConditionID,
+		&i.StatusID,
+		&i.MediaUrl,
+		&i.CustomFields,
+		&i.CreatedBy,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.PublishedAt,
+		&i.ExpiresAt,
+	)
+	return i, err
+}
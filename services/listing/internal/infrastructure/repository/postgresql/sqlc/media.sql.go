@@ -0,0 +1,153 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.27.0
+// source: media.sql
+
+package sqlc
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const createListingMedia = `-- name: CreateListingMedia :one
+INSERT INTO listing_media (
+    id, listing_id, position, storage_key, thumbnail_key,
+    content_type, size_bytes, width, height
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9
+)
+RETURNING created_at
+`
+
+type CreateListingMediaParams struct {
+	ID           uuid.UUID `json:"id"`
+	ListingID    uuid.UUID `json:"listingId"`
+	Position     int32     `json:"position"`
+	StorageKey   string    `json:"storageKey"`
+	ThumbnailKey string    `json:"thumbnailKey"`
+	ContentType  string    `json:"contentType"`
+	SizeBytes    int64     `json:"sizeBytes"`
+	Width        int32     `json:"width"`
+	Height       int32     `json:"height"`
+}
+
+func (q *Queries) CreateListingMedia(ctx context.Context, arg CreateListingMediaParams) (time.Time, error) {
+	row := q.db.QueryRowContext(ctx, createListingMedia,
+		arg.ID,
+		arg.ListingID,
+		arg.Position,
+		arg.StorageKey,
+		arg.ThumbnailKey,
+		arg.ContentType,
+		arg.SizeBytes,
+		arg.Width,
+		arg.Height,
+	)
+	var created_at time.Time
+	err := row.Scan(&created_at)
+	return created_at, err
+}
+
+const deleteListingMedia = `-- name: DeleteListingMedia :one
+DELETE FROM listing_media
+WHERE listing_id = $1 AND id = $2
+RETURNING id, listing_id, position, storage_key, thumbnail_key, content_type, size_bytes, width, height, created_at
+`
+
+type DeleteListingMediaParams struct {
+	ListingID uuid.UUID `json:"listingId"`
+	ID        uuid.UUID `json:"id"`
+}
+
+func (q *Queries) DeleteListingMedia(ctx context.Context, arg DeleteListingMediaParams) (ListingMedia, error) {
+	row := q.db.QueryRowContext(ctx, deleteListingMedia, arg.ListingID, arg.ID)
+	var i ListingMedia
+	err := row.Scan(
+		&i.ID,
+		&i.ListingID,
+		&i.Position,
+		&i.StorageKey,
+		&i.ThumbnailKey,
+		&i.ContentType,
+		&i.SizeBytes,
+		&i.Width,
+		&i.Height,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const listListingMedia = `-- name: ListListingMedia :many
+SELECT id, listing_id, position, storage_key, thumbnail_key, content_type, size_bytes, width, height, created_at FROM listing_media
+WHERE listing_id = $1
+ORDER BY position, created_at, id
+`
+
+func (q *Queries) ListListingMedia(ctx context.Context, listingID uuid.UUID) ([]ListingMedia, error) {
+	rows, err := q.db.QueryContext(ctx, listListingMedia, listingID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListingMedia{}
+	for rows.Next() {
+		var i ListingMedia
+		if err := rows.Scan(
+			&i.ID,
+			&i.ListingID,
+			&i.Position,
+			&i.StorageKey,
+			&i.ThumbnailKey,
+			&i.ContentType,
+			&i.SizeBytes,
+			&i.Width,
+			&i.Height,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const lockListingForMedia = `-- name: LockListingForMedia :one
+SELECT id FROM listings
+WHERE id = $1
+FOR UPDATE
+`
+
+func (q *Queries) LockListingForMedia(ctx context.Context, id uuid.UUID) (uuid.UUID, error) {
+	row := q.db.QueryRowContext(ctx, lockListingForMedia, id)
+	err := row.Scan(&id)
+	return id, err
+}
+
+const setListingMediaPosition = `-- name: SetListingMediaPosition :execrows
+UPDATE listing_media
+SET position = $1
+WHERE listing_id = $2 AND id = $3
+`
+
+type SetListingMediaPositionParams struct {
+	Position  int32     `json:"position"`
+	ListingID uuid.UUID `json:"listingId"`
+	ID        uuid.UUID `json:"id"`
+}
+
+func (q *Queries) SetListingMediaPosition(ctx context.Context, arg SetListingMediaPositionParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, setListingMediaPosition, arg.Position, arg.ListingID, arg.ID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
@@ -0,0 +1,85 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.27.0
+
+package sqlc
+
+import (
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Condition struct {
+	ID           uuid.UUID `json:"id"`
+	Name         string    `json:"name"`
+	DisplayName  string    `json:"displayName"`
+	DisplayOrder int32     `json:"displayOrder"`
+	CreatedAt    time.Time `json:"createdAt"`
+	IsActive     bool      `json:"isActive"`
+	UpdatedAt    time.Time `json:"updatedAt"`
+}
+
+type Currency struct {
+	ID           uuid.UUID `json:"id"`
+	Code         string    `json:"code"`
+	Name         string    `json:"name"`
+	IsActive     bool      `json:"isActive"`
+	CreatedAt    time.Time `json:"createdAt"`
+	DisplayOrder int32     `json:"displayOrder"`
+	UpdatedAt    time.Time `json:"updatedAt"`
+}
+
+type Listing struct {
+	ID           uuid.UUID       `json:"id"`
+	Title        string          `json:"title"`
+	Description  sql.NullString  `json:"description"`
+	CategoryID   uuid.UUID       `json:"categoryId"`
+	Price        sql.NullString  `json:"price"`
+	CurrencyID   uuid.NullUUID   `json:"currencyId"`
+	ConditionID  uuid.NullUUID   `json:"conditionId"`
+	StatusID     uuid.UUID       `json:"statusId"`
+	MediaUrl     sql.NullString  `json:"mediaUrl"`
+	CustomFields json.RawMessage `json:"customFields"`
+	CreatedBy    uuid.UUID       `json:"createdBy"`
+	CreatedAt    time.Time       `json:"createdAt"`
+	UpdatedAt    time.Time       `json:"updatedAt"`
+	PublishedAt  sql.NullTime    `json:"publishedAt"`
+	ExpiresAt    sql.NullTime    `json:"expiresAt"`
+}
+
+type ListingMedia struct {
+	ID           uuid.UUID `json:"id"`
+	ListingID    uuid.UUID `json:"listingId"`
+	Position     int32     `json:"position"`
+	StorageKey   string    `json:"storageKey"`
+	ThumbnailKey string    `json:"thumbnailKey"`
+	ContentType  string    `json:"contentType"`
+	SizeBytes    int64     `json:"sizeBytes"`
+	Width        int32     `json:"width"`
+	Height       int32     `json:"height"`
+	CreatedAt    time.Time `json:"createdAt"`
+}
+
+type ListingStatus struct {
+	ID           uuid.UUID `json:"id"`
+	Name         string    `json:"name"`
+	DisplayName  string    `json:"displayName"`
+	DisplayOrder int32     `json:"displayOrder"`
+	CreatedAt    time.Time `json:"createdAt"`
+	IsActive     bool      `json:"isActive"`
+	UpdatedAt    time.Time `json:"updatedAt"`
+}
+
+type ListingStatusTransition struct {
+	ID           uuid.UUID     `json:"id"`
+	ListingID    uuid.UUID     `json:"listingId"`
+	FromStatusID uuid.NullUUID `json:"fromStatusId"`
+	ToStatusID   uuid.UUID     `json:"toStatusId"`
+	Action       string        `json:"action"`
+	Reason       string        `json:"reason"`
+	ChangedBy    uuid.NullUUID `json:"changedBy"`
+	CreatedAt    time.Time     `json:"createdAt"`
+}
@@ -0,0 +1,43 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.27.0
+
+package sqlc
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Querier interface {
+	CreateListing(ctx context.Context, arg CreateListingParams) (Listing, error)
+	CreateListingMedia(ctx context.Context, arg CreateListingMediaParams) (time.Time, error)
+	CreateListingTransition(ctx context.Context, arg CreateListingTransitionParams) (time.Time, error)
+	DeleteListing(ctx context.Context, id uuid.UUID) error
+	DeleteListingMedia(ctx context.Context, arg DeleteListingMediaParams) (ListingMedia, error)
+	GetListing(ctx context.Context, id uuid.UUID) (Listing, error)
+	GetListingStatusForUpdate(ctx context.Context, id uuid.UUID) (string, error)
+	GetListingStatusID(ctx context.Context, name string) (uuid.UUID, error)
+	IsListingExpired(ctx context.Context, id uuid.UUID) (bool, error)
+	// Reference tables, keyed by the name of conditions and listing statuses and
+	// the code of currencies
+	ListConditions(ctx context.Context, includeInactive bool) ([]ListConditionsRow, error)
+	ListCurrencies(ctx context.Context, includeInactive bool) ([]ListCurrenciesRow, error)
+	// Listings can expire from the statuses the expire action applies to
+	ListExpiredListingIDs(ctx context.Context, batchSize int32) ([]uuid.UUID, error)
+	ListListingMedia(ctx context.Context, listingID uuid.UUID) ([]ListingMedia, error)
+	ListListingStatuses(ctx context.Context, includeInactive bool) ([]ListListingStatusesRow, error)
+	ListListingTransitions(ctx context.Context, listingID uuid.UUID) ([]ListListingTransitionsRow, error)
+	ListListings(ctx context.Context, arg ListListingsParams) ([]Listing, error)
+	LockListingForMedia(ctx context.Context, id uuid.UUID) (uuid.UUID, error)
+	SetListingMediaPosition(ctx context.Context, arg SetListingMediaPositionParams) (int64, error)
+	SetListingStatus(ctx context.Context, arg SetListingStatusParams) (Listing, error)
+	UpdateListing(ctx context.Context, arg UpdateListingParams) (Listing, error)
+	UpsertCondition(ctx context.Context, arg UpsertConditionParams) (UpsertConditionRow, error)
+	UpsertCurrency(ctx context.Context, arg UpsertCurrencyParams) (UpsertCurrencyRow, error)
+	UpsertListingStatus(ctx context.Context, arg UpsertListingStatusParams) (UpsertListingStatusRow, error)
+}
+
+var _ Querier = (*Queries)(nil)
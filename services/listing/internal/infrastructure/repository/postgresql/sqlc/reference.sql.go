@@ -0,0 +1,310 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.27.0
+// source: reference.sql
+
+package sqlc
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const listConditions = `-- name: ListConditions :many
+
+SELECT id, name AS key, display_name, display_order, is_active, created_at, updated_at
+FROM conditions
+WHERE $1::bool OR is_active
+ORDER BY display_order, name
+`
+
+type ListConditionsRow struct {
+	ID           uuid.UUID `json:"id"`
+	Key          string    `json:"key"`
+	DisplayName  string    `json:"displayName"`
+	DisplayOrder int32     `json:"displayOrder"`
+	IsActive     bool      `json:"isActive"`
+	CreatedAt    time.Time `json:"createdAt"`
+	UpdatedAt    time.Time `json:"updatedAt"`
+}
+
+// Reference tables, keyed by the name of conditions and listing statuses and
+// the code of currencies
+func (q *Queries) ListConditions(ctx context.Context, includeInactive bool) ([]ListConditionsRow, error) {
+	rows, err := q.db.QueryContext(ctx, listConditions, includeInactive)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListConditionsRow{}
+	for rows.Next() {
+		var i ListConditionsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Key,
+			&i.DisplayName,
+			&i.DisplayOrder,
+			&i.IsActive,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listCurrencies = `-- name: ListCurrencies :many
+SELECT id, code AS key, name AS display_name, display_order, is_active, created_at, updated_at
+FROM currencies
+WHERE $1::bool OR is_active
+ORDER BY display_order, code
+`
+
+type ListCurrenciesRow struct {
+	ID           uuid.UUID `json:"id"`
+	Key          string    `json:"key"`
+	DisplayName  string    `json:"displayName"`
+	DisplayOrder int32     `json:"displayOrder"`
+	IsActive     bool      `json:"isActive"`
+	CreatedAt    time.Time `json:"createdAt"`
+	UpdatedAt    time.Time `json:"updatedAt"`
+}
+
+func (q *Queries) ListCurrencies(ctx context.Context, includeInactive bool) ([]ListCurrenciesRow, error) {
+	rows, err := q.db.QueryContext(ctx, listCurrencies, includeInactive)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListCurrenciesRow{}
+	for rows.Next() {
+		var i ListCurrenciesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Key,
+			&i.DisplayName,
+			&i.DisplayOrder,
+			&i.IsActive,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listListingStatuses = `-- name: ListListingStatuses :many
+SELECT id, name AS key, display_name, display_order, is_active, created_at, updated_at
+FROM listing_statuses
+WHERE $1::bool OR is_active
+ORDER BY display_order, name
+`
+
+type ListListingStatusesRow struct {
+	ID           uuid.UUID `json:"id"`
+	Key          string    `json:"key"`
+	DisplayName  string    `json:"displayName"`
+	DisplayOrder int32     `json:"displayOrder"`
+	IsActive     bool      `json:"isActive"`
+	CreatedAt    time.Time `json:"createdAt"`
+	UpdatedAt    time.Time `json:"updatedAt"`
+}
+
+func (q *Queries) ListListingStatuses(ctx context.Context, includeInactive bool) ([]ListListingStatusesRow, error) {
+	rows, err := q.db.QueryContext(ctx, listListingStatuses, includeInactive)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListListingStatusesRow{}
+	for rows.Next() {
+		var i ListListingStatusesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Key,
+			&i.DisplayName,
+			&i.DisplayOrder,
+			&i.IsActive,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const upsertCondition = `-- name: UpsertCondition :one
+INSERT INTO conditions (id, name, display_name, display_order, is_active)
+VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (name) DO UPDATE
+SET display_name = EXCLUDED.display_name,
+    display_order = EXCLUDED.display_order,
+    is_active = EXCLUDED.is_active,
+    updated_at = NOW()
+RETURNING id, name AS key, display_name, display_order, is_active, created_at, updated_at
+`
+
+type UpsertConditionParams struct {
+	ID           uuid.UUID `json:"id"`
+	Key          string    `json:"key"`
+	DisplayName  string    `json:"displayName"`
+	DisplayOrder int32     `json:"displayOrder"`
+	IsActive     bool      `json:"isActive"`
+}
+
+type UpsertConditionRow struct {
+	ID           uuid.UUID `json:"id"`
+	Key          string    `json:"key"`
+	DisplayName  string    `json:"displayName"`
+	DisplayOrder int32     `json:"displayOrder"`
+	IsActive     bool      `json:"isActive"`
+	CreatedAt    time.Time `json:"createdAt"`
+	UpdatedAt    time.Time `json:"updatedAt"`
+}
+
+func (q *Queries) UpsertCondition(ctx context.Context, arg UpsertConditionParams) (UpsertConditionRow, error) {
+	row := q.db.QueryRowContext(ctx, upsertCondition,
+		arg.ID,
+		arg.Key,
+		arg.DisplayName,
+		arg.DisplayOrder,
+		arg.IsActive,
+	)
+	var i UpsertConditionRow
+	err := row.Scan(
+		&i.ID,
+		&i.Key,
+		&i.DisplayName,
+		&i.DisplayOrder,
+		&i.IsActive,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const upsertCurrency = `-- name: UpsertCurrency :one
+INSERT INTO currencies (id, code, name, display_order, is_active)
+VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (code) DO UPDATE
+SET name = EXCLUDED.name,
+    display_order = EXCLUDED.display_order,
+    is_active = EXCLUDED.is_active,
+    updated_at = NOW()
+RETURNING id, code AS key, name AS display_name, display_order, is_active, created_at, updated_at
+`
+
+type UpsertCurrencyParams struct {
+	ID           uuid.UUID `json:"id"`
+	Key          string    `json:"key"`
+	DisplayName  string    `json:"displayName"`
+	DisplayOrder int32     `json:"displayOrder"`
+	IsActive     bool      `json:"isActive"`
+}
+
+type UpsertCurrencyRow struct {
+	ID           uuid.UUID `json:"id"`
+	Key          string    `json:"key"`
+	DisplayName  string    `json:"displayName"`
+	DisplayOrder int32     `json:"displayOrder"`
+	IsActive     bool      `json:"isActive"`
+	CreatedAt    time.Time `json:"createdAt"`
+	UpdatedAt    time.Time `json:"updatedAt"`
+}
+
+func (q *Queries) UpsertCurrency(ctx context.Context, arg UpsertCurrencyParams) (UpsertCurrencyRow, error) {
+	row := q.db.QueryRowContext(ctx, upsertCurrency,
+		arg.ID,
+		arg.Key,
+		arg.DisplayName,
+		arg.DisplayOrder,
+		arg.IsActive,
+	)
+	var i UpsertCurrencyRow
+	err := row.Scan(
+		&i.ID,
+		&i.Key,
+		&i.DisplayName,
+		&i.DisplayOrder,
+		&i.IsActive,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const upsertListingStatus = `-- name: UpsertListingStatus :one
+INSERT INTO listing_statuses (id, name, display_name, display_order, is_active)
+VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (name) DO UPDATE
+SET display_name = EXCLUDED.display_name,
+    display_order = EXCLUDED.display_order,
+    is_active = EXCLUDED.is_active,
+    updated_at = NOW()
+RETURNING id, name AS key, display_name, display_order, is_active, created_at, updated_at
+`
+
+type UpsertListingStatusParams struct {
+	ID           uuid.UUID `json:"id"`
+	Key          string    `json:"key"`
+	DisplayName  string    `json:"displayName"`
+	DisplayOrder int32     `json:"displayOrder"`
+	IsActive     bool      `json:"isActive"`
+}
+
+type UpsertListingStatusRow struct {
+	ID           uuid.UUID `json:"id"`
+	Key          string    `json:"key"`
+	DisplayName  string    `json:"displayName"`
+	DisplayOrder int32     `json:"displayOrder"`
+	IsActive     bool      `json:"isActive"`
+	CreatedAt    time.Time `json:"createdAt"`
+	UpdatedAt    time.Time `json:"updatedAt"`
+}
+
+func (q *Queries) UpsertListingStatus(ctx context.Context, arg UpsertListingStatusParams) (UpsertListingStatusRow, error) {
+	row := q.db.QueryRowContext(ctx, upsertListingStatus,
+		arg.ID,
+		arg.Key,
+		arg.DisplayName,
+		arg.DisplayOrder,
+		arg.IsActive,
+	)
+	var i UpsertListingStatusRow
+	err := row.Scan(
+		&i.ID,
+		&i.Key,
+		&i.DisplayName,
+		&i.DisplayOrder,
+		&i.IsActive,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
@@ -0,0 +1,110 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"mime"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	listing "localloop/services/listing/internal/domain"
+)
+
+// FileSystem stores media objects as files under a root directory. Objects
+// are served by the listing service itself, under baseURL.
+type FileSystem struct {
+	root    string
+	baseURL string
+}
+
+func NewFileSystem(root, baseURL string) (*FileSystem, error) {
+	if err := os.MkdirAll(root, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create media directory: %w", err)
+	}
+	return &FileSystem{
+		root:    root,
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+	}, nil
+}
+
+func (s *FileSystem) Put(_ context.Context, key, _ string, data []byte) error {
+	name, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(name), 0o755); err != nil {
+		return fmt.Errorf("failed to create directory for %s: %w", key, err)
+	}
+
+	// Write to a temporary file first, so readers never see partial objects
+	tmp, err := os.CreateTemp(filepath.Dir(name), ".upload-*")
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", key, err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write %s: %w", key, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write %s: %w", key, err)
+	}
+	return os.Rename(tmp.Name(), name)
+}
+
+// Get opens an object, its content type is derived from the extension of the
+// key
+func (s *FileSystem) Get(_ context.Context, key string) (io.ReadCloser, string, error) {
+	name, err := s.path(key)
+	if err != nil {
+		return nil, "", err
+	}
+
+	file, err := os.Open(name)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, "", listing.ErrObjectNotFound
+		}
+		return nil, "", err
+	}
+
+	contentType := mime.TypeByExtension(path.Ext(key))
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	return file, contentType, nil
+}
+
+func (s *FileSystem) Delete(_ context.Context, key string) error {
+	name, err := s.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.Remove(name); err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return listing.ErrObjectNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+func (s *FileSystem) URL(key string) string {
+	return s.baseURL + "/" + key
+}
+
+// path maps a key to a file under the root, rejecting keys that would
+// escape it
+func (s *FileSystem) path(key string) (string, error) {
+	if !fs.ValidPath(key) || key == "." {
+		return "", listing.ErrObjectNotFound
+	}
+	return filepath.Join(s.root, filepath.FromSlash(key)), nil
+}
@@ -0,0 +1,101 @@
+package handler
+
+import (
+	"context"
+	listing "localloop/services/listing/internal/domain"
+	apperror "localloop/services/listing/internal/shared/error"
+
+	"localloop/libs/pkg/errorbuilder"
+	"localloop/libs/pkg/web/crud"
+	"localloop/libs/pkg/web/middleware"
+
+	"github.com/google/uuid"
+)
+
+type ListingHandler struct {
+	listingService *listing.Service
+	Listing        *crud.CRUDHandler[listing.Listing, CreateListingRequest, UpdateListingRequest, ListingResponse]
+}
+
+func NewListingHandler(listingService *listing.Service) *ListingHandler {
+	h := &ListingHandler{
+		listingService: listingService,
+	}
+
+	h.Listing = crud.NewCRUDHandler(
+		func(ctx context.Context, req CreateListingRequest) (*listing.Listing, error) {
+			ownerID, err := currentUserID(ctx)
+			if err != nil {
+				return nil, err
+			}
+
+			return h.listingService.CreateListing(ctx, listing.CreateListingParams{
+				Title:        req.Title,
+				Description:  req.Description,
+				CategoryID:   req.CategoryID,
+				Price:        req.Price,
+				CurrencyID:   req.CurrencyID,
+				ConditionID:  req.ConditionID,
+				MediaURL:     req.MediaURL,
+				CustomFields: req.CustomFields,
+				CreatedBy:    ownerID,
+			})
+		},
+		h.listingService.GetListing,
+		func(ctx context.Context, id uuid.UUID, req UpdateListingRequest) (*listing.Listing, error) {
+			userID, err := currentUserID(ctx)
+			if err != nil {
+				return nil, err
+			}
+
+			return h.listingService.UpdateListing(ctx, listing.UpdateListingParams{
+				ID:           id,
+				Title:        req.Title,
+				Description:  req.Description,
+				CategoryID:   req.CategoryID,
+				Price:        req.Price,
+				CurrencyID:   req.CurrencyID,
+				ConditionID:  req.ConditionID,
+				MediaURL:     req.MediaURL,
+				CustomFields: req.CustomFields,
+				UpdatedBy:    userID,
+			})
+		},
+		func(ctx context.Context, id uuid.UUID) error {
+			userID, err := currentUserID(ctx)
+			if err != nil {
+				return err
+			}
+
+			return h.listingService.DeleteListing(ctx, listing.DeleteListingParams{
+				ID:        id,
+				DeletedBy: userID,
+			})
+		},
+		h.listingService.ListListings,
+		toListingResponse,
+		listing.ListingListSpec,
+	)
+
+	return h
+}
+
+// currentUserID returns the ID of the authenticated user, carried as the
+// token subject
+func currentUserID(ctx context.Context) (uuid.UUID, error) {
+	claims, ok := middleware.ClaimsFromContext(ctx)
+	if !ok {
+		return uuid.Nil, apperror.ErrInvalidOwner(
+			apperror.WithValidation("createdBy", "request is not authenticated"),
+		)
+	}
+
+	id, err := uuid.Parse(claims.Subject)
+	if err != nil {
+		return uuid.Nil, apperror.ErrInvalidOwner(
+			apperror.WithValidation("createdBy", "token subject is not a user ID"),
+			errorbuilder.WithOriginal(err),
+		)
+	}
+	return id, nil
+}
@@ -0,0 +1,173 @@
+package handler
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	bh "localloop/libs/pkg/web/handler"
+	"localloop/libs/pkg/web/middleware"
+	listing "localloop/services/listing/internal/domain"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+// listingsRepository keeps listings in memory. Methods the tests do not use
+// panic through the embedded nil Repository.
+type listingsRepository struct {
+	listing.Repository
+	listings map[uuid.UUID]*listing.Listing
+}
+
+func (r *listingsRepository) WithTx(_ context.Context, fn func(repo listing.Repository) error) error {
+	return fn(r)
+}
+
+func (r *listingsRepository) GetListingStatusID(_ context.Context, status listing.ListingStatus) (uuid.UUID, error) {
+	return uuid.NewSHA1(uuid.Nil, []byte(status)), nil
+}
+
+func (r *listingsRepository) CreateListing(_ context.Context, l *listing.Listing) error {
+	r.listings[l.ID] = l
+	return nil
+}
+
+func (r *listingsRepository) CreateListingTransition(context.Context, *listing.ListingTransition) error {
+	return nil
+}
+
+func (r *listingsRepository) GetListing(_ context.Context, id uuid.UUID) (*listing.Listing, error) {
+	l, ok := r.listings[id]
+	if !ok {
+		return nil, sql.ErrNoRows
+	}
+	return l, nil
+}
+
+func (r *listingsRepository) UpdateListing(_ context.Context, l *listing.Listing) error {
+	r.listings[l.ID] = l
+	return nil
+}
+
+func (r *listingsRepository) ListListingMedia(context.Context, uuid.UUID) ([]*listing.ListingMedia, error) {
+	return nil, nil
+}
+
+func (r *listingsRepository) DeleteListing(_ context.Context, id uuid.UUID) error {
+	delete(r.listings, id)
+	return nil
+}
+
+// fieldlessCatalog knows every category, none of which has custom fields
+type fieldlessCatalog struct {
+	listing.CatalogClient
+}
+
+func (fieldlessCatalog) GetCategoryFieldDefinitions(context.Context, uuid.UUID) ([]*listing.CategoryFieldDefinition, error) {
+	return nil, nil
+}
+
+// newListingRouter routes the listing endpoints like the server, with every
+// request made by owner
+func newListingRouter(repo *listingsRepository, owner uuid.UUID) *mux.Router {
+	lh := NewListingHandler(listing.NewService(repo, fieldlessCatalog{}, nil, listing.ServiceConfig{}))
+
+	claims := &middleware.Claims{}
+	claims.Subject = owner.String()
+
+	router := mux.NewRouter()
+	router.Use(func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			next.ServeHTTP(w, r.WithContext(middleware.ContextWithClaims(r.Context(), claims)))
+		})
+	})
+	router.HandleFunc("/listings", bh.HandleRequest(lh.Listing.Create)).Methods("POST")
+	router.HandleFunc("/listings/{id}", bh.HandleRequest(lh.Listing.Get)).Methods("GET")
+	router.HandleFunc("/listings/{id}", bh.HandleRequest(lh.Listing.Update)).Methods("PUT")
+	router.HandleFunc("/listings/{id}", bh.HandleRequest(lh.Listing.Delete)).Methods("DELETE")
+	return router
+}
+
+func serve(router *mux.Router, method, target, body string) *httptest.ResponseRecorder {
+	r := httptest.NewRequest(method, target, strings.NewReader(body))
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, r)
+	return w
+}
+
+func TestListingCRUD(t *testing.T) {
+	repo := &listingsRepository{listings: make(map[uuid.UUID]*listing.Listing)}
+	router := newListingRouter(repo, uuid.New())
+	category := uuid.New()
+
+	w := serve(router, http.MethodPost, "/listings", `{"title":"Bike","categoryId":"`+category.String()+`","price":10}`)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("create status = %d: %s", w.Code, w.Body)
+	}
+	var created struct {
+		Data ListingResponse `json:"data"`
+	}
+	if err := json.NewDecoder(w.Body).Decode(&created); err != nil {
+		t.Fatal(err)
+	}
+	path := "/listings/" + created.Data.ID.String()
+
+	if w := serve(router, http.MethodGet, path, ""); w.Code != http.StatusOK || !strings.Contains(w.Body.String(), `"title":"Bike"`) {
+		t.Errorf("get status = %d: %s", w.Code, w.Body)
+	}
+
+	w = serve(router, http.MethodPut, path, `{"title":"Road bike","categoryId":"`+category.String()+`"}`)
+	if w.Code != http.StatusOK {
+		t.Fatalf("update status = %d: %s", w.Code, w.Body)
+	}
+	if title := repo.listings[created.Data.ID].Title; title != "Road bike" {
+		t.Errorf("title = %q after the update, want %q", title, "Road bike")
+	}
+
+	if w := serve(router, http.MethodDelete, path, ""); w.Code != http.StatusOK {
+		t.Fatalf("delete status = %d: %s", w.Code, w.Body)
+	}
+	if w := serve(router, http.MethodGet, path, ""); w.Code != http.StatusNotFound {
+		t.Errorf("get after the delete status = %d, want %d", w.Code, http.StatusNotFound)
+	}
+}
+
+func TestListingCRUDErrors(t *testing.T) {
+	repo := &listingsRepository{listings: make(map[uuid.UUID]*listing.Listing)}
+	router := newListingRouter(repo, uuid.New())
+	missing := "/listings/" + uuid.New().String()
+	valid := `{"title":"Bike","categoryId":"` + uuid.New().String() + `"}`
+
+	tests := []struct {
+		name   string
+		method string
+		target string
+		body   string
+		want   int
+	}{
+		{name: "create with a malformed body", method: http.MethodPost, target: "/listings", body: `{"title":`, want: http.StatusBadRequest},
+		{name: "create without a title", method: http.MethodPost, target: "/listings", body: `{"categoryId":"` + uuid.New().String() + `"}`, want: http.StatusBadRequest},
+		{name: "create with a negative price", method: http.MethodPost, target: "/listings", body: `{"title":"Bike","categoryId":"` + uuid.New().String() + `","price":-1}`, want: http.StatusBadRequest},
+		{name: "get with a malformed ID", method: http.MethodGet, target: "/listings/42", want: http.StatusBadRequest},
+		{name: "update with a malformed ID", method: http.MethodPut, target: "/listings/42", body: valid, want: http.StatusBadRequest},
+		{name: "get a missing listing", method: http.MethodGet, target: missing, want: http.StatusNotFound},
+		{name: "update a missing listing", method: http.MethodPut, target: missing, body: valid, want: http.StatusNotFound},
+		{name: "delete a missing listing", method: http.MethodDelete, target: missing, want: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if w := serve(router, tt.method, tt.target, tt.body); w.Code != tt.want {
+				t.Errorf("status = %d, want %d: %s", w.Code, tt.want, w.Body)
+			}
+			if len(repo.listings) != 0 {
+				t.Errorf("a failed request stored %d listings", len(repo.listings))
+			}
+		})
+	}
+}
@@ -0,0 +1,38 @@
+package handler
+
+import (
+	"net/http"
+
+	listing "localloop/services/listing/internal/domain"
+)
+
+func (h *ListingHandler) TransitionListing(req TransitionListingRequest, r *http.Request) (any, error) {
+	userID, err := currentUserID(r.Context())
+	if err != nil {
+		return nil, err
+	}
+
+	l, err := h.listingService.TransitionListing(r.Context(), listing.TransitionListingParams{
+		ID:        req.ID,
+		Action:    req.Action,
+		Reason:    req.Reason,
+		ChangedBy: &userID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toListingResponse(l), nil
+}
+
+func (h *ListingHandler) ListListingTransitions(req ListListingTransitionsRequest, r *http.Request) (any, error) {
+	history, err := h.listingService.ListListingTransitions(r.Context(), req.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]ListingTransitionResponse, len(history))
+	for i, t := range history {
+		responses[i] = toListingTransitionResponse(t)
+	}
+	return responses, nil
+}
@@ -0,0 +1,184 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	bh "localloop/libs/pkg/web/handler"
+	listing "localloop/services/listing/internal/domain"
+	apperror "localloop/services/listing/internal/shared/error"
+
+	"localloop/libs/pkg/errorbuilder"
+
+	"github.com/gorilla/mux"
+)
+
+const (
+	// mediaFormField is the multipart field uploaded images are sent in
+	mediaFormField = "files"
+	// multipartOverhead is the room left in upload bodies for part headers,
+	// boundaries and fields other than files, in bytes
+	multipartOverhead = 1 << 20
+)
+
+func (h *ListingHandler) UploadListingMedia(req ListingMediaRequest, r *http.Request) (any, error) {
+	userID, err := currentUserID(r.Context())
+	if err != nil {
+		return nil, err
+	}
+
+	files, err := h.readMediaFiles(r)
+	if err != nil {
+		return nil, err
+	}
+
+	media, err := h.listingService.AddListingMedia(r.Context(), listing.AddListingMediaParams{
+		ListingID:  req.ID,
+		UploadedBy: userID,
+		Files:      files,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toListingMediaResponses(media, h.listingService.MediaURL), nil
+}
+
+func (h *ListingHandler) ListListingMedia(req ListingMediaRequest, r *http.Request) (any, error) {
+	media, err := h.listingService.ListListingMedia(r.Context(), req.ID)
+	if err != nil {
+		return nil, err
+	}
+	return toListingMediaResponses(media, h.listingService.MediaURL), nil
+}
+
+func (h *ListingHandler) ReorderListingMedia(req ReorderListingMediaRequest, r *http.Request) (any, error) {
+	userID, err := currentUserID(r.Context())
+	if err != nil {
+		return nil, err
+	}
+
+	media, err := h.listingService.ReorderListingMedia(r.Context(), listing.ReorderListingMediaParams{
+		ListingID:   req.ID,
+		MediaIDs:    req.MediaIDs,
+		ReorderedBy: userID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toListingMediaResponses(media, h.listingService.MediaURL), nil
+}
+
+func (h *ListingHandler) DeleteListingMedia(req DeleteListingMediaRequest, r *http.Request) (any, error) {
+	userID, err := currentUserID(r.Context())
+	if err != nil {
+		return nil, err
+	}
+
+	err = h.listingService.DeleteListingMedia(r.Context(), listing.DeleteListingMediaParams{
+		ListingID: req.ID,
+		MediaID:   req.MediaID,
+		DeletedBy: userID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return map[string]string{"id": req.MediaID.String()}, nil
+}
+
+// ServeMedia streams a stored media file, the route variable key holds its
+// storage key
+func (h *ListingHandler) ServeMedia(w http.ResponseWriter, r *http.Request) {
+	body, contentType, err := h.listingService.GetMediaObject(r.Context(), mux.Vars(r)["key"])
+	if err != nil {
+		bh.RespondWithAppError(w, r, err)
+		return
+	}
+	defer body.Close()
+
+	// Keys are never reused, so objects can be cached for good
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Cache-Control", "public, max-age=31536000, immutable")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	io.Copy(w, body)
+}
+
+// readMediaFiles streams the uploaded files out of a multipart request. Files
+// are read up to one byte past the size limit and no more files than a
+// listing can hold, so the service can reject oversized uploads without
+// buffering them whole. The body as a whole is capped at what that many
+// files take, so parts that are not files cannot fill the memory either.
+func (h *ListingHandler) readMediaFiles(r *http.Request) ([]listing.MediaFile, error) {
+	maxSize, maxCount := h.listingService.MediaLimits()
+	maxBody := maxSize*int64(maxCount) + multipartOverhead
+	r.Body = http.MaxBytesReader(nil, r.Body, maxBody)
+
+	reader, err := r.MultipartReader()
+	if err != nil {
+		return nil, apperror.ErrInvalidMedia(
+			apperror.WithValidation(mediaFormField, "request must be multipart/form-data"),
+			errorbuilder.WithOriginal(err),
+		)
+	}
+
+	var files []listing.MediaFile
+	for {
+		part, err := reader.NextPart()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			if tooLarge := bodyTooLarge(err); tooLarge != nil {
+				return nil, tooLarge
+			}
+			return nil, apperror.ErrInvalidMedia(
+				apperror.WithValidation(mediaFormField, "request body is malformed"),
+				errorbuilder.WithOriginal(err),
+			)
+		}
+		if part.FormName() != mediaFormField {
+			part.Close()
+			continue
+		}
+
+		if len(files) == maxCount {
+			part.Close()
+			return nil, apperror.ErrInvalidMedia(
+				apperror.WithValidation(mediaFormField, fmt.Sprintf("a listing cannot have more than %d images", maxCount)),
+			)
+		}
+
+		data, err := io.ReadAll(io.LimitReader(part, maxSize+1))
+		part.Close()
+		if err != nil {
+			if tooLarge := bodyTooLarge(err); tooLarge != nil {
+				return nil, tooLarge
+			}
+			return nil, apperror.ErrInvalidMedia(
+				apperror.WithValidation(part.FileName(), "file could not be read"),
+				errorbuilder.WithOriginal(err),
+			)
+		}
+
+		files = append(files, listing.MediaFile{
+			Filename: part.FileName(),
+			Data:     data,
+		})
+	}
+
+	return files, nil
+}
+
+// bodyTooLarge returns the error reported for a request body read past its
+// limit, nil for other read errors
+func bodyTooLarge(err error) error {
+	var maxErr *http.MaxBytesError
+	if !errors.As(err, &maxErr) {
+		return nil
+	}
+	return apperror.ErrMediaTooLarge(
+		apperror.WithValidation(mediaFormField, fmt.Sprintf("request body cannot be larger than %d bytes", maxErr.Limit)),
+		errorbuilder.WithOriginal(err),
+	)
+}
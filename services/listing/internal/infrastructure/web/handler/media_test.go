@@ -0,0 +1,124 @@
+package handler
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"localloop/libs/pkg/errorbuilder"
+	listing "localloop/services/listing/internal/domain"
+)
+
+type formPart struct {
+	field, filename, content string
+}
+
+func multipartRequest(t *testing.T, parts ...formPart) *http.Request {
+	t.Helper()
+
+	var body bytes.Buffer
+	w := multipart.NewWriter(&body)
+	for _, p := range parts {
+		var err error
+		if p.filename != "" {
+			var fw io.Writer
+			fw, err = w.CreateFormFile(p.field, p.filename)
+			if err == nil {
+				_, err = fw.Write([]byte(p.content))
+			}
+		} else {
+			err = w.WriteField(p.field, p.content)
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	r := httptest.NewRequest(http.MethodPost, "/listings/1/media", &body)
+	r.Header.Set("Content-Type", w.FormDataContentType())
+	return r
+}
+
+func TestReadMediaFiles(t *testing.T) {
+	h := NewListingHandler(listing.NewService(nil, nil, nil, listing.ServiceConfig{
+		MaxMediaSize:       10,
+		MaxMediaPerListing: 2,
+	}))
+
+	tests := []struct {
+		name      string
+		parts     []formPart
+		wantFiles []string
+		wantCode  errorbuilder.ErrorCode
+	}{
+		{
+			name: "files are read and other fields skipped",
+			parts: []formPart{
+				{field: "files", filename: "a.png", content: "aaa"},
+				{field: "note", content: "hello"},
+				{field: "files", filename: "b.png", content: "bbb"},
+			},
+			wantFiles: []string{"a.png", "b.png"},
+		},
+		{
+			name: "oversized files are cut one byte past the limit",
+			parts: []formPart{
+				{field: "files", filename: "a.png", content: strings.Repeat("a", 50)},
+			},
+			wantFiles: []string{"a.png"},
+		},
+		{
+			name: "more files than a listing holds",
+			parts: []formPart{
+				{field: "files", filename: "a.png", content: "a"},
+				{field: "files", filename: "b.png", content: "b"},
+				{field: "files", filename: "c.png", content: "c"},
+			},
+			wantCode: errorbuilder.ErrValidation,
+		},
+		{
+			name: "body over the limit",
+			parts: []formPart{
+				{field: "note", content: strings.Repeat("x", multipartOverhead+100)},
+			},
+			wantCode: errorbuilder.ErrPayloadTooLarge,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			files, err := h.readMediaFiles(multipartRequest(t, tt.parts...))
+
+			if tt.wantCode != 0 {
+				var appErr *errorbuilder.CustomError
+				if !errors.As(err, &appErr) || appErr.Code != tt.wantCode {
+					t.Fatalf("readMediaFiles() error = %v, want code %d", err, tt.wantCode)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("readMediaFiles() error = %v", err)
+			}
+
+			if len(files) != len(tt.wantFiles) {
+				t.Fatalf("read %d files, want %d", len(files), len(tt.wantFiles))
+			}
+			for i, file := range files {
+				if file.Filename != tt.wantFiles[i] {
+					t.Errorf("file %d = %q, want %q", i, file.Filename, tt.wantFiles[i])
+				}
+				if len(file.Data) > 11 {
+					t.Errorf("file %d read %d bytes, want at most 11", i, len(file.Data))
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,39 @@
+package handler
+
+import (
+	"net/http"
+
+	listing "localloop/services/listing/internal/domain"
+)
+
+func (h *ListingHandler) ListReferenceItems(req ListReferenceItemsRequest, r *http.Request) (any, error) {
+	items, err := h.listingService.ListReferenceItems(r.Context(), req.Kind, req.IncludeInactive)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]ReferenceItemResponse, len(items))
+	for i, item := range items {
+		responses[i] = toReferenceItemResponse(item)
+	}
+	return responses, nil
+}
+
+func (h *ListingHandler) UpsertReferenceItem(req UpsertReferenceItemRequest, r *http.Request) (any, error) {
+	isActive := true
+	if req.IsActive != nil {
+		isActive = *req.IsActive
+	}
+
+	item, err := h.listingService.UpsertReferenceItem(r.Context(), listing.UpsertReferenceItemParams{
+		Kind:         req.Kind,
+		Key:          req.Key,
+		DisplayName:  req.DisplayName,
+		DisplayOrder: req.DisplayOrder,
+		IsActive:     isActive,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toReferenceItemResponse(item), nil
+}
@@ -0,0 +1,77 @@
+// Request types for handlers
+package handler
+
+import (
+	listing "localloop/services/listing/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+// CreateListingRequest creates a draft listing, see TransitionListingRequest
+type CreateListingRequest struct {
+	Title        string                 `json:"title" validate:"required,max=255"`
+	Description  string                 `json:"description"`
+	CategoryID   uuid.UUID              `json:"categoryId" validate:"required"`
+	Price        *float64               `json:"price,omitempty"`
+	CurrencyID   *uuid.UUID             `json:"currencyId,omitempty"`
+	ConditionID  *uuid.UUID             `json:"conditionId,omitempty"`
+	MediaURL     string                 `json:"mediaUrl"`
+	CustomFields map[string]interface{} `json:"customFields"`
+}
+
+type UpdateListingRequest struct {
+	Title        string                 `json:"title" validate:"required,max=255"`
+	Description  string                 `json:"description"`
+	CategoryID   uuid.UUID              `json:"categoryId" validate:"required"`
+	Price        *float64               `json:"price,omitempty"`
+	CurrencyID   *uuid.UUID             `json:"currencyId,omitempty"`
+	ConditionID  *uuid.UUID             `json:"conditionId,omitempty"`
+	MediaURL     string                 `json:"mediaUrl"`
+	CustomFields map[string]interface{} `json:"customFields"`
+}
+
+// ListReferenceItemsRequest lists the items of a reference table, inactive
+// ones only on request
+type ListReferenceItemsRequest struct {
+	Kind            listing.ReferenceKind `param:"kind" validate:"required,oneof=conditions currencies statuses"`
+	IncludeInactive bool                  `query:"includeInactive"`
+}
+
+// UpsertReferenceItemRequest creates or replaces the reference item with the
+// key of the path. Omitting isActive keeps the item active.
+type UpsertReferenceItemRequest struct {
+	Kind         listing.ReferenceKind `param:"kind" validate:"required,oneof=conditions currencies statuses"`
+	Key          string                `param:"key" validate:"required,max=50"`
+	DisplayName  string                `json:"displayName" validate:"required,max=50"`
+	DisplayOrder int32                 `json:"displayOrder" validate:"gte=0"`
+	IsActive     *bool                 `json:"isActive"`
+}
+
+// TransitionListingRequest applies a lifecycle action to a listing
+type TransitionListingRequest struct {
+	ID     uuid.UUID             `param:"id" validate:"required"`
+	Action listing.ListingAction `json:"action" validate:"required,oneof=publish renew pause reserve release sell archive"`
+	Reason string                `json:"reason" validate:"max=500"`
+}
+
+type ListListingTransitionsRequest struct {
+	ID uuid.UUID `param:"id" validate:"required"`
+}
+
+// ListingMediaRequest addresses the images of a listing. Uploads are
+// multipart/form-data requests with one or more "files" parts.
+type ListingMediaRequest struct {
+	ID uuid.UUID `param:"id" validate:"required"`
+}
+
+// ReorderListingMediaRequest lists every image of the listing in the order
+// they are to be shown
+type ReorderListingMediaRequest struct {
+	ID       uuid.UUID   `param:"id" validate:"required"`
+	MediaIDs []uuid.UUID `json:"mediaIds" validate:"required"`
+}
+
+type DeleteListingMediaRequest struct {
+	ID      uuid.UUID `param:"id" validate:"required"`
+	MediaID uuid.UUID `param:"mediaId" validate:"required"`
+}
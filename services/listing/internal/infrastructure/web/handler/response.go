@@ -0,0 +1,190 @@
+// Response types for handlers
+package handler
+
+import (
+	listing "localloop/services/listing/internal/domain"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Listing Response
+type ListingResponse struct {
+	ID           uuid.UUID              `json:"id"`
+	Title        string                 `json:"title"`
+	Description  string                 `json:"description"`
+	CategoryID   uuid.UUID              `json:"categoryId"`
+	Price        *float64               `json:"price,omitempty"`
+	CurrencyID   *uuid.UUID             `json:"currencyId,omitempty"`
+	ConditionID  *uuid.UUID             `json:"conditionId,omitempty"`
+	StatusID     uuid.UUID              `json:"statusId"`
+	MediaURL     string                 `json:"mediaUrl,omitempty"`
+	CustomFields map[string]interface{} `json:"customFields"`
+	CreatedBy    uuid.UUID              `json:"createdBy"`
+	CreatedAt    time.Time              `json:"createdAt"`
+	UpdatedAt    time.Time              `json:"updatedAt"`
+	PublishedAt  *time.Time             `json:"publishedAt,omitempty"`
+	ExpiresAt    *time.Time             `json:"expiresAt,omitempty"`
+}
+
+func toListingResponse(l *listing.Listing) ListingResponse {
+	return ListingResponse{
+		ID:           l.ID,
+		Title:        l.Title,
+		Description:  l.Description,
+		CategoryID:   l.CategoryID,
+		Price:        l.Price,
+		CurrencyID:   l.CurrencyID,
+		ConditionID:  l.ConditionID,
+		StatusID:     l.StatusID,
+		MediaURL:     l.MediaURL,
+		CustomFields: l.CustomFields,
+		CreatedBy:    l.CreatedBy,
+		CreatedAt:    l.CreatedAt,
+		UpdatedAt:    l.UpdatedAt,
+		PublishedAt:  l.PublishedAt,
+		ExpiresAt:    l.ExpiresAt,
+	}
+}
+
+// Listing Transition Response
+type ListingTransitionResponse struct {
+	ID        uuid.UUID  `json:"id"`
+	From      *string    `json:"from,omitempty"`
+	To        string     `json:"to"`
+	Action    string     `json:"action"`
+	Reason    string     `json:"reason,omitempty"`
+	ChangedBy *uuid.UUID `json:"changedBy,omitempty"`
+	CreatedAt time.Time  `json:"createdAt"`
+}
+
+func toListingTransitionResponse(t *listing.ListingTransition) ListingTransitionResponse {
+	var from *string
+	if t.From != nil {
+		status := string(*t.From)
+		from = &status
+	}
+
+	return ListingTransitionResponse{
+		ID:        t.ID,
+		From:      from,
+		To:        string(t.To),
+		Action:    string(t.Action),
+		Reason:    t.Reason,
+		ChangedBy: t.ChangedBy,
+		CreatedAt: t.CreatedAt,
+	}
+}
+
+// Listing Media Response
+type ListingMediaResponse struct {
+	ID           uuid.UUID `json:"id"`
+	URL          string    `json:"url"`
+	ThumbnailURL string    `json:"thumbnailUrl"`
+	ContentType  string    `json:"contentType"`
+	SizeBytes    int64     `json:"sizeBytes"`
+	Width        int32     `json:"width"`
+	Height       int32     `json:"height"`
+	Position     int32     `json:"position"`
+	CreatedAt    time.Time `json:"createdAt"`
+}
+
+// toListingMediaResponses resolves the download URLs of media with url
+func toListingMediaResponses(media []*listing.ListingMedia, url func(key string) string) []ListingMediaResponse {
+	responses := make([]ListingMediaResponse, len(media))
+	for i, m := range media {
+		responses[i] = ListingMediaResponse{
+			ID:           m.ID,
+			URL:          url(m.StorageKey),
+			ThumbnailURL: url(m.ThumbnailKey),
+			ContentType:  m.ContentType,
+			SizeBytes:    m.SizeBytes,
+			Width:        m.Width,
+			Height:       m.Height,
+			Position:     m.Position,
+			CreatedAt:    m.CreatedAt,
+		}
+	}
+	return responses
+}
+
+// Reference Item Response
+type ReferenceItemResponse struct {
+	ID           uuid.UUID `json:"id"`
+	Key          string    `json:"key"`
+	DisplayName  string    `json:"displayName"`
+	DisplayOrder int32     `json:"displayOrder"`
+	IsActive     bool      `json:"isActive"`
+	CreatedAt    time.Time `json:"createdAt"`
+	UpdatedAt    time.Time `json:"updatedAt"`
+}
+
+func toReferenceItemResponse(item *listing.ReferenceItem) ReferenceItemResponse {
+	return ReferenceItemResponse{
+		ID:           item.ID,
+		Key:          item.Key,
+		DisplayName:  item.DisplayName,
+		DisplayOrder: item.DisplayOrder,
+		IsActive:     item.IsActive,
+		CreatedAt:    item.CreatedAt,
+		UpdatedAt:    item.UpdatedAt,
+	}
+}
+
+// Search Response
+type FacetCountResponse struct {
+	Value string `json:"value"`
+	Count int64  `json:"count"`
+}
+
+type SearchFacetsResponse struct {
+	Categories   []FacetCountResponse            `json:"categories"`
+	Conditions   []FacetCountResponse            `json:"conditions"`
+	Currencies   []FacetCountResponse            `json:"currencies"`
+	Statuses     []FacetCountResponse            `json:"statuses"`
+	CustomFields map[string][]FacetCountResponse `json:"customFields,omitempty"`
+}
+
+type SearchListingsResponse struct {
+	Listings   []ListingResponse    `json:"listings"`
+	Facets     SearchFacetsResponse `json:"facets"`
+	NextCursor string               `json:"nextCursor,omitempty"`
+}
+
+func toFacetCountResponses(counts []listing.FacetCount) []FacetCountResponse {
+	responses := make([]FacetCountResponse, len(counts))
+	for i, count := range counts {
+		responses[i] = FacetCountResponse{
+			Value: count.Value,
+			Count: count.Count,
+		}
+	}
+	return responses
+}
+
+func toSearchListingsResponse(result *listing.SearchResult) SearchListingsResponse {
+	listings := make([]ListingResponse, len(result.Listings))
+	for i, l := range result.Listings {
+		listings[i] = toListingResponse(l)
+	}
+
+	facets := SearchFacetsResponse{
+		Categories: toFacetCountResponses(result.Facets.Categories),
+		Conditions: toFacetCountResponses(result.Facets.Conditions),
+		Currencies: toFacetCountResponses(result.Facets.Currencies),
+		Statuses:   toFacetCountResponses(result.Facets.Statuses),
+	}
+
+	if len(result.Facets.CustomFields) > 0 {
+		facets.CustomFields = make(map[string][]FacetCountResponse, len(result.Facets.CustomFields))
+		for key, counts := range result.Facets.CustomFields {
+			facets.CustomFields[key] = toFacetCountResponses(counts)
+		}
+	}
+
+	return SearchListingsResponse{
+		Listings:   listings,
+		Facets:     facets,
+		NextCursor: result.NextCursor,
+	}
+}
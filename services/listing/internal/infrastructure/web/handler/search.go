@@ -0,0 +1,166 @@
+package handler
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"localloop/libs/pkg/web/crud"
+	listing "localloop/services/listing/internal/domain"
+	apperror "localloop/services/listing/internal/shared/error"
+
+	"github.com/google/uuid"
+)
+
+// customFieldPrefix marks query parameters that filter on custom fields,
+// e.g. cf.<fieldId>=red or cf.<fieldId>.gte=2000
+const customFieldPrefix = "cf."
+
+func (h *ListingHandler) SearchListings(_ crud.EmptyRequest, r *http.Request) (any, error) {
+	params, err := parseSearchParams(r.URL.Query())
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := h.listingService.SearchListings(r.Context(), params)
+	if err != nil {
+		return nil, err
+	}
+
+	return toSearchListingsResponse(result), nil
+}
+
+func parseSearchParams(query url.Values) (listing.SearchParams, error) {
+	params := listing.SearchParams{
+		IncludeDescendants: true,
+		Cursor:             query.Get("cursor"),
+	}
+
+	var err error
+	if value := query.Get("categoryId"); value != "" {
+		id, err := uuid.Parse(value)
+		if err != nil {
+			return params, invalidParam("categoryId", "must be a valid UUID")
+		}
+		params.CategoryID = &id
+	}
+
+	if value := query.Get("includeDescendants"); value != "" {
+		if params.IncludeDescendants, err = strconv.ParseBool(value); err != nil {
+			return params, invalidParam("includeDescendants", "must be a boolean")
+		}
+	}
+
+	if params.MinPrice, err = parseFloatParam(query, "minPrice"); err != nil {
+		return params, err
+	}
+	if params.MaxPrice, err = parseFloatParam(query, "maxPrice"); err != nil {
+		return params, err
+	}
+
+	if params.ConditionIDs, err = parseUUIDListParam(query, "conditionId"); err != nil {
+		return params, err
+	}
+	if params.CurrencyIDs, err = parseUUIDListParam(query, "currencyId"); err != nil {
+		return params, err
+	}
+	if params.StatusIDs, err = parseUUIDListParam(query, "statusId"); err != nil {
+		return params, err
+	}
+
+	if value := query.Get("limit"); value != "" {
+		if params.Limit, err = strconv.Atoi(value); err != nil || params.Limit < 1 {
+			return params, invalidParam("limit", "must be a positive integer")
+		}
+	}
+
+	params.FacetFields = splitListParam(query["facet"])
+
+	for name, values := range query {
+		if !strings.HasPrefix(name, customFieldPrefix) {
+			continue
+		}
+
+		key, operator := strings.TrimPrefix(name, customFieldPrefix), listing.OpEq
+		if i := strings.LastIndex(key, "."); i != -1 {
+			key, operator = key[:i], key[i+1:]
+		}
+
+		switch operator {
+		case listing.OpEq, listing.OpGt, listing.OpGte, listing.OpLt, listing.OpLte:
+		default:
+			return params, invalidParam(name, "unsupported operator "+operator)
+		}
+
+		if key == "" {
+			return params, invalidParam(name, "custom field key cannot be empty")
+		}
+
+		for _, value := range values {
+			params.CustomFields = append(params.CustomFields, listing.CustomFieldPredicate{
+				Key:      key,
+				Operator: operator,
+				Value:    parseScalar(value),
+			})
+		}
+	}
+
+	return params, nil
+}
+
+// parseScalar interprets a query value as a JSON boolean or number when
+// possible and falls back to a string.
+func parseScalar(value string) interface{} {
+	if value == "true" || value == "false" {
+		return value == "true"
+	}
+	if f, err := strconv.ParseFloat(value, 64); err == nil {
+		return f
+	}
+	return value
+}
+
+func parseFloatParam(query url.Values, name string) (*float64, error) {
+	value := query.Get(name)
+	if value == "" {
+		return nil, nil
+	}
+	f, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return nil, invalidParam(name, "must be a number")
+	}
+	return &f, nil
+}
+
+// parseUUIDListParam accepts both repeated (?id=a&id=b) and comma separated
+// (?id=a,b) values.
+func parseUUIDListParam(query url.Values, name string) ([]uuid.UUID, error) {
+	var ids []uuid.UUID
+	for _, value := range splitListParam(query[name]) {
+		id, err := uuid.Parse(value)
+		if err != nil {
+			return nil, invalidParam(name, "must be a list of valid UUIDs")
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+func splitListParam(values []string) []string {
+	var items []string
+	for _, value := range values {
+		for _, item := range strings.Split(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+	}
+	return items
+}
+
+func invalidParam(name, reason string) error {
+	return apperror.ErrInvalidSearch(
+		apperror.WithValidation(name, reason),
+	)
+}
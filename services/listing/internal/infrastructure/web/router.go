@@ -0,0 +1,68 @@
+package web
+
+import (
+	"localloop/libs/pkg/web"
+	bh "localloop/libs/pkg/web/handler"
+	"localloop/libs/pkg/web/middleware"
+	listing "localloop/services/listing/internal/domain"
+	h "localloop/services/listing/internal/infrastructure/web/handler"
+)
+
+// Roles issued by the user service that the listing service cares about
+const (
+	roleAdmin = "admin"
+)
+
+type ListingManagementServer struct {
+	*web.Server    // Embedding the shared Server struct
+	listingService *listing.Service
+	authenticator  *middleware.Authenticator
+}
+
+func NewListingManagementServer(listingService *listing.Service, authenticator *middleware.Authenticator) *ListingManagementServer {
+	server := &ListingManagementServer{
+		Server:         web.NewServer(),
+		listingService: listingService,
+		authenticator:  authenticator,
+	}
+
+	server.setupRoutes()
+	return server
+}
+
+func (s *ListingManagementServer) setupRoutes() {
+	router := s.Router
+	lh := h.NewListingHandler(s.listingService)
+
+	// Reads are public, every write requires an authenticated user
+	writes := router.Methods("POST", "PUT", "DELETE").Subrouter()
+	writes.Use(s.authenticator.Authenticate)
+
+	// Search routes, registered before /listings/{id} so "search" is not taken as an ID
+	router.HandleFunc("/listings/search", bh.HandleRequest(lh.SearchListings)).Methods("GET")
+
+	// Listing routes
+	router.HandleFunc("/listings", bh.HandleRequest(lh.Listing.List)).Methods("GET")
+	router.HandleFunc("/listings/{id}", bh.HandleRequest(lh.Listing.Get)).Methods("GET")
+	writes.HandleFunc("/listings", bh.HandleRequest(lh.Listing.Create)).Methods("POST")
+	writes.HandleFunc("/listings/{id}", bh.HandleRequest(lh.Listing.Update)).Methods("PUT")
+	writes.HandleFunc("/listings/{id}", bh.HandleRequest(lh.Listing.Delete)).Methods("DELETE")
+
+	// Lifecycle routes
+	router.HandleFunc("/listings/{id}/transitions", bh.HandleRequest(lh.ListListingTransitions)).Methods("GET")
+	writes.HandleFunc("/listings/{id}/transitions", bh.HandleRequest(lh.TransitionListing)).Methods("POST")
+
+	// Media routes, the order route is registered before /media/{mediaId}
+	router.HandleFunc("/listings/{id}/media", bh.HandleRequest(lh.ListListingMedia)).Methods("GET")
+	writes.HandleFunc("/listings/{id}/media", bh.HandleRequest(lh.UploadListingMedia)).Methods("POST")
+	writes.HandleFunc("/listings/{id}/media/order", bh.HandleRequest(lh.ReorderListingMedia)).Methods("PUT")
+	writes.HandleFunc("/listings/{id}/media/{mediaId}", bh.HandleRequest(lh.DeleteListingMedia)).Methods("DELETE")
+	router.HandleFunc("/media/{key:.+}", lh.ServeMedia).Methods("GET")
+
+	// Reference data routes, maintained by admins
+	admin := router.Methods("PUT").Subrouter()
+	admin.Use(s.authenticator.Authenticate, middleware.RequireRole(roleAdmin))
+
+	router.HandleFunc("/reference/{kind}", bh.HandleRequest(lh.ListReferenceItems)).Methods("GET")
+	admin.HandleFunc("/reference/{kind}/{key}", bh.HandleRequest(lh.UpsertReferenceItem)).Methods("PUT")
+}
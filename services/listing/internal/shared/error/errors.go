@@ -0,0 +1,70 @@
+package apperror
+
+import (
+	"localloop/libs/pkg/errorbuilder"
+)
+
+var (
+	// Resource errors
+	ErrListingNotFound       = errorbuilder.NewError("listing not found", errorbuilder.ErrNotFound)
+	ErrCategoryNotFound      = errorbuilder.NewError("category not found", errorbuilder.ErrNotFound)
+	ErrReferenceKindNotFound = errorbuilder.NewError("reference data kind not found", errorbuilder.ErrNotFound)
+	ErrMediaNotFound         = errorbuilder.NewError("media not found", errorbuilder.ErrNotFound)
+
+	// Authorization errors
+	ErrNotListingOwner = errorbuilder.NewError("only the owner can do this", errorbuilder.ErrForbidden)
+
+	// Conflict errors
+	ErrIllegalTransition = errorbuilder.NewError("illegal listing status transition", errorbuilder.ErrConflict)
+	ErrListingNotExpired = errorbuilder.NewError("listing has not expired", errorbuilder.ErrConflict)
+
+	// Validation errors
+	ErrInvalidTitle         = errorbuilder.NewError("invalid listing title", errorbuilder.ErrValidation)
+	ErrInvalidCategory      = errorbuilder.NewError("invalid category", errorbuilder.ErrValidation)
+	ErrInvalidStatus        = errorbuilder.NewError("invalid listing status", errorbuilder.ErrValidation)
+	ErrInvalidPrice         = errorbuilder.NewError("invalid price", errorbuilder.ErrValidation)
+	ErrInvalidOwner         = errorbuilder.NewError("invalid listing owner", errorbuilder.ErrValidation)
+	ErrInvalidFields        = errorbuilder.NewError("invalid custom fields", errorbuilder.ErrValidation)
+	ErrInvalidSearch        = errorbuilder.NewError("invalid search parameters", errorbuilder.ErrValidation)
+	ErrInvalidCursor        = errorbuilder.NewError("invalid cursor", errorbuilder.ErrValidation)
+	ErrInvalidReferenceItem = errorbuilder.NewError("invalid reference data item", errorbuilder.ErrValidation)
+	ErrInvalidTransition    = errorbuilder.NewError("invalid listing action", errorbuilder.ErrValidation)
+	ErrInvalidMedia         = errorbuilder.NewError("invalid media", errorbuilder.ErrValidation)
+	ErrInvalidMediaOrder    = errorbuilder.NewError("invalid media order", errorbuilder.ErrValidation)
+	ErrMediaTooLarge        = errorbuilder.NewError("media upload too large", errorbuilder.ErrPayloadTooLarge)
+
+	// Service errors
+	ErrCatalogService = errorbuilder.NewError("catalog service error", errorbuilder.ErrInternal)
+	ErrMediaStorage   = errorbuilder.NewError("media storage error", errorbuilder.ErrInternal)
+
+	// Internal errors
+	ErrDatabaseOperation = errorbuilder.NewError("database operation failed", errorbuilder.ErrInternal)
+	ErrInvalidJSON       = errorbuilder.NewError("invalid JSON data", errorbuilder.ErrInternal)
+	// ErrMissingReferenceData means the reference data has not been seeded
+	ErrMissingReferenceData = errorbuilder.NewError("reference data missing", errorbuilder.ErrInternal)
+)
+
+func WithListing(id string) errorbuilder.ErrorOption {
+	return errorbuilder.WithContext(map[string]any{
+		"listingId": id,
+	})
+}
+
+func WithValidation(field, reason string) errorbuilder.ErrorOption {
+	return errorbuilder.WithContext(map[string]any{
+		"field":  field,
+		"reason": reason,
+	})
+}
+
+func WithFieldErrors(fields map[string]string) errorbuilder.ErrorOption {
+	return errorbuilder.WithContext(map[string]any{
+		"fields": fields,
+	})
+}
+
+func WithService(service string) errorbuilder.ErrorOption {
+	return errorbuilder.WithContext(map[string]any{
+		"service": service,
+	})
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"log"
+	"os"
+
+	app "localloop/services/listing/internal"
+	"localloop/services/listing/internal/config"
+)
+
+func main() {
+	cfg := config.Load()
+
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		if err := app.Migrate(cfg, os.Args[2:]); err != nil {
+			log.Fatal("Migration failed:", err)
+		}
+		return
+	}
+
+	if len(os.Args) > 1 && os.Args[1] == "seed" {
+		if err := app.Seed(cfg); err != nil {
+			log.Fatal("Seeding failed:", err)
+		}
+		return
+	}
+
+	listingApp, err := app.NewApp(
+		cfg,
+		app.WithPostgresDatabase(),
+		app.WithMigrations(),
+		app.WithPostgresListingRepository(),
+		app.WithCatalogClient(),
+		app.WithMediaStorage(),
+		app.WithListingService(),
+		app.WithReferenceData(),
+		app.WithAuthenticator(),
+		app.WithWebServer(),
+		app.WithScheduler(),
+	)
+
+	if err != nil {
+		log.Fatal("Error initializing the app:", err)
+	}
+
+	if err := listingApp.Server.Run(cfg.Port); err != nil {
+		log.Fatal("Error starting the server:", err)
+	}
+}
@@ -0,0 +1,12 @@
+ALTER TABLE currencies
+    DROP COLUMN updated_at,
+    DROP COLUMN display_order,
+    ALTER COLUMN is_active DROP NOT NULL;
+
+ALTER TABLE listing_statuses
+    DROP COLUMN updated_at,
+    DROP COLUMN is_active;
+
+ALTER TABLE conditions
+    DROP COLUMN updated_at,
+    DROP COLUMN is_active;
@@ -0,0 +1,15 @@
+-- Reference rows are looked up by their natural key when seeding and are
+-- deactivated rather than deleted, since listings keep referring to them.
+ALTER TABLE conditions
+    ADD COLUMN is_active BOOLEAN NOT NULL DEFAULT true,
+    ADD COLUMN updated_at TIMESTAMP NOT NULL DEFAULT NOW();
+
+ALTER TABLE listing_statuses
+    ADD COLUMN is_active BOOLEAN NOT NULL DEFAULT true,
+    ADD COLUMN updated_at TIMESTAMP NOT NULL DEFAULT NOW();
+
+UPDATE currencies SET is_active = true WHERE is_active IS NULL;
+ALTER TABLE currencies
+    ALTER COLUMN is_active SET NOT NULL,
+    ADD COLUMN display_order INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN updated_at TIMESTAMP NOT NULL DEFAULT NOW();
@@ -0,0 +1,14 @@
+-- Every status change of a listing, including the initial draft status. The
+-- statuses themselves are reference data, see the seeds package.
+CREATE TABLE listing_status_transitions (
+    id UUID PRIMARY KEY,
+    listing_id UUID NOT NULL REFERENCES listings(id) ON DELETE CASCADE,
+    from_status_id UUID REFERENCES listing_statuses(id),
+    to_status_id UUID NOT NULL REFERENCES listing_statuses(id),
+    action VARCHAR(50) NOT NULL,
+    reason TEXT NOT NULL DEFAULT '',
+    changed_by UUID,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_listing_status_transitions_listing ON listing_status_transitions(listing_id, created_at);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_listings_expires_at;
+ALTER TABLE listings DROP COLUMN IF EXISTS expires_at;
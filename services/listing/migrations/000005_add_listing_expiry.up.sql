@@ -0,0 +1,11 @@
+-- Published listings expire at expires_at unless their owner renews them
+ALTER TABLE listings ADD COLUMN expires_at TIMESTAMP;
+
+UPDATE listings l
+SET expires_at = l.published_at + INTERVAL '30 days'
+FROM listing_statuses s
+WHERE s.id = l.status_id
+  AND s.name IN ('published', 'paused')
+  AND l.published_at IS NOT NULL;
+
+CREATE INDEX idx_listings_expires_at ON listings(expires_at) WHERE expires_at IS NOT NULL;
@@ -0,0 +1,17 @@
+-- Images of a listing, shown by ascending position. The files live in the
+-- media storage under storage_key and thumbnail_key. media_url is kept for
+-- listings that link to an external image.
+CREATE TABLE listing_media (
+    id UUID PRIMARY KEY,
+    listing_id UUID NOT NULL REFERENCES listings(id) ON DELETE CASCADE,
+    position INTEGER NOT NULL,
+    storage_key VARCHAR(500) NOT NULL,
+    thumbnail_key VARCHAR(500) NOT NULL,
+    content_type VARCHAR(100) NOT NULL,
+    size_bytes BIGINT NOT NULL,
+    width INTEGER NOT NULL,
+    height INTEGER NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_listing_media_listing ON listing_media(listing_id, position);
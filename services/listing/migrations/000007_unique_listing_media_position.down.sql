@@ -0,0 +1,2 @@
+ALTER TABLE listing_media DROP CONSTRAINT IF EXISTS uq_listing_media_position;
+CREATE INDEX IF NOT EXISTS idx_listing_media_listing ON listing_media(listing_id, position);
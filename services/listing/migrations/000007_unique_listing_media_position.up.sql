@@ -0,0 +1,15 @@
+-- Two images of a listing cannot share a position. The constraint is checked
+-- at commit, so a reorder can swap positions one row at a time.
+UPDATE listing_media m
+SET position = r.position
+FROM (
+    SELECT id, (ROW_NUMBER() OVER (PARTITION BY listing_id ORDER BY position, created_at, id) - 1)::INTEGER AS position
+    FROM listing_media
+) r
+WHERE r.id = m.id AND r.position <> m.position;
+
+DROP INDEX IF EXISTS idx_listing_media_listing;
+
+ALTER TABLE listing_media
+    ADD CONSTRAINT uq_listing_media_position UNIQUE (listing_id, position)
+    DEFERRABLE INITIALLY DEFERRED;
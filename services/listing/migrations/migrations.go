@@ -0,0 +1,8 @@
+// Package migrations embeds the schema migrations of the service, see
+// db.Migrator
+package migrations
+
+import "embed"
+
+//go:embed *.sql
+var FS embed.FS
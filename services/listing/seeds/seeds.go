@@ -0,0 +1,55 @@
+// Package seeds embeds the reference data fixtures of the service, see
+// listing.Service.SeedReferenceData
+package seeds
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+
+	listing "localloop/services/listing/internal/domain"
+)
+
+//go:embed reference.json
+var referenceData []byte
+
+// fixture is one reference item. Items are active unless they say otherwise.
+type fixture struct {
+	Key          string `json:"key"`
+	DisplayName  string `json:"displayName"`
+	DisplayOrder int32  `json:"displayOrder"`
+	IsActive     *bool  `json:"isActive"`
+}
+
+// ReferenceData returns the reference items to seed, grouped by kind in the
+// order of listing.ReferenceKinds
+func ReferenceData() ([]listing.UpsertReferenceItemParams, error) {
+	var fixtures map[listing.ReferenceKind][]fixture
+	if err := json.Unmarshal(referenceData, &fixtures); err != nil {
+		return nil, fmt.Errorf("failed to parse reference fixtures: %w", err)
+	}
+
+	var items []listing.UpsertReferenceItemParams
+	for _, kind := range listing.ReferenceKinds {
+		for _, f := range fixtures[kind] {
+			isActive := true
+			if f.IsActive != nil {
+				isActive = *f.IsActive
+			}
+
+			items = append(items, listing.UpsertReferenceItemParams{
+				Kind:         kind,
+				Key:          f.Key,
+				DisplayName:  f.DisplayName,
+				DisplayOrder: f.DisplayOrder,
+				IsActive:     isActive,
+			})
+		}
+		delete(fixtures, kind)
+	}
+
+	for kind := range fixtures {
+		return nil, fmt.Errorf("unknown reference kind %q in fixtures", kind)
+	}
+	return items, nil
+}
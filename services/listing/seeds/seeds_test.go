@@ -0,0 +1,41 @@
+package seeds
+
+import (
+	"testing"
+
+	listing "localloop/services/listing/internal/domain"
+)
+
+func TestReferenceData(t *testing.T) {
+	items, err := ReferenceData()
+	if err != nil {
+		t.Fatalf("ReferenceData() error = %v", err)
+	}
+
+	keys := make(map[listing.ReferenceKind]map[string]bool)
+	for _, item := range items {
+		if keys[item.Kind] == nil {
+			keys[item.Kind] = make(map[string]bool)
+		}
+		if keys[item.Kind][item.Key] {
+			t.Errorf("%s %s is seeded twice", item.Kind, item.Key)
+		}
+		keys[item.Kind][item.Key] = true
+	}
+
+	for _, kind := range listing.ReferenceKinds {
+		if len(keys[kind]) == 0 {
+			t.Errorf("no %s are seeded", kind)
+		}
+	}
+
+	// Every status of the listing lifecycle has to exist
+	for _, status := range []listing.ListingStatus{
+		listing.StatusDraft, listing.StatusPublished, listing.StatusPaused, listing.StatusReserved,
+		listing.StatusSold, listing.StatusExpired, listing.StatusArchived,
+	} {
+		if !keys[listing.ReferenceStatuses][string(status)] {
+			t.Errorf("status %s is not seeded", status)
+		}
+	}
+}
@@ -3,12 +3,14 @@ package app
 import (
 	"context"
 	"errors"
+	"fmt"
 	"log"
 	"time"
 
 	"localloop/libs/pkg/db"
 	"localloop/services/user/internal/config"
 	"localloop/services/user/internal/domain/user"
+	"localloop/services/user/internal/infrastructure/keys"
 	"localloop/services/user/internal/infrastructure/repository"
 	"localloop/services/user/internal/infrastructure/web"
 
@@ -74,7 +76,15 @@ func WithMongoUserRepositroy() Option {
 		}
 
 		database := app.MongoClient.Database("users")
-		app.UserRepo = repository.NewMongoRepository(database)
+		repo := repository.NewMongoRepository(database)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		if err := repo.EnsureIndexes(ctx); err != nil {
+			return fmt.Errorf("failed to create MongoDB indexes: %w", err)
+		}
+
+		app.UserRepo = repo
 		return nil
 	}
 }
@@ -89,15 +99,72 @@ func WithInMemoryUserRepository() Option {
 // WithUserService initializes the user repository and service and applies them to the App
 func WithUserService() Option {
 	return func(app *App) error {
-		app.UserService = user.NewService(app.UserRepo, user.ServiceConfig{
-			JWTSecret:            app.config.JWTSecret,
+		signingKeys, activeKeyID, err := loadSigningKeys(app.config)
+		if err != nil {
+			return err
+		}
+
+		userService, err := user.NewService(app.UserRepo, user.ServiceConfig{
+			SigningKeys:          signingKeys,
+			ActiveKeyID:          activeKeyID,
 			JWTExpirationMinutes: app.config.JWTExpirationMinutes,
 			SaltLength:           app.config.SaltLength,
+
+			RefreshTokenExpirationHours: app.config.RefreshTokenExpirationHours,
 		})
+		if err != nil {
+			return err
+		}
+
+		app.UserService = userService
 		return nil
 	}
 }
 
+// GrantRole adds a role to a registered user, e.g. to bootstrap the first
+// admin. args are the email of the user and the role. The app has to use the
+// same repository as the server for the role to take effect.
+func (app *App) GrantRole(args []string) error {
+	if len(args) != 2 {
+		return errors.New("usage: grant-role <email> <role>")
+	}
+
+	u, err := app.UserService.GrantRole(args[0], args[1])
+	if err != nil {
+		return err
+	}
+	log.Printf("%s now has the roles %v", u.Email, u.Roles)
+	return nil
+}
+
+// loadSigningKeys reads the token signing keys from JWT_KEYS_DIR. In dev mode
+// an ephemeral key is generated when no keys directory is set. The active key
+// defaults to the last one by ID.
+func loadSigningKeys(cfg *config.Config) ([]user.SigningKey, string, error) {
+	if cfg.JWTKeysDir == "" {
+		if !cfg.DevMode {
+			return nil, "", errors.New("JWT_KEYS_DIR is required unless DEV_MODE is enabled")
+		}
+		log.Println("JWT_KEYS_DIR is not set, signing tokens with an ephemeral", cfg.JWTSigningAlgorithm, "key")
+		key, err := keys.GenerateSigningKey(cfg.JWTSigningAlgorithm)
+		if err != nil {
+			return nil, "", err
+		}
+		return []user.SigningKey{key}, key.ID, nil
+	}
+
+	signingKeys, err := keys.LoadSigningKeys(cfg.JWTKeysDir)
+	if err != nil {
+		return nil, "", err
+	}
+
+	activeKeyID := cfg.JWTActiveKeyID
+	if activeKeyID == "" {
+		activeKeyID = signingKeys[len(signingKeys)-1].ID
+	}
+	return signingKeys, activeKeyID, nil
+}
+
 func WithWebServer() Option {
 	return func(app *App) error {
 		app.Server = web.NewUserManagementServer(app.UserService)
@@ -0,0 +1,83 @@
+package app
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+
+	"localloop/services/user/internal/config"
+)
+
+func TestLoadSigningKeysRequiresKeysOutsideDevMode(t *testing.T) {
+	if _, _, err := loadSigningKeys(&config.Config{JWTSigningAlgorithm: "EdDSA"}); err == nil {
+		t.Error("loadSigningKeys() without JWT_KEYS_DIR succeeded outside dev mode")
+	}
+
+	keys, activeKeyID, err := loadSigningKeys(&config.Config{DevMode: true, JWTSigningAlgorithm: "EdDSA"})
+	if err != nil {
+		t.Fatalf("loadSigningKeys() in dev mode error = %v", err)
+	}
+	if len(keys) != 1 || keys[0].ID != activeKeyID {
+		t.Errorf("loadSigningKeys() = %+v, %s, want one ephemeral active key", keys, activeKeyID)
+	}
+}
+
+func serve(t *testing.T, app *App, method, target, body, token string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	r := httptest.NewRequest(method, target, strings.NewReader(body))
+	if token != "" {
+		r.Header.Set("Authorization", "Bearer "+token)
+	}
+	w := httptest.NewRecorder()
+	app.Server.Router.ServeHTTP(w, r)
+	return w
+}
+
+func TestGrantRoleIsVisibleToTheServer(t *testing.T) {
+	app, err := NewApp(
+		&config.Config{DevMode: true, JWTSigningAlgorithm: "EdDSA", JWTExpirationMinutes: 15, SaltLength: 16},
+		WithInMemoryUserRepository(),
+		WithUserService(),
+		WithWebServer(),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	credentials := `{"email":"jane@localloop.dev","password":"secret"}`
+	if w := serve(t, app, http.MethodPost, "/users/register", credentials, ""); w.Code != http.StatusCreated {
+		t.Fatalf("register status = %d: %s", w.Code, w.Body)
+	}
+	if err := app.GrantRole([]string{"jane@localloop.dev", "admin"}); err != nil {
+		t.Fatalf("GrantRole() error = %v", err)
+	}
+
+	w := serve(t, app, http.MethodPost, "/users/login", credentials, "")
+	var login struct {
+		Token string `json:"token"`
+	}
+	if err := json.NewDecoder(w.Body).Decode(&login); err != nil || login.Token == "" {
+		t.Fatalf("login status = %d, error = %v", w.Code, err)
+	}
+
+	w = serve(t, app, http.MethodGet, "/users/me", "", login.Token)
+	var me struct {
+		Roles []string `json:"roles"`
+	}
+	if err := json.NewDecoder(w.Body).Decode(&me); err != nil {
+		t.Fatalf("me status = %d, error = %v", w.Code, err)
+	}
+	if !slices.Contains(me.Roles, "admin") {
+		t.Errorf("roles = %v, want the granted admin role", me.Roles)
+	}
+
+	// Only admins get past the role check to the missing user
+	w = serve(t, app, http.MethodPut, "/users/john@localloop.dev/roles", `{"roles":["member"]}`, login.Token)
+	if w.Code != http.StatusNotFound {
+		t.Errorf("setting roles as the granted admin status = %d, want %d", w.Code, http.StatusNotFound)
+	}
+}
@@ -2,13 +2,17 @@ package user
 
 import (
 	"context"
+	"time"
 )
 
 // UpdateData represents the fields that can be updated for a user
 type UpdateData struct {
+	ID   string
 	Hash string
 	Salt string
 	Name string
+	// Roles replaces the user's roles when non-nil
+	Roles []string
 }
 
 // Repository defines the methods that any user repository implementation must have
@@ -16,4 +20,12 @@ type Repository interface {
 	Create(ctx context.Context, user User) error
 	FindByEmail(ctx context.Context, email string) (User, error)
 	Update(ctx context.Context, email string, updates UpdateData) error
+
+	// Refresh token operations
+	CreateRefreshToken(ctx context.Context, token RefreshToken) error
+	FindRefreshToken(ctx context.Context, hash string) (RefreshToken, error)
+	// MarkRefreshTokenUsed revokes a single token and returns
+	// ErrRefreshTokenUsed if it was already revoked
+	MarkRefreshTokenUsed(ctx context.Context, hash string, at time.Time) error
+	RevokeRefreshTokenFamily(ctx context.Context, familyID string, at time.Time) error
 }
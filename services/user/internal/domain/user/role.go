@@ -0,0 +1,34 @@
+package user
+
+// Roles a user can hold. They are emitted as the "roles" claim of access
+// tokens and enforced by the services consuming them.
+const (
+	RoleAdmin     = "admin"
+	RoleModerator = "moderator"
+	RoleMember    = "member"
+)
+
+var knownRoles = map[string]bool{
+	RoleAdmin:     true,
+	RoleModerator: true,
+	RoleMember:    true,
+}
+
+// EffectiveRoles returns the roles of the user. Users stored before roles
+// were introduced are members.
+func (u User) EffectiveRoles() []string {
+	if len(u.Roles) == 0 {
+		return []string{RoleMember}
+	}
+	return u.Roles
+}
+
+// HasRole reports whether the user holds role
+func (u User) HasRole(role string) bool {
+	for _, r := range u.EffectiveRoles() {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
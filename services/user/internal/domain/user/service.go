@@ -2,9 +2,12 @@ package user
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"time"
 
 	"github.com/golang-jwt/jwt"
+	"github.com/google/uuid"
 
 	"localloop/libs/pkg/errorbuilder"
 	"localloop/services/user/internal/shared"
@@ -13,27 +16,38 @@ import (
 
 type Service struct {
 	repo                  Repository // Use the Repository interface
-	jwtSecret             []byte
+	signingKeys           []SigningKey
+	activeKey             SigningKey
 	jwtExpirationDuration int
+	refreshTokenDuration  time.Duration
 }
 
 type ServiceConfig struct {
-	JWTSecret            string
+	// SigningKeys are published in the JWKS and accepted for verification,
+	// new tokens are signed with the one identified by ActiveKeyID
+	SigningKeys          []SigningKey
+	ActiveKeyID          string
 	JWTExpirationMinutes int
 	SaltLength           int
-}
 
-type TokenClaims struct {
-	Email string
-	jwt.StandardClaims
+	RefreshTokenExpirationHours int
 }
 
-func NewService(repo Repository, cfg ServiceConfig) *Service {
-	return &Service{
+func NewService(repo Repository, cfg ServiceConfig) (*Service, error) {
+	s := &Service{
 		repo:                  repo,
-		jwtSecret:             []byte(cfg.JWTSecret),
+		signingKeys:           cfg.SigningKeys,
 		jwtExpirationDuration: cfg.JWTExpirationMinutes,
+		refreshTokenDuration:  time.Duration(cfg.RefreshTokenExpirationHours) * time.Hour,
+	}
+
+	for _, key := range cfg.SigningKeys {
+		if key.ID == cfg.ActiveKeyID {
+			s.activeKey = key
+			return s, nil
+		}
 	}
+	return nil, fmt.Errorf("active signing key %q is not configured", cfg.ActiveKeyID)
 }
 
 func (s *Service) Register(email, password, name string) error {
@@ -62,10 +76,12 @@ func (s *Service) Register(email, password, name string) error {
 	}
 
 	user := User{
+		ID:    uuid.NewString(),
 		Email: email,
 		Hash:  hashedPassword,
 		Salt:  salt,
 		Name:  name,
+		Roles: []string{RoleMember},
 	}
 
 	if err := s.repo.Create(ctx, user); err != nil {
@@ -78,39 +94,172 @@ func (s *Service) Register(email, password, name string) error {
 	return nil
 }
 
-func (s *Service) Login(email, password string) (string, error) {
+func (s *Service) Login(email, password string) (TokenPair, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
 	user, err := s.repo.FindByEmail(ctx, email)
 	if err != nil {
-		return "", apperror.ErrInvalidCredentials(
+		return TokenPair{}, apperror.ErrInvalidCredentials(
 			apperror.WithUser(email),
 		)
 	}
 
 	if !shared.CheckPasswordHash(password, user.Salt, user.Hash) {
-		return "", apperror.ErrInvalidCredentials(
+		return TokenPair{}, apperror.ErrInvalidCredentials(
 			apperror.WithUser(email),
 		)
 	}
 
+	familyID, err := generateFamilyID()
+	if err != nil {
+		return TokenPair{}, apperror.ErrGeneratingToken(
+			errorbuilder.WithOriginal(err),
+		)
+	}
+
+	// Users registered before IDs were introduced get one on their next login
+	if user.ID == "" {
+		user.ID = uuid.NewString()
+		if err := s.repo.Update(ctx, email, UpdateData{ID: user.ID}); err != nil {
+			return TokenPair{}, apperror.ErrDatabaseOperation(
+				errorbuilder.WithOriginal(err),
+				apperror.WithUser(email),
+			)
+		}
+	}
+
+	return s.issueTokenPair(ctx, user, familyID)
+}
+
+// Refresh exchanges a refresh token for a new token pair. The presented token
+// is rotated: it is revoked and replaced by a new one in the same family.
+// Presenting a token that was already rotated means it leaked, so the whole
+// family is revoked and the user has to log in again.
+func (s *Service) Refresh(refreshToken string) (TokenPair, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	stored, err := s.repo.FindRefreshToken(ctx, hashRefreshToken(refreshToken))
+	if err != nil {
+		return TokenPair{}, apperror.ErrInvalidRefreshToken()
+	}
+
+	if stored.Revoked() {
+		return TokenPair{}, s.revokeReusedFamily(ctx, stored)
+	}
+
+	now := time.Now()
+	if now.After(stored.ExpiresAt) {
+		return TokenPair{}, apperror.ErrRefreshTokenExpired(
+			apperror.WithUser(stored.Email),
+		)
+	}
+
+	if err := s.repo.MarkRefreshTokenUsed(ctx, stored.Hash, now); err != nil {
+		// Another request rotated the token concurrently
+		if errors.Is(err, ErrRefreshTokenUsed) {
+			return TokenPair{}, s.revokeReusedFamily(ctx, stored)
+		}
+		return TokenPair{}, apperror.ErrDatabaseOperation(
+			errorbuilder.WithOriginal(err),
+			apperror.WithUser(stored.Email),
+		)
+	}
+
+	// The user may have been removed since the token was issued
+	user, err := s.repo.FindByEmail(ctx, stored.Email)
+	if err != nil {
+		return TokenPair{}, apperror.ErrInvalidRefreshToken(
+			apperror.WithUser(stored.Email),
+		)
+	}
+
+	return s.issueTokenPair(ctx, user, stored.FamilyID)
+}
+
+// Logout revokes the refresh token family the given token belongs to
+func (s *Service) Logout(refreshToken string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	stored, err := s.repo.FindRefreshToken(ctx, hashRefreshToken(refreshToken))
+	if err != nil {
+		return apperror.ErrInvalidRefreshToken()
+	}
+
+	if err := s.repo.RevokeRefreshTokenFamily(ctx, stored.FamilyID, time.Now()); err != nil {
+		return apperror.ErrDatabaseOperation(
+			errorbuilder.WithOriginal(err),
+			apperror.WithUser(stored.Email),
+		)
+	}
+
+	return nil
+}
+
+func (s *Service) revokeReusedFamily(ctx context.Context, stored RefreshToken) error {
+	if err := s.repo.RevokeRefreshTokenFamily(ctx, stored.FamilyID, time.Now()); err != nil {
+		return apperror.ErrDatabaseOperation(
+			errorbuilder.WithOriginal(err),
+			apperror.WithUser(stored.Email),
+		)
+	}
+
+	return apperror.ErrRefreshTokenReused(
+		apperror.WithUser(stored.Email),
+	)
+}
+
+func (s *Service) issueTokenPair(ctx context.Context, user User, familyID string) (TokenPair, error) {
+	email := user.Email
+	expiresIn := time.Duration(s.jwtExpirationDuration) * time.Minute
+
 	// Create a new JWT token
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+	token := jwt.NewWithClaims(s.activeKey.Method, jwt.MapClaims{
+		"sub":   user.ID,
 		"email": email,
-		"exp":   time.Now().Add(time.Duration(s.jwtExpirationDuration) * time.Minute).Unix(),
+		"roles": user.EffectiveRoles(),
+		"exp":   time.Now().Add(expiresIn).Unix(),
 	})
+	token.Header["kid"] = s.activeKey.ID
 
-	// Sign the token with the secret key
-	tokenString, err := token.SignedString(s.jwtSecret)
+	// Sign the token with the active private key
+	accessToken, err := token.SignedString(s.activeKey.Private)
 	if err != nil {
-		return "", apperror.ErrDatabaseOperation(
+		return TokenPair{}, apperror.ErrGeneratingToken(
 			errorbuilder.WithOriginal(err),
 			apperror.WithUser(email),
 		)
 	}
 
-	return tokenString, nil
+	refreshToken, err := generateRefreshToken()
+	if err != nil {
+		return TokenPair{}, apperror.ErrGeneratingToken(
+			errorbuilder.WithOriginal(err),
+			apperror.WithUser(email),
+		)
+	}
+
+	now := time.Now()
+	if err := s.repo.CreateRefreshToken(ctx, RefreshToken{
+		Hash:      hashRefreshToken(refreshToken),
+		FamilyID:  familyID,
+		Email:     email,
+		ExpiresAt: now.Add(s.refreshTokenDuration),
+		CreatedAt: now,
+	}); err != nil {
+		return TokenPair{}, apperror.ErrDatabaseOperation(
+			errorbuilder.WithOriginal(err),
+			apperror.WithUser(email),
+		)
+	}
+
+	return TokenPair{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		ExpiresIn:    int(expiresIn.Seconds()),
+	}, nil
 }
 
 func (s *Service) Get(email string) (User, error) {
@@ -148,33 +297,53 @@ func (s *Service) Update(email, name, password string) error {
 	return s.repo.Update(ctx, email, updates)
 }
 
-func (s *Service) ValidateToken(tokenString string) (*TokenClaims, error) {
-	claims := &TokenClaims{}
-	token, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
-		return s.jwtSecret, nil
-	})
+// SetRoles replaces the roles of a user. Access tokens already issued keep
+// their roles until they are refreshed.
+func (s *Service) SetRoles(email string, roles []string) (User, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
 
-	if err != nil {
-		switch v := err.(type) {
-		case *jwt.ValidationError:
-			switch v.Errors {
-			case jwt.ValidationErrorExpired:
-				return nil, apperror.ErrTokenExpired()
-			default:
-				return nil, apperror.ErrInvalidToken(
-					errorbuilder.WithOriginal(err),
-				)
-			}
-		default:
-			return nil, apperror.ErrInvalidToken(
-				errorbuilder.WithOriginal(err),
+	if len(roles) == 0 {
+		return User{}, apperror.ErrInvalidRole(
+			apperror.WithValidation("roles", "at least one role is required"),
+		)
+	}
+	for _, role := range roles {
+		if !knownRoles[role] {
+			return User{}, apperror.ErrInvalidRole(
+				apperror.WithValidation("roles", fmt.Sprintf("unknown role %q", role)),
 			)
 		}
 	}
 
-	if !token.Valid {
-		return nil, apperror.ErrInvalidToken()
+	user, err := s.repo.FindByEmail(ctx, email)
+	if err != nil {
+		return User{}, apperror.ErrUserNotFound(
+			apperror.WithUser(email),
+		)
+	}
+
+	if err := s.repo.Update(ctx, email, UpdateData{Roles: roles}); err != nil {
+		return User{}, apperror.ErrDatabaseOperation(
+			errorbuilder.WithOriginal(err),
+			apperror.WithUser(email),
+		)
+	}
+
+	user.Roles = roles
+	return user, nil
+}
+
+// GrantRole adds role to the roles of a user, keeping the ones they hold
+func (s *Service) GrantRole(email, role string) (User, error) {
+	user, err := s.Get(email)
+	if err != nil {
+		return User{}, err
+	}
+	if user.HasRole(role) {
+		return user, nil
 	}
 
-	return claims, nil
+	roles := append(append([]string{}, user.EffectiveRoles()...), role)
+	return s.SetRoles(email, roles)
 }
@@ -0,0 +1,126 @@
+package user_test
+
+import (
+	"reflect"
+	"testing"
+
+	"localloop/services/user/internal/domain/user"
+	"localloop/services/user/internal/infrastructure/keys"
+	"localloop/services/user/internal/infrastructure/repository"
+	apperror "localloop/services/user/internal/shared/error"
+)
+
+func newService(t *testing.T) *user.Service {
+	t.Helper()
+
+	key, err := keys.GenerateSigningKey("EdDSA")
+	if err != nil {
+		t.Fatal(err)
+	}
+	service, err := user.NewService(repository.NewInMemoryRepository(), user.ServiceConfig{
+		SigningKeys:          []user.SigningKey{key},
+		ActiveKeyID:          key.ID,
+		JWTExpirationMinutes: 15,
+		SaltLength:           25,
+
+		RefreshTokenExpirationHours: 24,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return service
+}
+
+func TestRegisterGrantsMemberRoleOnly(t *testing.T) {
+	service := newService(t)
+	if err := service.Register("admin@localloop.dev", "secret", "Admin"); err != nil {
+		t.Fatal(err)
+	}
+
+	u, err := service.Get("admin@localloop.dev")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(u.Roles, []string{user.RoleMember}) {
+		t.Errorf("roles = %v, want [%s]", u.Roles, user.RoleMember)
+	}
+}
+
+func TestGrantRole(t *testing.T) {
+	service := newService(t)
+	if err := service.Register("jane@localloop.dev", "secret", "Jane"); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{user.RoleMember, user.RoleAdmin}
+	for i := 0; i < 2; i++ {
+		u, err := service.GrantRole("jane@localloop.dev", user.RoleAdmin)
+		if err != nil {
+			t.Fatalf("GrantRole() error = %v", err)
+		}
+		if !reflect.DeepEqual(u.Roles, want) {
+			t.Errorf("roles = %v, want %v", u.Roles, want)
+		}
+	}
+
+	if _, err := service.GrantRole("jane@localloop.dev", "owner"); err == nil {
+		t.Error("GrantRole() with an unknown role succeeded")
+	}
+	if _, err := service.GrantRole("nobody@localloop.dev", user.RoleAdmin); err == nil {
+		t.Error("GrantRole() for an unregistered user succeeded")
+	}
+}
+
+func TestRefreshRotatesTokens(t *testing.T) {
+	service := newService(t)
+	if err := service.Register("jane@localloop.dev", "secret", "Jane"); err != nil {
+		t.Fatal(err)
+	}
+	login, err := service.Login("jane@localloop.dev", "secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rotated, err := service.Refresh(login.RefreshToken)
+	if err != nil {
+		t.Fatalf("Refresh() error = %v", err)
+	}
+	if rotated.RefreshToken == login.RefreshToken || rotated.AccessToken == "" {
+		t.Error("Refresh() did not issue a new token pair")
+	}
+
+	// Replaying the rotated token revokes the tokens issued since
+	reused := apperror.ErrRefreshTokenReused().Error()
+	if _, err := service.Refresh(login.RefreshToken); err == nil || err.Error() != reused {
+		t.Errorf("Refresh() with a rotated token error = %v, want %s", err, reused)
+	}
+	if _, err := service.Refresh(rotated.RefreshToken); err == nil {
+		t.Error("Refresh() succeeded with a token of a revoked family")
+	}
+
+	if _, err := service.Refresh("unknown"); err == nil {
+		t.Error("Refresh() succeeded with an unknown token")
+	}
+}
+
+func TestLogoutRevokesTheFamily(t *testing.T) {
+	service := newService(t)
+	if err := service.Register("jane@localloop.dev", "secret", "Jane"); err != nil {
+		t.Fatal(err)
+	}
+	login, err := service.Login("jane@localloop.dev", "secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	rotated, err := service.Refresh(login.RefreshToken)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := service.Logout(rotated.RefreshToken); err != nil {
+		t.Fatalf("Logout() error = %v", err)
+	}
+	if _, err := service.Refresh(rotated.RefreshToken); err == nil {
+		t.Error("Refresh() succeeded after Logout()")
+	}
+}
@@ -0,0 +1,72 @@
+package user
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"fmt"
+
+	"github.com/golang-jwt/jwt"
+
+	"localloop/libs/pkg/jwks"
+)
+
+// SigningKey is a private key used to sign access tokens. Its ID is sent as
+// the kid header so verifiers can pick the matching key from the JWKS.
+type SigningKey struct {
+	ID      string
+	Method  jwt.SigningMethod
+	Private crypto.Signer
+}
+
+// NewSigningKey derives the signing method from the key type: RSA keys sign
+// with RS256, Ed25519 keys with EdDSA.
+func NewSigningKey(id string, private crypto.Signer) (SigningKey, error) {
+	var method jwt.SigningMethod
+	switch private.(type) {
+	case *rsa.PrivateKey:
+		method = jwt.SigningMethodRS256
+	case ed25519.PrivateKey:
+		method = jwt.SigningMethodEdDSA
+	default:
+		return SigningKey{}, fmt.Errorf("unsupported private key type %T", private)
+	}
+
+	return SigningKey{ID: id, Method: method, Private: private}, nil
+}
+
+func (k SigningKey) Public() crypto.PublicKey {
+	return k.Private.Public()
+}
+
+// JWKS returns the public keys that tokens issued by the service may be
+// signed with. Retired keys stay published until they are removed from the
+// configuration, so tokens signed before a rotation remain verifiable.
+func (s *Service) JWKS() (jwks.Set, error) {
+	set := jwks.Set{Keys: make([]jwks.Key, 0, len(s.signingKeys))}
+	for _, key := range s.signingKeys {
+		jwk, err := jwks.NewKey(key.ID, key.Public())
+		if err != nil {
+			return jwks.Set{}, err
+		}
+		set.Keys = append(set.Keys, jwk)
+	}
+	return set, nil
+}
+
+// VerificationKey resolves the public key for a token by its kid header. It
+// is a jwt.Keyfunc, so the service can verify its own tokens without going
+// through the JWKS endpoint.
+func (s *Service) VerificationKey(token *jwt.Token) (interface{}, error) {
+	kid, _ := token.Header["kid"].(string)
+	for _, key := range s.signingKeys {
+		if key.ID != kid {
+			continue
+		}
+		if token.Method.Alg() != key.Method.Alg() {
+			return nil, fmt.Errorf("unexpected signing method %s for key %q", token.Method.Alg(), kid)
+		}
+		return key.Public(), nil
+	}
+	return nil, fmt.Errorf("unknown key %q", kid)
+}
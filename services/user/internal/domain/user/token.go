@@ -0,0 +1,60 @@
+package user
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"time"
+)
+
+// ErrRefreshTokenUsed is returned by the repository when a refresh token that
+// has already been rotated or revoked is marked as used again.
+var ErrRefreshTokenUsed = errors.New("refresh token already used")
+
+// RefreshToken is an opaque, single-use token exchanged for a new access
+// token. Only the SHA-256 hash of the token is persisted. Tokens issued by
+// rotating one another share a FamilyID, so a whole login session can be
+// revoked at once.
+type RefreshToken struct {
+	Hash      string     `bson:"_id"`
+	FamilyID  string     `bson:"familyId"`
+	Email     string     `bson:"email"`
+	ExpiresAt time.Time  `bson:"expiresAt"`
+	CreatedAt time.Time  `bson:"createdAt"`
+	RevokedAt *time.Time `bson:"revokedAt,omitempty"`
+}
+
+// Revoked reports whether the token was rotated, logged out or revoked
+func (t RefreshToken) Revoked() bool {
+	return t.RevokedAt != nil
+}
+
+// TokenPair is returned on login and refresh
+type TokenPair struct {
+	AccessToken  string
+	RefreshToken string
+	ExpiresIn    int // access token lifetime in seconds
+}
+
+func generateRefreshToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+func hashRefreshToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+func generateFamilyID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -2,8 +2,10 @@ package user
 
 // User represents a user in the system
 type User struct {
+	ID    string `bson:"id"`       // Token subject, referenced by other services
 	Email string `bson:"email"`    // Tags for MongoDB serialization
 	Hash  string `bson:"password"` // In production, this would be a hashed password
 	Salt  string
-	Name  string `bson:"name"`
+	Name  string   `bson:"name"`
+	Roles []string `bson:"roles"`
 }
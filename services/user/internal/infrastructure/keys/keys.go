@@ -0,0 +1,99 @@
+package keys
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"localloop/libs/pkg/jwks"
+	"localloop/services/user/internal/domain/user"
+)
+
+// LoadSigningKeys reads every *.pem private key in dir. The file name without
+// extension becomes the key ID, so rotating keys is a matter of adding a new
+// file and pointing the active key ID at it. Keys are returned sorted by ID.
+func LoadSigningKeys(dir string) ([]user.SigningKey, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.pem"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(paths)
+
+	signingKeys := make([]user.SigningKey, 0, len(paths))
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read key %s: %w", path, err)
+		}
+
+		signer, err := parsePrivateKey(data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse key %s: %w", path, err)
+		}
+
+		key, err := user.NewSigningKey(strings.TrimSuffix(filepath.Base(path), ".pem"), signer)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load key %s: %w", path, err)
+		}
+		signingKeys = append(signingKeys, key)
+	}
+
+	if len(signingKeys) == 0 {
+		return nil, fmt.Errorf("no *.pem keys found in %s", dir)
+	}
+	return signingKeys, nil
+}
+
+// GenerateSigningKey creates a throwaway key for the given algorithm. Tokens
+// signed with it become unverifiable once the process restarts.
+func GenerateSigningKey(alg string) (user.SigningKey, error) {
+	var signer crypto.Signer
+	var err error
+
+	switch alg {
+	case jwks.AlgEdDSA:
+		_, signer, err = ed25519.GenerateKey(rand.Reader)
+	case jwks.AlgRS256:
+		signer, err = rsa.GenerateKey(rand.Reader, 2048)
+	default:
+		return user.SigningKey{}, fmt.Errorf("unsupported signing algorithm %q", alg)
+	}
+	if err != nil {
+		return user.SigningKey{}, err
+	}
+
+	return user.NewSigningKey(fmt.Sprintf("ephemeral-%d", time.Now().Unix()), signer)
+}
+
+func parsePrivateKey(data []byte) (crypto.Signer, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("no PEM block found")
+	}
+
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		return x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "PRIVATE KEY":
+		key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		signer, ok := key.(crypto.Signer)
+		if !ok {
+			return nil, fmt.Errorf("unsupported private key type %T", key)
+		}
+		return signer, nil
+	default:
+		return nil, fmt.Errorf("unsupported PEM block type %q", block.Type)
+	}
+}
@@ -0,0 +1,85 @@
+package keys
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeKey(t *testing.T, dir, name, blockType string, der []byte) {
+	t.Helper()
+
+	data := pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der})
+	if err := os.WriteFile(filepath.Join(dir, name), data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLoadSigningKeys(t *testing.T) {
+	dir := t.TempDir()
+
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(edKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	writeKey(t, dir, "2024-02.pem", "PRIVATE KEY", der)
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	writeKey(t, dir, "2024-01.pem", "RSA PRIVATE KEY", x509.MarshalPKCS1PrivateKey(rsaKey))
+	writeKey(t, dir, "notes.txt", "NOTE", []byte("not a key"))
+
+	keys, err := LoadSigningKeys(dir)
+	if err != nil {
+		t.Fatalf("LoadSigningKeys() error = %v", err)
+	}
+	if len(keys) != 2 || keys[0].ID != "2024-01" || keys[1].ID != "2024-02" {
+		t.Fatalf("LoadSigningKeys() = %+v, want the keys 2024-01 and 2024-02", keys)
+	}
+	if alg := keys[0].Method.Alg(); alg != "RS256" {
+		t.Errorf("key 2024-01 signs with %s, want RS256", alg)
+	}
+	if alg := keys[1].Method.Alg(); alg != "EdDSA" {
+		t.Errorf("key 2024-02 signs with %s, want EdDSA", alg)
+	}
+}
+
+func TestLoadSigningKeysRejectsInvalidDirectories(t *testing.T) {
+	empty := t.TempDir()
+	if _, err := LoadSigningKeys(empty); err == nil {
+		t.Error("LoadSigningKeys() of an empty directory succeeded")
+	}
+
+	invalid := t.TempDir()
+	writeKey(t, invalid, "key.pem", "PUBLIC KEY", []byte("public"))
+	if _, err := LoadSigningKeys(invalid); err == nil {
+		t.Error("LoadSigningKeys() of a public key succeeded")
+	}
+}
+
+func TestGenerateSigningKey(t *testing.T) {
+	for _, alg := range []string{"EdDSA", "RS256"} {
+		key, err := GenerateSigningKey(alg)
+		if err != nil {
+			t.Fatalf("GenerateSigningKey(%s) error = %v", alg, err)
+		}
+		if key.Method.Alg() != alg {
+			t.Errorf("GenerateSigningKey(%s) signs with %s", alg, key.Method.Alg())
+		}
+	}
+
+	if _, err := GenerateSigningKey("HS256"); err == nil {
+		t.Error("GenerateSigningKey(HS256) succeeded")
+	}
+}
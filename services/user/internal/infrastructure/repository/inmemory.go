@@ -5,18 +5,21 @@ import (
 	"errors"
 	"localloop/services/user/internal/domain/user"
 	"sync"
+	"time"
 )
 
 // InMemoryRepository implements the Repository interface using a map for storage.
 type InMemoryRepository struct {
-	data map[string]user.User
-	mu   sync.RWMutex
+	data          map[string]user.User
+	refreshTokens map[string]user.RefreshToken
+	mu            sync.RWMutex
 }
 
 // NewInMemoryRepository creates a new instance of InMemoryRepository.
 func NewInMemoryRepository() *InMemoryRepository {
 	return &InMemoryRepository{
-		data: make(map[string]user.User),
+		data:          make(map[string]user.User),
+		refreshTokens: make(map[string]user.RefreshToken),
 	}
 }
 
@@ -56,6 +59,9 @@ func (r *InMemoryRepository) Update(ctx context.Context, email string, updates u
 		return errors.New("user not found")
 	}
 
+	if updates.ID != "" {
+		user.ID = updates.ID
+	}
 	if updates.Name != "" {
 		user.Name = updates.Name
 	}
@@ -63,7 +69,68 @@ func (r *InMemoryRepository) Update(ctx context.Context, email string, updates u
 		user.Hash = updates.Hash
 		user.Salt = updates.Salt
 	}
+	if updates.Roles != nil {
+		user.Roles = updates.Roles
+	}
 
 	r.data[email] = user
 	return nil
 }
+
+// CreateRefreshToken stores a new refresh token.
+func (r *InMemoryRepository) CreateRefreshToken(ctx context.Context, token user.RefreshToken) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.refreshTokens[token.Hash]; exists {
+		return errors.New("refresh token already exists")
+	}
+
+	r.refreshTokens[token.Hash] = token
+	return nil
+}
+
+// FindRefreshToken retrieves a refresh token by its hash.
+func (r *InMemoryRepository) FindRefreshToken(ctx context.Context, hash string) (user.RefreshToken, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	token, exists := r.refreshTokens[hash]
+	if !exists {
+		return user.RefreshToken{}, errors.New("refresh token not found")
+	}
+
+	return token, nil
+}
+
+// MarkRefreshTokenUsed revokes a single refresh token unless it already is.
+func (r *InMemoryRepository) MarkRefreshTokenUsed(ctx context.Context, hash string, at time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	token, exists := r.refreshTokens[hash]
+	if !exists {
+		return errors.New("refresh token not found")
+	}
+	if token.Revoked() {
+		return user.ErrRefreshTokenUsed
+	}
+
+	token.RevokedAt = &at
+	r.refreshTokens[hash] = token
+	return nil
+}
+
+// RevokeRefreshTokenFamily revokes every active token of a family.
+func (r *InMemoryRepository) RevokeRefreshTokenFamily(ctx context.Context, familyID string, at time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for hash, token := range r.refreshTokens {
+		if token.FamilyID == familyID && !token.Revoked() {
+			token.RevokedAt = &at
+			r.refreshTokens[hash] = token
+		}
+	}
+	return nil
+}
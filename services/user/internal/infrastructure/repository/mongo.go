@@ -3,24 +3,44 @@ package repository
 import (
 	"context"
 	"errors"
+	"time"
 
 	"localloop/services/user/internal/domain/user"
 
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 type MongoRepository struct {
-	collection *mongo.Collection
+	collection    *mongo.Collection
+	refreshTokens *mongo.Collection
 }
 
 // NewMongoRepository creates a new instance of MongoRepository
 func NewMongoRepository(db *mongo.Database) *MongoRepository {
 	return &MongoRepository{
-		collection: db.Collection("users"),
+		collection:    db.Collection("users"),
+		refreshTokens: db.Collection("refresh_tokens"),
 	}
 }
 
+// EnsureIndexes creates the indexes of the collections, if missing. Refresh
+// tokens are looked up by family on revocation and removed by MongoDB once
+// they expire.
+func (r *MongoRepository) EnsureIndexes(ctx context.Context) error {
+	_, err := r.refreshTokens.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "expiresAt", Value: 1}},
+			Options: options.Index().SetExpireAfterSeconds(0),
+		},
+		{
+			Keys: bson.D{{Key: "familyId", Value: 1}},
+		},
+	})
+	return err
+}
+
 func (r *MongoRepository) Create(ctx context.Context, user user.User) error {
 	_, err := r.collection.InsertOne(ctx, user)
 	return err
@@ -37,6 +57,9 @@ func (r *MongoRepository) FindByEmail(ctx context.Context, email string) (user.U
 
 func (r *MongoRepository) Update(ctx context.Context, email string, updates user.UpdateData) error {
 	updateData := bson.M{}
+	if updates.ID != "" {
+		updateData["id"] = updates.ID
+	}
 	if updates.Name != "" {
 		updateData["name"] = updates.Name
 	}
@@ -44,6 +67,9 @@ func (r *MongoRepository) Update(ctx context.Context, email string, updates user
 		updateData["password"] = updates.Hash
 		updateData["salt"] = updates.Salt
 	}
+	if updates.Roles != nil {
+		updateData["roles"] = updates.Roles
+	}
 
 	_, err := r.collection.UpdateOne(
 		ctx,
@@ -52,3 +78,42 @@ func (r *MongoRepository) Update(ctx context.Context, email string, updates user
 	)
 	return err
 }
+
+func (r *MongoRepository) CreateRefreshToken(ctx context.Context, token user.RefreshToken) error {
+	_, err := r.refreshTokens.InsertOne(ctx, token)
+	return err
+}
+
+func (r *MongoRepository) FindRefreshToken(ctx context.Context, hash string) (user.RefreshToken, error) {
+	var token user.RefreshToken
+	err := r.refreshTokens.FindOne(ctx, bson.M{"_id": hash}).Decode(&token)
+	if err == mongo.ErrNoDocuments {
+		return user.RefreshToken{}, errors.New("refresh token not found")
+	}
+	return token, err
+}
+
+func (r *MongoRepository) MarkRefreshTokenUsed(ctx context.Context, hash string, at time.Time) error {
+	// Matching on the missing revokedAt makes the rotation a compare-and-set
+	result, err := r.refreshTokens.UpdateOne(
+		ctx,
+		bson.M{"_id": hash, "revokedAt": bson.M{"$exists": false}},
+		bson.M{"$set": bson.M{"revokedAt": at}},
+	)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return user.ErrRefreshTokenUsed
+	}
+	return nil
+}
+
+func (r *MongoRepository) RevokeRefreshTokenFamily(ctx context.Context, familyID string, at time.Time) error {
+	_, err := r.refreshTokens.UpdateMany(
+		ctx,
+		bson.M{"familyId": familyID, "revokedAt": bson.M{"$exists": false}},
+		bson.M{"$set": bson.M{"revokedAt": at}},
+	)
+	return err
+}
@@ -4,6 +4,10 @@ import (
 	"encoding/json"
 	"net/http"
 
+	"github.com/gorilla/mux"
+
+	bh "localloop/libs/pkg/web/handler"
+	"localloop/libs/pkg/web/middleware"
 	"localloop/services/user/internal/domain/user"
 )
 
@@ -28,25 +32,28 @@ type LoginRequest struct {
 	Password string `json:"password"`
 }
 
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refreshToken"`
+}
+
 type ApiResponse struct {
-	Message string `json:"message,omitempty"`
-	Token   string `json:"token,omitempty"`
-	Error   string `json:"error,omitempty"`
+	Message      string `json:"message,omitempty"`
+	Token        string `json:"token,omitempty"`
+	RefreshToken string `json:"refreshToken,omitempty"`
+	ExpiresIn    int    `json:"expiresIn,omitempty"`
 }
 
 func (h *UserHandler) RegisterUser(w http.ResponseWriter, r *http.Request) {
 	var req RegisterRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		w.WriteHeader(http.StatusBadRequest)
-		json.NewEncoder(w).Encode(ApiResponse{Error: "Invalid request payload"})
+		bh.RespondWithError(w, http.StatusBadRequest, "Invalid request payload")
 		return
 	}
 	defer r.Body.Close()
 
 	err := h.userService.Register(req.Email, req.Password, req.Name)
 	if err != nil {
-		w.WriteHeader(http.StatusConflict)
-		json.NewEncoder(w).Encode(ApiResponse{Error: err.Error()})
+		bh.RespondWithAppError(w, r, err)
 		return
 	}
 
@@ -57,21 +64,80 @@ func (h *UserHandler) RegisterUser(w http.ResponseWriter, r *http.Request) {
 func (h *UserHandler) LoginUser(w http.ResponseWriter, r *http.Request) {
 	var req LoginRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		w.WriteHeader(http.StatusBadRequest)
-		json.NewEncoder(w).Encode(ApiResponse{Error: "Invalid request payload"})
+		bh.RespondWithError(w, http.StatusBadRequest, "Invalid request payload")
 		return
 	}
 	defer r.Body.Close()
 
-	token, err := h.userService.Login(req.Email, req.Password)
+	tokens, err := h.userService.Login(req.Email, req.Password)
 	if err != nil {
-		w.WriteHeader(http.StatusUnauthorized)
-		json.NewEncoder(w).Encode(ApiResponse{Error: err.Error()})
+		bh.RespondWithAppError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(ApiResponse{
+		Message:      "Login successful",
+		Token:        tokens.AccessToken,
+		RefreshToken: tokens.RefreshToken,
+		ExpiresIn:    tokens.ExpiresIn,
+	})
+}
+
+// RefreshToken rotates a refresh token and issues a new access token
+func (h *UserHandler) RefreshToken(w http.ResponseWriter, r *http.Request) {
+	var req RefreshTokenRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.RefreshToken == "" {
+		bh.RespondWithError(w, http.StatusBadRequest, "Invalid request payload")
+		return
+	}
+	defer r.Body.Close()
+
+	tokens, err := h.userService.Refresh(req.RefreshToken)
+	if err != nil {
+		bh.RespondWithAppError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(ApiResponse{
+		Message:      "Token refreshed",
+		Token:        tokens.AccessToken,
+		RefreshToken: tokens.RefreshToken,
+		ExpiresIn:    tokens.ExpiresIn,
+	})
+}
+
+// Logout revokes the refresh token family of the current session
+func (h *UserHandler) Logout(w http.ResponseWriter, r *http.Request) {
+	var req RefreshTokenRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.RefreshToken == "" {
+		bh.RespondWithError(w, http.StatusBadRequest, "Invalid request payload")
+		return
+	}
+	defer r.Body.Close()
+
+	if err := h.userService.Logout(req.RefreshToken); err != nil {
+		bh.RespondWithAppError(w, r, err)
 		return
 	}
 
 	w.WriteHeader(http.StatusOK)
-	json.NewEncoder(w).Encode(ApiResponse{Message: "Login successful", Token: token})
+	json.NewEncoder(w).Encode(ApiResponse{Message: "Logout successful"})
+}
+
+// GetJWKS publishes the public keys access tokens are signed with
+func (h *UserHandler) GetJWKS(w http.ResponseWriter, r *http.Request) {
+	set, err := h.userService.JWKS()
+	if err != nil {
+		bh.RespondWithAppError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "public, max-age=300")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(set)
 }
 
 func (h *UserHandler) GetUser(w http.ResponseWriter, r *http.Request) {
@@ -79,8 +145,7 @@ func (h *UserHandler) GetUser(w http.ResponseWriter, r *http.Request) {
 
 	user, err := h.userService.Get(email)
 	if err != nil {
-		w.WriteHeader(http.StatusNotFound)
-		json.NewEncoder(w).Encode(ApiResponse{Error: "User not found"})
+		bh.RespondWithAppError(w, r, err)
 		return
 	}
 
@@ -94,17 +159,16 @@ func (h *UserHandler) GetUser(w http.ResponseWriter, r *http.Request) {
 
 // GetCurrentUser retrieves the authenticated user's profile
 func (h *UserHandler) GetCurrentUser(w http.ResponseWriter, r *http.Request) {
-	// Get user from context (set by auth middleware)
-	user, ok := r.Context().Value("user").(user.User)
+	user, ok := h.currentUser(w, r)
 	if !ok {
-		w.WriteHeader(http.StatusUnauthorized)
-		json.NewEncoder(w).Encode(ApiResponse{Error: "Unauthorized"})
 		return
 	}
 
 	response := map[string]interface{}{
+		"id":    user.ID,
 		"email": user.Email,
 		"name":  user.Name,
+		"roles": user.EffectiveRoles(),
 	}
 
 	w.Header().Set("Content-Type", "application/json")
@@ -114,26 +178,21 @@ func (h *UserHandler) GetCurrentUser(w http.ResponseWriter, r *http.Request) {
 
 // UpdateCurrentUser updates the authenticated user's profile
 func (h *UserHandler) UpdateCurrentUser(w http.ResponseWriter, r *http.Request) {
-	// Get user from context
-	currentUser, ok := r.Context().Value("user").(user.User)
+	currentUser, ok := h.currentUser(w, r)
 	if !ok {
-		w.WriteHeader(http.StatusUnauthorized)
-		json.NewEncoder(w).Encode(ApiResponse{Error: "Unauthorized"})
 		return
 	}
 
 	var req UpdateUserRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		w.WriteHeader(http.StatusBadRequest)
-		json.NewEncoder(w).Encode(ApiResponse{Error: "Invalid request payload"})
+		bh.RespondWithError(w, http.StatusBadRequest, "Invalid request payload")
 		return
 	}
 	defer r.Body.Close()
 
 	err := h.userService.Update(currentUser.Email, req.Name, req.Password)
 	if err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
-		json.NewEncoder(w).Encode(ApiResponse{Error: "Failed to update user"})
+		bh.RespondWithAppError(w, r, err)
 		return
 	}
 
@@ -142,6 +201,56 @@ func (h *UserHandler) UpdateCurrentUser(w http.ResponseWriter, r *http.Request)
 	json.NewEncoder(w).Encode(ApiResponse{Message: "User updated successfully"})
 }
 
+// SetUserRoles replaces the roles of the user identified by the email path
+// parameter
+func (h *UserHandler) SetUserRoles(w http.ResponseWriter, r *http.Request) {
+	var req SetRolesRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		bh.RespondWithError(w, http.StatusBadRequest, "Invalid request payload")
+		return
+	}
+	defer r.Body.Close()
+
+	updated, err := h.userService.SetRoles(mux.Vars(r)["email"], req.Roles)
+	if err != nil {
+		bh.RespondWithAppError(w, r, err)
+		return
+	}
+
+	response := map[string]interface{}{
+		"id":    updated.ID,
+		"email": updated.Email,
+		"name":  updated.Name,
+		"roles": updated.EffectiveRoles(),
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(response)
+}
+
+type SetRolesRequest struct {
+	Roles []string `json:"roles"`
+}
+
+// currentUser loads the user identified by the token claims set by the auth
+// middleware. The user may have been removed after the token was issued.
+func (h *UserHandler) currentUser(w http.ResponseWriter, r *http.Request) (user.User, bool) {
+	claims, ok := middleware.ClaimsFromContext(r.Context())
+	if !ok {
+		bh.RespondWithError(w, http.StatusUnauthorized, "Unauthorized")
+		return user.User{}, false
+	}
+
+	current, err := h.userService.Get(claims.Email)
+	if err != nil {
+		bh.RespondWithError(w, http.StatusUnauthorized, "Unauthorized - User not found")
+		return user.User{}, false
+	}
+
+	return current, true
+}
+
 type UpdateUserRequest struct {
 	Name     string `json:"name,omitempty"`
 	Password string `json:"password,omitempty"`
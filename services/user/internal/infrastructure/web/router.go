@@ -1,7 +1,10 @@
 package web
 
 import (
+	"net/http"
+
 	"localloop/libs/pkg/web"
+	"localloop/libs/pkg/web/middleware"
 	"localloop/services/user/internal/domain/user"
 	"localloop/services/user/internal/infrastructure/web/handler"
 )
@@ -26,17 +29,25 @@ func NewUserManagementServer(userService *user.Service) *UserManagementServer {
 func (s *UserManagementServer) setupRoutes() {
 	router := s.Router
 
-	// Initialize handler and authenticator
+	// Initialize handler and authenticator, the service verifies its own
+	// tokens without going through the JWKS endpoint
 	userHandler := handler.NewUserHandler(s.userService)
-	authenticator := NewAuthenticator(s.userService)
+	authenticator := middleware.NewAuthenticator(s.userService.VerificationKey)
 
 	// Public routes
+	router.HandleFunc("/.well-known/jwks.json", userHandler.GetJWKS).Methods("GET")
 	router.HandleFunc("/users/register", userHandler.RegisterUser).Methods("POST")
 	router.HandleFunc("/users/login", userHandler.LoginUser).Methods("POST")
+	router.HandleFunc("/users/token/refresh", userHandler.RefreshToken).Methods("POST")
+	router.HandleFunc("/users/logout", userHandler.Logout).Methods("POST")
 
 	// Authenticated routes
 	authRouter := router.PathPrefix("/users").Subrouter()
-	authRouter.Use(authenticator.AuthMiddleware)
+	authRouter.Use(authenticator.Authenticate)
 	authRouter.HandleFunc("/me", userHandler.GetCurrentUser).Methods("GET")
 	authRouter.HandleFunc("/me", userHandler.UpdateCurrentUser).Methods("PUT")
+
+	// Admin routes
+	requireAdmin := middleware.RequireRole(user.RoleAdmin)
+	authRouter.Handle("/{email}/roles", requireAdmin(http.HandlerFunc(userHandler.SetUserRoles))).Methods("PUT")
 }
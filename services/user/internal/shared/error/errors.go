@@ -10,6 +10,10 @@ var (
 	ErrTokenExpired       = errorbuilder.NewError("token has expired", errorbuilder.ErrAuth)
 	ErrInvalidToken       = errorbuilder.NewError("invalid token", errorbuilder.ErrAuth)
 
+	ErrInvalidRefreshToken = errorbuilder.NewError("invalid refresh token", errorbuilder.ErrAuth)
+	ErrRefreshTokenExpired = errorbuilder.NewError("refresh token has expired", errorbuilder.ErrAuth)
+	ErrRefreshTokenReused  = errorbuilder.NewError("refresh token reuse detected", errorbuilder.ErrAuth)
+
 	// Resource errors
 	ErrUserNotFound = errorbuilder.NewError("user not found", errorbuilder.ErrNotFound)
 	ErrUserExists   = errorbuilder.NewError("user already exists", errorbuilder.ErrConflict)
@@ -19,11 +23,13 @@ var (
 	ErrInvalidEmail    = errorbuilder.NewError("invalid email format", errorbuilder.ErrValidation)
 	ErrInvalidPassword = errorbuilder.NewError("invalid password format", errorbuilder.ErrValidation)
 	ErrInvalidName     = errorbuilder.NewError("invalid name format", errorbuilder.ErrValidation)
+	ErrInvalidRole     = errorbuilder.NewError("invalid role", errorbuilder.ErrValidation)
 
 	// Internal errors
 	ErrHashingPassword   = errorbuilder.NewError("failed to hash password", errorbuilder.ErrInternal)
 	ErrGeneratingSalt    = errorbuilder.NewError("failed to generate salt", errorbuilder.ErrInternal)
 	ErrDatabaseOperation = errorbuilder.NewError("database operation failed", errorbuilder.ErrInternal)
+	ErrGeneratingToken   = errorbuilder.NewError("failed to generate token", errorbuilder.ErrInternal)
 )
 
 func WithUser(email string) errorbuilder.ErrorOption {
@@ -1,7 +1,9 @@
 package main
 
 import (
+	"context"
 	"log"
+	"os"
 
 	app "localloop/services/user/internal"
 	"localloop/services/user/internal/config"
@@ -10,12 +12,27 @@ import (
 func main() {
 	cfg := config.Load()
 
-	userManagementApp, err := app.NewApp(
-		cfg,
-		app.WithInMemoryUserRepository(),
+	// The server and the grant-role command share the user repository
+	opts := []app.Option{
+		app.WithMongoDatabase(),
+		app.WithMongoUserRepositroy(),
 		app.WithUserService(),
-		app.WithWebServer(),
-	)
+	}
+
+	if len(os.Args) > 1 && os.Args[1] == "grant-role" {
+		bootstrapApp, err := app.NewApp(cfg, opts...)
+		if err != nil {
+			log.Fatal("Error initializing the app:", err)
+		}
+		defer bootstrapApp.MongoClient.Disconnect(context.Background())
+
+		if err := bootstrapApp.GrantRole(os.Args[2:]); err != nil {
+			log.Fatal("Granting the role failed:", err)
+		}
+		return
+	}
+
+	userManagementApp, err := app.NewApp(cfg, append(opts, app.WithWebServer())...)
 
 	if err != nil {
 		log.Fatal("Error initializing the app:", err)
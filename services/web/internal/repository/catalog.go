@@ -5,6 +5,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/url"
+	"strconv"
 
 	"localloop/libs/pkg/errorbuilder"
 	apperror "localloop/services/web/internal/shared/error"
@@ -18,6 +20,9 @@ type Category struct {
 	Description string     `json:"description"`
 	ParentID    *uuid.UUID `json:"parentId"`
 	ParentName  string     `json:"parentName,omitempty"`
+	// ETag is the version GetCategory read, which updates and deletes are
+	// conditional on
+	ETag string `json:"-"`
 }
 
 type createCategoryRequest struct {
@@ -36,8 +41,8 @@ type CatalogRepository interface {
 	ListCategories() ([]Category, error)
 	GetCategory(id uuid.UUID) (*Category, error)
 	CreateCategory(name, description string, parentID *uuid.UUID) error
-	UpdateCategory(id uuid.UUID, name, description string, parentID *uuid.UUID) error
-	DeleteCategory(id uuid.UUID) error
+	UpdateCategory(id uuid.UUID, etag, name, description string, parentID *uuid.UUID) error
+	DeleteCategory(id uuid.UUID, etag string) error
 }
 
 type catalogRepository struct {
@@ -55,31 +60,124 @@ func NewCatalogRepository(baseURL string) *catalogRepository {
 type apiResponse struct {
 	Message string          `json:"message,omitempty"`
 	Data    json.RawMessage `json:"data,omitempty"`
-	Error   string          `json:"error,omitempty"`
+	Meta    listMeta        `json:"meta,omitempty"`
 }
 
-func (r *catalogRepository) ListCategories() ([]Category, error) {
-	resp, err := r.client.Get(r.baseURL + "/categories")
-	if err != nil {
-		return nil, fmt.Errorf("failed to send request: %w", err)
+type listMeta struct {
+	NextCursor string `json:"nextCursor,omitempty"`
+}
+
+// listPageSize is the largest page the catalog service serves
+const listPageSize = 100
+
+// decodeResponse reads the data envelope of a catalog response into out. A nil
+// out discards the payload. Failed responses are read as problem details.
+func decodeResponse(resp *http.Response, out any) error {
+	_, err := decodePage(resp, out)
+	return err
+}
+
+// decodePage is decodeResponse for list responses, which also carry the
+// cursor of the next page
+func decodePage(resp *http.Response, out any) (listMeta, error) {
+	if resp.StatusCode >= http.StatusBadRequest {
+		return listMeta{}, catalogError(errorbuilder.FromResponse(resp))
 	}
-	defer resp.Body.Close()
 
 	var apiResp apiResponse
 	if err := json.NewDecoder(resp.Body).Decode(&apiResp); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
+		return listMeta{}, apperror.ErrInvalidJSON(
+			errorbuilder.WithOriginal(err),
+		)
+	}
+
+	if out == nil || len(apiResp.Data) == 0 {
+		return apiResp.Meta, nil
+	}
+
+	if err := json.Unmarshal(apiResp.Data, out); err != nil {
+		return listMeta{}, apperror.ErrInvalidJSON(
+			errorbuilder.WithOriginal(err),
+		)
+	}
+
+	return apiResp.Meta, nil
+}
+
+// catalogError converts a problem reported by the catalog service into an
+// application error carrying the same code, so handlers can branch on it.
+func catalogError(problem *errorbuilder.CustomError) error {
+	context := map[string]any{
+		"service": "catalog",
+		"error":   problem.Message,
+	}
+	if problem.Detail != "" {
+		context["detail"] = problem.Detail
+	}
+	for key, value := range problem.Context {
+		context[key] = value
+	}
+	opts := []errorbuilder.ErrorOption{
+		errorbuilder.WithContext(context),
+		errorbuilder.WithOriginal(problem),
+	}
+
+	switch problem.Code {
+	case errorbuilder.ErrValidation:
+		return apperror.ErrInvalidRequest(opts...)
+	case errorbuilder.ErrAuth:
+		return apperror.ErrUnauthorized(opts...)
+	case errorbuilder.ErrForbidden:
+		return apperror.ErrForbidden(opts...)
+	case errorbuilder.ErrNotFound:
+		return apperror.ErrCategoryNotFound(opts...)
+	case errorbuilder.ErrConflict:
+		return apperror.ErrConflict(opts...)
+	case errorbuilder.ErrPreconditionFailed:
+		return apperror.ErrStaleResource(opts...)
+	default:
+		return apperror.ErrCatalogService(opts...)
+	}
+}
+
+// ListCategories fetches every category, following the pagination cursors
+// of the catalog service
+func (r *catalogRepository) ListCategories() ([]Category, error) {
+	var categories []Category
+	cursor := ""
+	for {
+		page, next, err := r.listCategoryPage(cursor)
+		if err != nil {
+			return nil, err
+		}
+		categories = append(categories, page...)
+
+		if next == "" {
+			return categories, nil
+		}
+		cursor = next
+	}
+}
+
+func (r *catalogRepository) listCategoryPage(cursor string) ([]Category, string, error) {
+	query := url.Values{"limit": {strconv.Itoa(listPageSize)}}
+	if cursor != "" {
+		query.Set("cursor", cursor)
 	}
 
-	if apiResp.Error != "" {
-		return nil, fmt.Errorf(apiResp.Error)
+	resp, err := r.client.Get(r.baseURL + "/categories?" + query.Encode())
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to send request: %w", err)
 	}
+	defer resp.Body.Close()
 
 	var categories []Category
-	if err := json.Unmarshal(apiResp.Data, &categories); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal categories: %w", err)
+	meta, err := decodePage(resp, &categories)
+	if err != nil {
+		return nil, "", err
 	}
 
-	return categories, nil
+	return categories, meta.NextCursor, nil
 }
 
 func (r *catalogRepository) GetCategory(id uuid.UUID) (*Category, error) {
@@ -92,33 +190,17 @@ func (r *catalogRepository) GetCategory(id uuid.UUID) (*Category, error) {
 	}
 	defer resp.Body.Close()
 
-	var apiResp apiResponse
-	if err := json.NewDecoder(resp.Body).Decode(&apiResp); err != nil {
-		return nil, apperror.ErrInvalidJSON(
-			errorbuilder.WithOriginal(err),
-		)
-	}
-
-	if apiResp.Error != "" {
-		if resp.StatusCode == http.StatusNotFound {
-			return nil, apperror.ErrCategoryNotFound(
-				apperror.WithResource("category", id.String()),
-			)
-		}
-		return nil, apperror.ErrCatalogService(
-			apperror.WithService("catalog"),
-			errorbuilder.WithContext(map[string]any{
-				"error": apiResp.Error,
-			}),
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, apperror.ErrCategoryNotFound(
+			apperror.WithResource("category", id.String()),
 		)
 	}
 
 	var category Category
-	if err := json.Unmarshal(apiResp.Data, &category); err != nil {
-		return nil, apperror.ErrInvalidJSON(
-			errorbuilder.WithOriginal(err),
-		)
+	if err := decodeResponse(resp, &category); err != nil {
+		return nil, err
 	}
+	category.ETag = resp.Header.Get("ETag")
 
 	return &category, nil
 }
@@ -145,19 +227,12 @@ func (r *catalogRepository) CreateCategory(name, description string, parentID *u
 	}
 	defer resp.Body.Close()
 
-	var apiResp apiResponse
-	if err := json.NewDecoder(resp.Body).Decode(&apiResp); err != nil {
-		return fmt.Errorf("failed to decode response: %w", err)
-	}
-
-	if apiResp.Error != "" {
-		return fmt.Errorf(apiResp.Error)
-	}
-
-	return nil
+	return decodeResponse(resp, nil)
 }
 
-func (r *catalogRepository) UpdateCategory(id uuid.UUID, name, description string, parentID *uuid.UUID) error {
+// UpdateCategory replaces a category, provided it is still at the version
+// etag names
+func (r *catalogRepository) UpdateCategory(id uuid.UUID, etag, name, description string, parentID *uuid.UUID) error {
 	reqBody := updateCategoryRequest{
 		Name:        name,
 		Description: description,
@@ -178,6 +253,7 @@ func (r *catalogRepository) UpdateCategory(id uuid.UUID, name, description strin
 		return fmt.Errorf("failed to create request: %w", err)
 	}
 	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("If-Match", etag)
 
 	resp, err := r.client.Do(req)
 	if err != nil {
@@ -185,19 +261,12 @@ func (r *catalogRepository) UpdateCategory(id uuid.UUID, name, description strin
 	}
 	defer resp.Body.Close()
 
-	var apiResp apiResponse
-	if err := json.NewDecoder(resp.Body).Decode(&apiResp); err != nil {
-		return fmt.Errorf("failed to decode response: %w", err)
-	}
-
-	if apiResp.Error != "" {
-		return fmt.Errorf(apiResp.Error)
-	}
-
-	return nil
+	return decodeResponse(resp, nil)
 }
 
-func (r *catalogRepository) DeleteCategory(id uuid.UUID) error {
+// DeleteCategory deletes a category, provided it is still at the version
+// etag names
+func (r *catalogRepository) DeleteCategory(id uuid.UUID, etag string) error {
 	req, err := http.NewRequest(
 		http.MethodDelete,
 		fmt.Sprintf("%s/categories/%s", r.baseURL, id),
@@ -206,6 +275,7 @@ func (r *catalogRepository) DeleteCategory(id uuid.UUID) error {
 	if err != nil {
 		return fmt.Errorf("failed to create request: %w", err)
 	}
+	req.Header.Set("If-Match", etag)
 
 	resp, err := r.client.Do(req)
 	if err != nil {
@@ -213,14 +283,5 @@ func (r *catalogRepository) DeleteCategory(id uuid.UUID) error {
 	}
 	defer resp.Body.Close()
 
-	var apiResp apiResponse
-	if err := json.NewDecoder(resp.Body).Decode(&apiResp); err != nil {
-		return fmt.Errorf("failed to decode response: %w", err)
-	}
-
-	if apiResp.Error != "" {
-		return fmt.Errorf(apiResp.Error)
-	}
-
-	return nil
+	return decodeResponse(resp, nil)
 }
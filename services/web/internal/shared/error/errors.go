@@ -9,11 +9,14 @@ var (
 	ErrInvalidSession = errorbuilder.NewError("invalid session", errorbuilder.ErrAuth)
 	ErrSessionExpired = errorbuilder.NewError("session has expired", errorbuilder.ErrAuth)
 	ErrUnauthorized   = errorbuilder.NewError("unauthorized access", errorbuilder.ErrAuth)
+	ErrForbidden      = errorbuilder.NewError("access forbidden", errorbuilder.ErrForbidden)
 
 	// Resource errors
 	ErrCategoryNotFound = errorbuilder.NewError("category not found", errorbuilder.ErrNotFound)
 	ErrListingNotFound  = errorbuilder.NewError("listing not found", errorbuilder.ErrNotFound)
 	ErrUserNotFound     = errorbuilder.NewError("user not found", errorbuilder.ErrNotFound)
+	ErrConflict         = errorbuilder.NewError("resource conflict", errorbuilder.ErrConflict)
+	ErrStaleResource    = errorbuilder.NewError("resource was modified", errorbuilder.ErrPreconditionFailed)
 
 	// Service errors
 	ErrCatalogService = errorbuilder.NewError("catalog service error", errorbuilder.ErrInternal)